@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strings"
 
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
 	"github.com/noders-team/go-daml/pkg/codec"
 	"github.com/noders-team/go-daml/pkg/model"
 	. "github.com/noders-team/go-daml/pkg/types"
@@ -15,6 +16,7 @@ var (
 	_ = errors.New
 	_ = big.NewInt
 	_ = strings.NewReader
+	_ = (*v2.Value)(nil)
 )
 
 const SDKVersion = "3.3.0-snapshot.20250507.0"
@@ -52,6 +54,17 @@ func argsToMap(args interface{}) map[string]interface{} {
 // Accept is a Record type
 type Accept struct{}
 
+// ToValue converts Accept to a Ledger API value
+func (t Accept) ToValue() (*v2.Value, error) {
+	return codec.RecordValue(), nil
+}
+
+// FromValue sets Accept from a Ledger API value
+func (t *Accept) FromValue(pb *v2.Value) error {
+	_, err := codec.RecordFieldsFromValue(pb)
+	return err
+}
+
 // ToMap converts Accept to a map for DAML arguments
 func (t Accept) ToMap() map[string]interface{} {
 	m := make(map[string]interface{})
@@ -102,13 +115,63 @@ func (e *Color) UnmarshalJSON(data []byte) error {
 	return jsonCodec.Unmarshall(data, e)
 }
 
+// ToValue converts Color to a Ledger API value
+func (e Color) ToValue() (*v2.Value, error) {
+	return codec.EnumValue(string(e)), nil
+}
+
+// FromValue sets Color from a Ledger API value
+func (e *Color) FromValue(pb *v2.Value) error {
+	constructor, err := codec.EnumFromValue(pb)
+	if err != nil {
+		return err
+	}
+	switch Color(constructor) {
+	case ColorRed, ColorGreen, ColorBlue:
+		*e = Color(constructor)
+		return nil
+	}
+	return fmt.Errorf("unknown Color constructor %s", constructor)
+}
+
 // Verify interface implementation
 var _ ENUM = Color("")
 
 // MappyContract is a Template type
 type MappyContract struct {
-	Operator PARTY   `json:"operator"`
-	Value    TEXTMAP `json:"value"`
+	Operator PARTY         `json:"operator"`
+	Value    TextMap[TEXT] `json:"value"`
+}
+
+// ToValue converts MappyContract to a Ledger API value
+func (t MappyContract) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 2)
+	if values[0], err = codec.PartyValue(t.Operator); err != nil {
+		return nil, fmt.Errorf("field operator: %w", err)
+	}
+	if values[1], err = codec.TextMapValue(t.Value, codec.TextValue); err != nil {
+		return nil, fmt.Errorf("field value: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("operator", values[0]),
+		codec.RecordField("value", values[1]),
+	), nil
+}
+
+// FromValue sets MappyContract from a Ledger API value
+func (t *MappyContract) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "operator", "value")
+	if err != nil {
+		return err
+	}
+	if t.Operator, err = codec.PartyFromValue(fields[0]); err != nil {
+		return fmt.Errorf("field operator: %w", err)
+	}
+	if t.Value, err = codec.TextMapFromValue(fields[1], codec.TextFromValue); err != nil {
+		return fmt.Errorf("field value: %w", err)
+	}
+	return nil
 }
 
 // GetTemplateID returns the template ID for this template
@@ -122,9 +185,7 @@ func (t MappyContract) CreateCommand() *model.CreateCommand {
 
 	args["operator"] = t.Operator.ToMap()
 
-	if t.Value != nil && len(t.Value) > 0 {
-		args["value"] = map[string]interface{}{"_type": "textmap", "value": t.Value}
-	}
+	args["value"] = t.Value
 
 	return &model.CreateCommand{
 		TemplateID: t.GetTemplateID(),
@@ -162,6 +223,37 @@ type MyPair struct {
 	Right interface{} `json:"right"`
 }
 
+// ToValue converts MyPair to a Ledger API value
+func (t MyPair) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 2)
+	if values[0], err = codec.DynamicValue(t.Left); err != nil {
+		return nil, fmt.Errorf("field left: %w", err)
+	}
+	if values[1], err = codec.DynamicValue(t.Right); err != nil {
+		return nil, fmt.Errorf("field right: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("left", values[0]),
+		codec.RecordField("right", values[1]),
+	), nil
+}
+
+// FromValue sets MyPair from a Ledger API value
+func (t *MyPair) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "left", "right")
+	if err != nil {
+		return err
+	}
+	if t.Left, err = codec.DynamicFromValue[interface{}](fields[0]); err != nil {
+		return fmt.Errorf("field left: %w", err)
+	}
+	if t.Right, err = codec.DynamicFromValue[interface{}](fields[1]); err != nil {
+		return fmt.Errorf("field right: %w", err)
+	}
+	return nil
+}
+
 // ToMap converts MyPair to a map for DAML arguments
 func (t MyPair) ToMap() map[string]interface{} {
 	m := make(map[string]interface{})
@@ -205,6 +297,135 @@ type OneOfEverything struct {
 	TheUnit         UNIT      `json:"theUnit"`
 }
 
+// ToValue converts OneOfEverything to a Ledger API value
+func (t OneOfEverything) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 16)
+	if values[0], err = codec.PartyValue(t.Operator); err != nil {
+		return nil, fmt.Errorf("field operator: %w", err)
+	}
+	if values[1], err = codec.BoolValue(t.SomeBoolean); err != nil {
+		return nil, fmt.Errorf("field someBoolean: %w", err)
+	}
+	if values[2], err = codec.Int64Value(t.SomeInteger); err != nil {
+		return nil, fmt.Errorf("field someInteger: %w", err)
+	}
+	if values[3], err = codec.NumericValue(t.SomeDecimal); err != nil {
+		return nil, fmt.Errorf("field someDecimal: %w", err)
+	}
+	if values[4], err = codec.OptionalValue(t.SomeMaybe, codec.Int64Value); err != nil {
+		return nil, fmt.Errorf("field someMaybe: %w", err)
+	}
+	if values[5], err = codec.OptionalValue(t.SomeMaybeNot, codec.Int64Value); err != nil {
+		return nil, fmt.Errorf("field someMaybeNot: %w", err)
+	}
+	if values[6], err = codec.TextValue(t.SomeText); err != nil {
+		return nil, fmt.Errorf("field someText: %w", err)
+	}
+	if values[7], err = codec.DateValue(t.SomeDate); err != nil {
+		return nil, fmt.Errorf("field someDate: %w", err)
+	}
+	if values[8], err = codec.TimestampValue(t.SomeDatetime); err != nil {
+		return nil, fmt.Errorf("field someDatetime: %w", err)
+	}
+	if values[9], err = codec.ListValue(t.SomeSimpleList, codec.Int64Value); err != nil {
+		return nil, fmt.Errorf("field someSimpleList: %w", err)
+	}
+	if values[10], err = t.SomeSimplePair.ToValue(); err != nil {
+		return nil, fmt.Errorf("field someSimplePair: %w", err)
+	}
+	if values[11], err = t.SomeNestedPair.ToValue(); err != nil {
+		return nil, fmt.Errorf("field someNestedPair: %w", err)
+	}
+	if values[12], err = t.SomeUglyNesting.ToValue(); err != nil {
+		return nil, fmt.Errorf("field someUglyNesting: %w", err)
+	}
+	if values[13], err = codec.NumericValue(t.SomeMeasurement); err != nil {
+		return nil, fmt.Errorf("field someMeasurement: %w", err)
+	}
+	if values[14], err = t.SomeEnum.ToValue(); err != nil {
+		return nil, fmt.Errorf("field someEnum: %w", err)
+	}
+	if values[15], err = codec.UnitValue(t.TheUnit); err != nil {
+		return nil, fmt.Errorf("field theUnit: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("operator", values[0]),
+		codec.RecordField("someBoolean", values[1]),
+		codec.RecordField("someInteger", values[2]),
+		codec.RecordField("someDecimal", values[3]),
+		codec.RecordField("someMaybe", values[4]),
+		codec.RecordField("someMaybeNot", values[5]),
+		codec.RecordField("someText", values[6]),
+		codec.RecordField("someDate", values[7]),
+		codec.RecordField("someDatetime", values[8]),
+		codec.RecordField("someSimpleList", values[9]),
+		codec.RecordField("someSimplePair", values[10]),
+		codec.RecordField("someNestedPair", values[11]),
+		codec.RecordField("someUglyNesting", values[12]),
+		codec.RecordField("someMeasurement", values[13]),
+		codec.RecordField("someEnum", values[14]),
+		codec.RecordField("theUnit", values[15]),
+	), nil
+}
+
+// FromValue sets OneOfEverything from a Ledger API value
+func (t *OneOfEverything) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "operator", "someBoolean", "someInteger", "someDecimal", "someMaybe", "someMaybeNot", "someText", "someDate", "someDatetime", "someSimpleList", "someSimplePair", "someNestedPair", "someUglyNesting", "someMeasurement", "someEnum", "theUnit")
+	if err != nil {
+		return err
+	}
+	if t.Operator, err = codec.PartyFromValue(fields[0]); err != nil {
+		return fmt.Errorf("field operator: %w", err)
+	}
+	if t.SomeBoolean, err = codec.BoolFromValue(fields[1]); err != nil {
+		return fmt.Errorf("field someBoolean: %w", err)
+	}
+	if t.SomeInteger, err = codec.Int64FromValue(fields[2]); err != nil {
+		return fmt.Errorf("field someInteger: %w", err)
+	}
+	if t.SomeDecimal, err = codec.NumericFromValue(fields[3]); err != nil {
+		return fmt.Errorf("field someDecimal: %w", err)
+	}
+	if t.SomeMaybe, err = codec.OptionalFromValue(fields[4], codec.Int64FromValue); err != nil {
+		return fmt.Errorf("field someMaybe: %w", err)
+	}
+	if t.SomeMaybeNot, err = codec.OptionalFromValue(fields[5], codec.Int64FromValue); err != nil {
+		return fmt.Errorf("field someMaybeNot: %w", err)
+	}
+	if t.SomeText, err = codec.TextFromValue(fields[6]); err != nil {
+		return fmt.Errorf("field someText: %w", err)
+	}
+	if t.SomeDate, err = codec.DateFromValue(fields[7]); err != nil {
+		return fmt.Errorf("field someDate: %w", err)
+	}
+	if t.SomeDatetime, err = codec.TimestampFromValue(fields[8]); err != nil {
+		return fmt.Errorf("field someDatetime: %w", err)
+	}
+	if t.SomeSimpleList, err = codec.ListFromValue(fields[9], codec.Int64FromValue); err != nil {
+		return fmt.Errorf("field someSimpleList: %w", err)
+	}
+	if t.SomeSimplePair, err = codec.GeneratedFromValue[MyPair](fields[10]); err != nil {
+		return fmt.Errorf("field someSimplePair: %w", err)
+	}
+	if t.SomeNestedPair, err = codec.GeneratedFromValue[MyPair](fields[11]); err != nil {
+		return fmt.Errorf("field someNestedPair: %w", err)
+	}
+	if t.SomeUglyNesting, err = codec.GeneratedFromValue[VPair](fields[12]); err != nil {
+		return fmt.Errorf("field someUglyNesting: %w", err)
+	}
+	if t.SomeMeasurement, err = codec.NumericFromValue(fields[13]); err != nil {
+		return fmt.Errorf("field someMeasurement: %w", err)
+	}
+	if t.SomeEnum, err = codec.GeneratedFromValue[Color](fields[14]); err != nil {
+		return fmt.Errorf("field someEnum: %w", err)
+	}
+	if t.TheUnit, err = codec.UnitFromValue(fields[15]); err != nil {
+		return fmt.Errorf("field theUnit: %w", err)
+	}
+	return nil
+}
+
 // GetTemplateID returns the template ID for this template
 func (t OneOfEverything) GetTemplateID() string {
 	return fmt.Sprintf("#%s:%s:%s", packageName, "AllKindsOf", "OneOfEverything")
@@ -220,8 +441,8 @@ func (t OneOfEverything) CreateCommand() *model.CreateCommand {
 
 	args["someInteger"] = int64(t.SomeInteger)
 
-	if t.SomeDecimal != "" {
-		args["someDecimal"] = string(t.SomeDecimal)
+	if !t.SomeDecimal.IsZero() {
+		args["someDecimal"] = t.SomeDecimal
 	}
 
 	if t.SomeMaybe != nil {
@@ -260,8 +481,8 @@ func (t OneOfEverything) CreateCommand() *model.CreateCommand {
 
 	args["someUglyNesting"] = t.SomeUglyNesting
 
-	if t.SomeMeasurement != "" {
-		args["someMeasurement"] = string(t.SomeMeasurement)
+	if !t.SomeMeasurement.IsZero() {
+		args["someMeasurement"] = t.SomeMeasurement
 	}
 
 	if t.SomeEnum != "" {
@@ -363,5 +584,63 @@ func (v VPair) GetVariantValue() interface{} {
 	return nil
 }
 
+// ToValue converts VPair to a Ledger API value
+func (v VPair) ToValue() (*v2.Value, error) {
+	if v.Left != nil {
+		value, err := codec.DynamicValue(*v.Left)
+		if err != nil {
+			return nil, fmt.Errorf("variant Left: %w", err)
+		}
+		return codec.VariantValue("Left", value), nil
+	}
+	if v.Right != nil {
+		value, err := codec.DynamicValue(*v.Right)
+		if err != nil {
+			return nil, fmt.Errorf("variant Right: %w", err)
+		}
+		return codec.VariantValue("Right", value), nil
+	}
+	if v.Both != nil {
+		value, err := (*v.Both).ToValue()
+		if err != nil {
+			return nil, fmt.Errorf("variant Both: %w", err)
+		}
+		return codec.VariantValue("Both", value), nil
+	}
+	return nil, fmt.Errorf("no VPair constructor is set")
+}
+
+// FromValue sets VPair from a Ledger API value
+func (v *VPair) FromValue(pb *v2.Value) error {
+	constructor, payload, err := codec.VariantFromValue(pb)
+	if err != nil {
+		return err
+	}
+	*v = VPair{}
+	switch constructor {
+	case "Left":
+		value, err := codec.DynamicFromValue[interface{}](payload)
+		if err != nil {
+			return fmt.Errorf("variant Left: %w", err)
+		}
+		v.Left = &value
+	case "Right":
+		value, err := codec.DynamicFromValue[interface{}](payload)
+		if err != nil {
+			return fmt.Errorf("variant Right: %w", err)
+		}
+		v.Right = &value
+	case "Both":
+		value, err := codec.GeneratedFromValue[VPair](payload)
+		if err != nil {
+			return fmt.Errorf("variant Both: %w", err)
+		}
+		v.Both = &value
+	default:
+		return fmt.Errorf("unknown VPair constructor %s", constructor)
+	}
+	return nil
+}
+
 // Verify interface implementation
 var _ VARIANT = (*VPair)(nil)
@@ -13,6 +13,7 @@ import (
 	"github.com/noders-team/go-daml/pkg/errors"
 	"github.com/noders-team/go-daml/pkg/model"
 	"github.com/noders-team/go-daml/pkg/service/ledger"
+	"github.com/noders-team/go-daml/pkg/testutil"
 	. "github.com/noders-team/go-daml/pkg/types"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -26,6 +27,7 @@ const (
 )
 
 func TestCodegenIntegration(t *testing.T) {
+	testutil.RequireClient(t)
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
@@ -87,7 +89,7 @@ func TestCodegenIntegration(t *testing.T) {
 
 	mappyContract := MappyContract{
 		Operator: PARTY(party),
-		Value: TEXTMAP{
+		Value: TextMap[TEXT]{
 			"key1": "value1",
 			"key2": "value2",
 		},
@@ -189,14 +191,15 @@ func TestCodegenIntegration(t *testing.T) {
 
 			require.Equal(t, PARTY(party), contract.Operator, "operator should match")
 			require.NotNil(t, contract.Value, "value should not be nil")
-			require.Equal(t, "value1", contract.Value["key1"], "key1 should have correct value")
-			require.Equal(t, "value2", contract.Value["key2"], "key2 should have correct value")
+			require.Equal(t, TEXT("value1"), contract.Value["key1"], "key1 should have correct value")
+			require.Equal(t, TEXT("value2"), contract.Value["key2"], "key2 should have correct value")
 		}
 	}
 	require.True(t, foundTypedContract, "should find at least one typed created event")
 }
 
 func TestCodegenIntegrationAllFieldsContract(t *testing.T) {
+	testutil.RequireClient(t)
 	zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
@@ -302,8 +305,8 @@ func TestCodegenIntegrationAllFieldsContract(t *testing.T) {
 		Operator:        PARTY(party),
 		SomeBoolean:     true,
 		SomeInteger:     190,
-		SomeDecimal:     NUMERIC("0.0000000200"),
-		SomeMeasurement: NUMERIC("0.0000000300"),
+		SomeDecimal:     MustParseNumeric("0.0000000200"),
+		SomeMeasurement: MustParseNumeric("0.0000000300"),
 		SomeMaybe:       &someMaybe,
 		SomeMaybeNot:    nil, // Testing optional None case
 		SomeDate:        DATE(time.Now().UTC()),
@@ -448,6 +451,7 @@ func TestCodegenIntegrationAllFieldsContract(t *testing.T) {
 }
 
 func TestAmuletsTransfer(t *testing.T) {
+	testutil.RequireClient(t)
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
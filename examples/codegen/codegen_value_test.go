@@ -0,0 +1,124 @@
+package codegen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/service/ledger"
+	. "github.com/noders-team/go-daml/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func oneOfEverythingFixture() OneOfEverything {
+	someMaybe := INT64(42)
+	nested := interface{}(MyPair{Left: INT64(10), Right: INT64(20)})
+	return OneOfEverything{
+		Operator:        "Alice::1220aa",
+		SomeBoolean:     true,
+		SomeInteger:     190,
+		SomeDecimal:     MustParseNumeric("0.0000000200"),
+		SomeMeasurement: MustParseNumeric("0.0000000300"),
+		SomeMaybe:       &someMaybe,
+		SomeDate:        DATE(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+		SomeDatetime:    TIMESTAMP(time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)),
+		SomeSimpleList:  []INT64{1, 2, 3},
+		SomeSimplePair:  MyPair{Left: INT64(100), Right: INT64(200)},
+		SomeNestedPair:  MyPair{Left: TEXT("left"), Right: TEXT("right")},
+		SomeUglyNesting: VPair{Both: &VPair{Left: &nested}},
+		SomeText:        "some text",
+		SomeEnum:        ColorRed,
+	}
+}
+
+func TestGeneratedToValueFromValue(t *testing.T) {
+	contract := oneOfEverythingFixture()
+	contract.SomeUglyNesting = VPair{Both: &VPair{Right: func() *interface{} {
+		v := interface{}(INT64(7))
+		return &v
+	}()}}
+
+	value, err := contract.ToValue()
+	require.NoError(t, err)
+	record := value.GetRecord()
+	require.NotNil(t, record)
+	require.Len(t, record.Fields, 16)
+	require.Equal(t, "someMaybe", record.Fields[4].Label)
+	require.Equal(t, int64(42), record.Fields[4].Value.GetOptional().GetValue().GetInt64())
+	require.Equal(t, "Red", record.Fields[14].Value.GetEnum().GetConstructor())
+	require.Equal(t, "Both", record.Fields[12].Value.GetVariant().GetConstructor())
+
+	var decoded OneOfEverything
+	require.NoError(t, decoded.FromValue(value))
+	require.Equal(t, contract, decoded)
+
+	var viaLedger OneOfEverything
+	viaRecord, err := ledger.ConvertToRecord(contract)
+	require.NoError(t, err)
+	require.NoError(t, ledger.RecordToStruct(viaRecord, &viaLedger))
+	require.Equal(t, contract, viaLedger)
+
+	purple, err := Color("Purple").ToValue()
+	require.NoError(t, err)
+	var color Color
+	require.ErrorContains(t, color.FromValue(purple), "unknown Color constructor Purple")
+}
+
+func TestGeneratedToValueErrors(t *testing.T) {
+	contract := oneOfEverythingFixture()
+	contract.SomeDecimal = NUMERIC{}
+	_, err := contract.ToValue()
+	require.ErrorContains(t, err, "field someDecimal: numeric value is not set")
+
+	contract = oneOfEverythingFixture()
+	contract.SomeUglyNesting = VPair{Both: &VPair{}}
+	_, err = contract.ToValue()
+	require.ErrorContains(t, err, "field someUglyNesting: variant Both: no VPair constructor is set")
+
+	_, err = ledger.ConvertToRecord(contract)
+	require.ErrorContains(t, err, "no VPair constructor is set")
+}
+
+func BenchmarkOneOfEverything_ToMap(b *testing.B) {
+	contract := oneOfEverythingFixture()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ledger.ConvertToRecord(contract.CreateCommand().Arguments)
+	}
+}
+
+func BenchmarkOneOfEverything_ToValue(b *testing.B) {
+	contract := oneOfEverythingFixture()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = contract.ToValue()
+	}
+}
+
+func BenchmarkOneOfEverything_RecordToMap(b *testing.B) {
+	value, err := oneOfEverythingFixture().ToValue()
+	if err != nil {
+		b.Fatal(err)
+	}
+	record := value.GetRecord()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var contract OneOfEverything
+		if err := ledger.MapToStruct(ledger.RecordToMap(record), &contract); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkOneOfEverything_FromValue(b *testing.B) {
+	value, err := oneOfEverythingFixture().ToValue()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var contract OneOfEverything
+		if err := contract.FromValue(value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
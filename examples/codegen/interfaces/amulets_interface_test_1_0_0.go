@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strings"
 
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
 	"github.com/noders-team/go-daml/pkg/codec"
 	"github.com/noders-team/go-daml/pkg/model"
 	. "github.com/noders-team/go-daml/pkg/types"
@@ -15,6 +16,7 @@ var (
 	_ = errors.New
 	_ = big.NewInt
 	_ = strings.NewReader
+	_ = (*v2.Value)(nil)
 )
 
 const (
@@ -66,6 +68,44 @@ type Asset struct {
 	Value INT64 `json:"value"`
 }
 
+// ToValue converts Asset to a Ledger API value
+func (t Asset) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 3)
+	if values[0], err = codec.PartyValue(t.Owner); err != nil {
+		return nil, fmt.Errorf("field owner: %w", err)
+	}
+	if values[1], err = codec.TextValue(t.Name); err != nil {
+		return nil, fmt.Errorf("field name: %w", err)
+	}
+	if values[2], err = codec.Int64Value(t.Value); err != nil {
+		return nil, fmt.Errorf("field value: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("owner", values[0]),
+		codec.RecordField("name", values[1]),
+		codec.RecordField("value", values[2]),
+	), nil
+}
+
+// FromValue sets Asset from a Ledger API value
+func (t *Asset) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "owner", "name", "value")
+	if err != nil {
+		return err
+	}
+	if t.Owner, err = codec.PartyFromValue(fields[0]); err != nil {
+		return fmt.Errorf("field owner: %w", err)
+	}
+	if t.Name, err = codec.TextFromValue(fields[1]); err != nil {
+		return fmt.Errorf("field name: %w", err)
+	}
+	if t.Value, err = codec.Int64FromValue(fields[2]); err != nil {
+		return fmt.Errorf("field value: %w", err)
+	}
+	return nil
+}
+
 // GetTemplateID returns the template ID for this template
 func (t Asset) GetTemplateID() string {
 	return fmt.Sprintf("%s:%s:%s", PackageID, "Interfaces", "Asset")
@@ -140,6 +180,30 @@ type AssetTransfer struct {
 	NewOwner PARTY `json:"newOwner"`
 }
 
+// ToValue converts AssetTransfer to a Ledger API value
+func (t AssetTransfer) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 1)
+	if values[0], err = codec.PartyValue(t.NewOwner); err != nil {
+		return nil, fmt.Errorf("field newOwner: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("newOwner", values[0]),
+	), nil
+}
+
+// FromValue sets AssetTransfer from a Ledger API value
+func (t *AssetTransfer) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "newOwner")
+	if err != nil {
+		return err
+	}
+	if t.NewOwner, err = codec.PartyFromValue(fields[0]); err != nil {
+		return fmt.Errorf("field newOwner: %w", err)
+	}
+	return nil
+}
+
 // toMap converts AssetTransfer to a map for DAML arguments
 func (t AssetTransfer) toMap() map[string]interface{} {
 	return map[string]interface{}{
@@ -166,6 +230,44 @@ type Token struct {
 	Amount NUMERIC `json:"amount"`
 }
 
+// ToValue converts Token to a Ledger API value
+func (t Token) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 3)
+	if values[0], err = codec.PartyValue(t.Issuer); err != nil {
+		return nil, fmt.Errorf("field issuer: %w", err)
+	}
+	if values[1], err = codec.PartyValue(t.Owner); err != nil {
+		return nil, fmt.Errorf("field owner: %w", err)
+	}
+	if values[2], err = codec.NumericValue(t.Amount); err != nil {
+		return nil, fmt.Errorf("field amount: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("issuer", values[0]),
+		codec.RecordField("owner", values[1]),
+		codec.RecordField("amount", values[2]),
+	), nil
+}
+
+// FromValue sets Token from a Ledger API value
+func (t *Token) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "issuer", "owner", "amount")
+	if err != nil {
+		return err
+	}
+	if t.Issuer, err = codec.PartyFromValue(fields[0]); err != nil {
+		return fmt.Errorf("field issuer: %w", err)
+	}
+	if t.Owner, err = codec.PartyFromValue(fields[1]); err != nil {
+		return fmt.Errorf("field owner: %w", err)
+	}
+	if t.Amount, err = codec.NumericFromValue(fields[2]); err != nil {
+		return fmt.Errorf("field amount: %w", err)
+	}
+	return nil
+}
+
 // GetTemplateID returns the template ID for this template
 func (t Token) GetTemplateID() string {
 	return fmt.Sprintf("%s:%s:%s", PackageID, "Interfaces", "Token")
@@ -179,8 +281,8 @@ func (t Token) CreateCommand() *model.CreateCommand {
 
 	args["owner"] = t.Owner.ToMap()
 
-	if t.Amount != "" {
-		args["amount"] = string(t.Amount)
+	if !t.Amount.IsZero() {
+		args["amount"] = t.Amount
 	}
 
 	return &model.CreateCommand{
@@ -232,6 +334,30 @@ type Transfer struct {
 	NewOwner PARTY `json:"newOwner"`
 }
 
+// ToValue converts Transfer to a Ledger API value
+func (t Transfer) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 1)
+	if values[0], err = codec.PartyValue(t.NewOwner); err != nil {
+		return nil, fmt.Errorf("field newOwner: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("newOwner", values[0]),
+	), nil
+}
+
+// FromValue sets Transfer from a Ledger API value
+func (t *Transfer) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "newOwner")
+	if err != nil {
+		return err
+	}
+	if t.NewOwner, err = codec.PartyFromValue(fields[0]); err != nil {
+		return fmt.Errorf("field newOwner: %w", err)
+	}
+	return nil
+}
+
 // toMap converts Transfer to a map for DAML arguments
 func (t Transfer) toMap() map[string]interface{} {
 	return map[string]interface{}{
@@ -256,6 +382,30 @@ type TransferableView struct {
 	Owner PARTY `json:"owner"`
 }
 
+// ToValue converts TransferableView to a Ledger API value
+func (t TransferableView) ToValue() (*v2.Value, error) {
+	var err error
+	values := make([]*v2.Value, 1)
+	if values[0], err = codec.PartyValue(t.Owner); err != nil {
+		return nil, fmt.Errorf("field owner: %w", err)
+	}
+	return codec.RecordValue(
+		codec.RecordField("owner", values[0]),
+	), nil
+}
+
+// FromValue sets TransferableView from a Ledger API value
+func (t *TransferableView) FromValue(pb *v2.Value) error {
+	fields, err := codec.RecordFieldsFromValue(pb, "owner")
+	if err != nil {
+		return err
+	}
+	if t.Owner, err = codec.PartyFromValue(fields[0]); err != nil {
+		return fmt.Errorf("field owner: %w", err)
+	}
+	return nil
+}
+
 // toMap converts TransferableView to a map for DAML arguments
 func (t TransferableView) toMap() map[string]interface{} {
 	return map[string]interface{}{
@@ -2,6 +2,7 @@ package codegen_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -17,7 +18,10 @@ func TestMain(m *testing.M) {
 	ctx, cancel := context.WithTimeout(context.Background(), 7*time.Minute)
 	defer cancel()
 
-	if err := testutil.Setup(ctx); err != nil {
+	err := testutil.Setup(ctx)
+	if errors.Is(err, testutil.ErrDockerUnavailable) {
+		log.Warn().Err(err).Msg("running without the Canton sandbox")
+	} else if err != nil {
 		log.Fatal().Err(err).Msg("failed to setup test environment")
 	}
 
@@ -30,7 +30,7 @@ func RunInteractiveSubmission(cl *client.DamlBindingClient) {
 	} else {
 		log.Info().
 			Interface("packageReference", packageVersionResp.PackageReference).
-			Str("synchronizerId", packageVersionResp.SynchronizerID).
+			Str("synchronizerId", string(packageVersionResp.SynchronizerID)).
 			Msg("got preferred package version")
 	}
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/noders-team/go-daml/pkg/client"
 	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/printer"
 	"github.com/rs/zerolog/log"
 )
 
@@ -59,6 +60,7 @@ func RunStateService(cl *client.DamlBindingClient) {
 
 	responseCh, errCh := cl.StateService.GetActiveContracts(ctx, activeContractsReq)
 
+	argsPrinter := printer.NewPrinter(printer.WithRedactedParties())
 	contractCount := 0
 	for {
 		select {
@@ -75,7 +77,8 @@ func RunStateService(cl *client.DamlBindingClient) {
 						log.Info().
 							Str("contractID", entry.ActiveContract.CreatedEvent.ContractID).
 							Str("templateID", entry.ActiveContract.CreatedEvent.TemplateID).
-							Str("synchronizerID", entry.ActiveContract.SynchronizerID).
+							Str("arguments", argsPrinter.Sprint(entry.ActiveContract.CreatedEvent.CreateArguments)).
+							Str("synchronizerID", string(entry.ActiveContract.SynchronizerID)).
 							Uint64("reassignmentCounter", entry.ActiveContract.ReassignmentCounter).
 							Msg("received active contract")
 					}
@@ -466,25 +466,92 @@ func (c *codeGenAst) extractTapp(pkg *daml.Package, tapp *daml.Type_TApp) string
 		return "unknown_tapp"
 	}
 
-	lhs := model.NormalizeDAMLType(c.extractType(pkg, tapp.GetLhs()))
+	// collect the arguments of a curried application such as GenMap k v
+	args := []*daml.Type{tapp.GetRhs()}
+	head := tapp.GetLhs()
+	for head.GetTapp() != nil {
+		args = append([]*daml.Type{head.GetTapp().GetRhs()}, args...)
+		head = head.GetTapp().GetLhs()
+	}
+
+	lhs := model.NormalizeDAMLType(c.extractType(pkg, head))
+	argTypes := make([]string, len(args))
+	for i, arg := range args {
+		argTypes[i] = model.NormalizeDAMLType(c.extractType(pkg, arg))
+	}
 
 	switch lhs {
 	case "LIST":
-		elem := model.NormalizeDAMLType(c.extractType(pkg, tapp.GetRhs()))
-		return "[]" + elem
+		return "[]" + argTypes[0]
 
 	case "OPTIONAL":
-		elem := model.NormalizeDAMLType(c.extractType(pkg, tapp.GetRhs()))
-		return "*" + elem
+		return "*" + argTypes[0]
 
 	case "CONTRACT_ID":
 		// ContractId X  -> CONTRACT_ID (don’t collapse to string)
 		return "CONTRACT_ID"
 	}
 
+	if lhs == "GENMAP" {
+		lhs = c.genMapName(pkg, argTypes)
+	}
+	if generic := genericType(lhs, argTypes); generic != "" {
+		return generic
+	}
+
 	// some other type application; keep lhs
 	return lhs
 }
+
+// genericType maps an applied container type to its generic Go type, e.g. GenMap[PARTY,INT64],
+// or returns "" if the type is not one of them or an argument is a type variable.
+func genericType(name string, args []string) string {
+	var generic string
+	switch {
+	case name == "GENMAP" && len(args) == 2:
+		generic = "GenMap"
+	case name == "ENTRYLIST" && len(args) == 2:
+		generic = "EntryList"
+	case name == "TEXTMAP" && len(args) == 1:
+		generic = "TextMap"
+	case name == "SET" && len(args) == 1:
+		generic = "Set"
+	case name == "TUPLE2" && len(args) == 2:
+		generic = "Tuple2"
+	case name == "TUPLE3" && len(args) == 3:
+		generic = "Tuple3"
+	default:
+		return ""
+	}
+
+	for _, arg := range args {
+		if arg == "interface{}" {
+			return ""
+		}
+	}
+	return generic + "[" + strings.Join(args, ",") + "]"
+}
+
+// comparableKeyTypes are the DAML types whose Go types compare by value, so they can key a GenMap.
+var comparableKeyTypes = map[string]bool{
+	"PARTY":       true,
+	"TEXT":        true,
+	"INT64":       true,
+	"BOOL":        true,
+	"CONTRACT_ID": true,
+	"UNIT":        true,
+	"RELTIME":     true,
+}
+
+// genMapName returns GENMAP for a GenMap whose keys are primitives or enums, and ENTRYLIST for
+// one keyed by records, variants, numerics, times or containers, which Go maps cannot hold.
+func (c *codeGenAst) genMapName(pkg *daml.Package, args []string) string {
+	if len(args) == 2 && !comparableKeyTypes[args[0]] && !c.isEnumType(args[0], pkg) {
+		return "ENTRYLIST"
+	}
+	return "GENMAP"
+}
+
 func (c *codeGenAst) extractType(pkg *daml.Package, typ *daml.Type) string {
 	if typ == nil {
 		return ""
@@ -555,9 +622,21 @@ func (c *codeGenAst) handleBuiltinType(pkg *daml.Package, b *daml.Type_Builtin)
 		return RawTypeContractID
 
 	case daml.BuiltinType_GENMAP:
+		args := make([]string, len(b.Args))
+		for i, arg := range b.Args {
+			args[i] = model.NormalizeDAMLType(c.extractType(pkg, arg))
+		}
+		if generic := genericType(c.genMapName(pkg, args), args); generic != "" {
+			return generic
+		}
 		return "GENMAP"
 
 	case daml.BuiltinType_TEXTMAP:
+		if len(b.Args) > 0 {
+			if generic := genericType("TEXTMAP", []string{model.NormalizeDAMLType(c.extractType(pkg, b.Args[0]))}); generic != "" {
+				return generic
+			}
+		}
 		return "TEXTMAP"
 
 	default:
@@ -587,21 +666,15 @@ func (c *codeGenAst) handleConType(pkg *daml.Package, conType *daml.Type_Con) st
 			return "[]" + normalizedElementType
 		}
 		return RawTypeList
-	case "Tuple2":
-		if len(conType.Args) >= 2 {
-			arg1Type := c.extractType(pkg, conType.Args[0])
-			arg2Type := c.extractType(pkg, conType.Args[1])
-			return "TUPLE2[" + model.NormalizeDAMLType(arg1Type) + "," + model.NormalizeDAMLType(arg2Type) + "]"
-		}
-		return "TUPLE2"
-	case "Tuple3":
-		if len(conType.Args) >= 3 {
-			arg1Type := c.extractType(pkg, conType.Args[0])
-			arg2Type := c.extractType(pkg, conType.Args[1])
-			arg3Type := c.extractType(pkg, conType.Args[2])
-			return "TUPLE3[" + model.NormalizeDAMLType(arg1Type) + "," + model.NormalizeDAMLType(arg2Type) + "," + model.NormalizeDAMLType(arg3Type) + "]"
-		}
-		return "TUPLE3"
+	case "Tuple2", "Tuple3", "Set":
+		args := make([]string, len(conType.Args))
+		for i, arg := range conType.Args {
+			args[i] = model.NormalizeDAMLType(c.extractType(pkg, arg))
+		}
+		if generic := genericType(model.NormalizeDAMLType(tyconName), args); generic != "" {
+			return generic
+		}
+		return model.NormalizeDAMLType(tyconName)
 	default:
 		return tyconName
 	}
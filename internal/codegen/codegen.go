@@ -424,27 +424,23 @@ func getPackageName(mainDalf string) string {
 	parts := strings.Split(mainDalf, "/")
 	filename := strings.TrimSuffix(parts[len(parts)-1], ".dalf")
 
-	// Strip version pattern like "-1.0.0", "-2.9.1", etc.
+	// Strip the trailing package hash, then the version pattern like "-1.0.0", "-2.9.1", etc.
+	if lastHyphen := strings.LastIndex(filename, "-"); lastHyphen != -1 && isPackageHash(filename[lastHyphen+1:]) {
+		filename = filename[:lastHyphen]
+	}
 	filename = stripVersionFromPackageName(filename)
 
-	lastHyphen := strings.LastIndex(filename, "-")
-	if lastHyphen == -1 {
-		return strings.ToLower(filename)
-	}
+	return strings.ToLower(filename)
+}
 
-	potentialHash := filename[lastHyphen+1:]
-	if len(potentialHash) == 64 {
-		allHex := true
-		for _, ch := range potentialHash {
-			if !((ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')) {
-				allHex = false
-				break
-			}
-		}
-		if allHex {
-			return strings.ToLower(filename[:lastHyphen])
+func isPackageHash(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, ch := range s {
+		if !((ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')) {
+			return false
 		}
 	}
-
-	return strings.ToLower(filename)
+	return true
 }
@@ -64,6 +64,10 @@ func NormalizeDAMLType(damlType string) string {
 		return "[]" + inner
 	}
 
+	if isGenericType(strings.TrimLeft(damlType, "*[]")) {
+		return damlType
+	}
+
 	switch {
 	// Handle both v1/v2 format (prim:TYPE) and v3 format (TYPE)
 	case strings.Contains(damlType, "prim:PARTY") || damlType == "PARTY":
@@ -106,16 +110,6 @@ func NormalizeDAMLType(damlType string) string {
 		return "RELTIME"
 	case strings.Contains(damlType, "Set") && !strings.Contains(damlType, "Settle") && !strings.Contains(damlType, "Setup"):
 		return "SET"
-	case strings.HasPrefix(damlType, "TUPLE2["):
-		return damlType
-	case strings.HasPrefix(damlType, "TUPLE3["):
-		return damlType
-	case strings.HasPrefix(damlType, "[]TUPLE2[") || strings.HasPrefix(damlType, "[]TUPLE3["):
-		return damlType
-	case strings.HasPrefix(damlType, "*TUPLE2[") || strings.HasPrefix(damlType, "*TUPLE3["):
-		return damlType
-	case strings.HasPrefix(damlType, "*[]TUPLE2[") || strings.HasPrefix(damlType, "*[]TUPLE3["):
-		return damlType
 	case strings.Contains(damlType, "Tuple2") || strings.Contains(damlType, "TUPLE2"):
 		return "TUPLE2"
 	case strings.Contains(damlType, "Tuple3") || strings.Contains(damlType, "TUPLE3"):
@@ -143,3 +137,13 @@ func NormalizeDAMLType(damlType string) string {
 		return strings.ReplaceAll(damlType, "_", "")
 	}
 }
+
+// isGenericType reports whether damlType is already one of the generic container types from pkg/types.
+func isGenericType(damlType string) bool {
+	for _, prefix := range []string{"GenMap[", "EntryList[", "TextMap[", "Set[", "Tuple2[", "Tuple3["} {
+		if strings.HasPrefix(damlType, prefix) {
+			return true
+		}
+	}
+	return false
+}
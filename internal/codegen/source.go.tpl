@@ -9,12 +9,14 @@ import (
 	"github.com/noders-team/go-daml/pkg/model"
 	. "github.com/noders-team/go-daml/pkg/types"
 	"github.com/noders-team/go-daml/pkg/codec"
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
 )
 
 var (
 	_ = errors.New
 	_ = big.NewInt
 	_ = strings.NewReader
+	_ = (*v2.Value)(nil)
 )
 
 
@@ -26,7 +28,7 @@ var (
   {{- else if eq $baseType "BOOL" -}}bool(*t.{{capitalise .Name}})
   {{- else if eq $baseType "PARTY" -}}(*t.{{capitalise .Name}}).ToMap()
   {{- else if eq $baseType "NUMERIC" -}}*t.{{capitalise .Name}}
-  {{- else if eq $baseType "DECIMAL" -}}(*big.Int)(*t.{{capitalise .Name}})
+  {{- else if eq $baseType "DECIMAL" -}}*t.{{capitalise .Name}}
   {{- else if eq $baseType "DATE" -}}*t.{{capitalise .Name}}
   {{- else if eq $baseType "TIMESTAMP" -}}*t.{{capitalise .Name}}
   {{- else if eq $baseType "UNIT" -}}map[string]interface{}{"_type": "unit"}
@@ -38,7 +40,7 @@ var (
   {{- else if eq .Type "INT64" -}}int64(t.{{capitalise .Name}})
   {{- else if eq .Type "BOOL" -}}bool(t.{{capitalise .Name}})
   {{- else if eq .Type "NUMERIC" -}}t.{{capitalise .Name}}
-  {{- else if eq .Type "DECIMAL" -}}(*big.Int)(t.{{capitalise .Name}})
+  {{- else if eq .Type "DECIMAL" -}}t.{{capitalise .Name}}
   {{- else if eq .Type "DATE" -}}t.{{capitalise .Name}}
   {{- else if eq .Type "TIMESTAMP" -}}t.{{capitalise .Name}}
   {{- else if eq .Type "UNIT" -}}map[string]interface{}{"_type": "unit"}
@@ -61,7 +63,7 @@ var (
         {{- else if eq $elem "NUMERIC" -}}
           res = append(res, e)
         {{- else if eq $elem "DECIMAL" -}}
-          res = append(res, (*big.Int)(e))
+          res = append(res, e)
         {{- else -}}
           type mapper interface{ toMap() map[string]interface{} }
           if m, ok := any(e).(mapper); ok {
@@ -104,8 +106,8 @@ var (
 {{- else if eq .Type "TEXT" -}}t.{{capitalise .Name}} != ""
 {{- else if eq .Type "INT64" -}}t.{{capitalise .Name}} != 0
 {{- else if eq .Type "BOOL" -}}true
-{{- else if eq .Type "NUMERIC" -}}t.{{capitalise .Name}} != ""
-{{- else if eq .Type "DECIMAL" -}}t.{{capitalise .Name}} != nil
+{{- else if eq .Type "NUMERIC" -}}!t.{{capitalise .Name}}.IsZero()
+{{- else if eq .Type "DECIMAL" -}}!t.{{capitalise .Name}}.IsZero()
 {{- else if eq .Type "DATE" -}}!t.{{capitalise .Name}}.IsZero()
 {{- else if eq .Type "TIMESTAMP" -}}!t.{{capitalise .Name}}.IsZero()
 {{- else if eq .Type "LIST" -}}len(t.{{capitalise .Name}}) > 0
@@ -222,6 +224,42 @@ func (v {{capitalise .Name}}) GetVariantValue() interface{} {
 	return nil
 }
 
+// ToValue converts {{capitalise .Name}} to a Ledger API value
+func (v {{capitalise .Name}}) ToValue() (*v2.Value, error) {
+	{{- range $field := .Fields}}
+	if v.{{capitalise $field.Name}} != nil {
+		value, err := {{toValue $field.Type (printf "*v.%s" (capitalise $field.Name))}}
+		if err != nil {
+			return nil, fmt.Errorf("variant {{$field.Name}}: %w", err)
+		}
+		return codec.VariantValue("{{$field.Name}}", value), nil
+	}
+	{{- end}}
+	return nil, fmt.Errorf("no {{capitalise .Name}} constructor is set")
+}
+
+// FromValue sets {{capitalise .Name}} from a Ledger API value
+func (v *{{capitalise .Name}}) FromValue(pb *v2.Value) error {
+	constructor, payload, err := codec.VariantFromValue(pb)
+	if err != nil {
+		return err
+	}
+	*v = {{capitalise .Name}}{}
+	switch constructor {
+	{{- range $field := .Fields}}
+	case "{{$field.Name}}":
+		value, err := {{fromValue $field.Type "payload"}}
+		if err != nil {
+			return fmt.Errorf("variant {{$field.Name}}: %w", err)
+		}
+		v.{{capitalise $field.Name}} = &value
+	{{- end}}
+	default:
+		return fmt.Errorf("unknown {{capitalise .Name}} constructor %s", constructor)
+	}
+	return nil
+}
+
 var _ VARIANT = (*{{capitalise .Name}})(nil)
 
 {{else if eq .RawType "Enum"}}
@@ -255,6 +293,25 @@ func (e *{{capitalise .Name}}) UnmarshalJSON(data []byte) error {
 	return jsonCodec.Unmarshall(data, e)
 }
 
+// ToValue converts {{capitalise .Name}} to a Ledger API value
+func (e {{capitalise .Name}}) ToValue() (*v2.Value, error) {
+	return codec.EnumValue(string(e)), nil
+}
+
+// FromValue sets {{capitalise .Name}} from a Ledger API value
+func (e *{{capitalise .Name}}) FromValue(pb *v2.Value) error {
+	constructor, err := codec.EnumFromValue(pb)
+	if err != nil {
+		return err
+	}
+	switch {{capitalise .Name}}(constructor) {
+	case {{range $i, $field := .Fields}}{{if $i}}, {{end}}{{capitalise $structName}}{{$field.Name}}{{end}}:
+		*e = {{capitalise .Name}}(constructor)
+		return nil
+	}
+	return fmt.Errorf("unknown {{capitalise .Name}} constructor %s", constructor)
+}
+
 var _ ENUM = {{capitalise .Name}}("")
 
 {{else}}
@@ -265,6 +322,45 @@ type {{capitalise .Name}} struct {
 	{{- end}}
 }
 
+// ToValue converts {{capitalise .Name}} to a Ledger API value
+func (t {{capitalise .Name}}) ToValue() (*v2.Value, error) {
+	{{- if .Fields}}
+	var err error
+	values := make([]*v2.Value, {{len .Fields}})
+	{{- range $i, $field := .Fields}}
+	if values[{{$i}}], err = {{toValue $field.Type (printf "t.%s" (capitalise $field.Name))}}; err != nil {
+		return nil, fmt.Errorf("field {{$field.Name}}: %w", err)
+	}
+	{{- end}}
+	return codec.RecordValue(
+		{{- range $i, $field := .Fields}}
+		codec.RecordField("{{$field.Name}}", values[{{$i}}]),
+		{{- end}}
+	), nil
+	{{- else}}
+	return codec.RecordValue(), nil
+	{{- end}}
+}
+
+// FromValue sets {{capitalise .Name}} from a Ledger API value
+func (t *{{capitalise .Name}}) FromValue(pb *v2.Value) error {
+	{{- if .Fields}}
+	fields, err := codec.RecordFieldsFromValue(pb{{range $field := .Fields}}, "{{$field.Name}}"{{end}})
+	if err != nil {
+		return err
+	}
+	{{- range $i, $field := .Fields}}
+	if t.{{capitalise $field.Name}}, err = {{fromValue $field.Type (printf "fields[%d]" $i)}}; err != nil {
+		return fmt.Errorf("field {{$field.Name}}: %w", err)
+	}
+	{{- end}}
+	return nil
+	{{- else}}
+	_, err := codec.RecordFieldsFromValue(pb)
+	return err
+	{{- end}}
+}
+
 {{if and (eq .RawType "Record") (not .IsTemplate) (not .IsInterface)}}
 // ToMap converts {{capitalise .Name}} to a map for DAML arguments
 func (t {{capitalise .Name}}) ToMap() map[string]interface{} {
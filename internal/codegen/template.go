@@ -37,6 +37,8 @@ func Bind(pkg string, packageName string, sdkVersion string, structs map[string]
 		"decapitalize":      decapitalize,
 		"stringsHasPrefix":  strings.HasPrefix,
 		"stringsTrimPrefix": strings.TrimPrefix,
+		"toValue":           toValueExpr,
+		"fromValue":         fromValueExpr,
 	}
 	tmpl := template.Must(template.New("").Funcs(funcs).Parse(tmplSource))
 	if err := tmpl.Execute(buffer, data); err != nil {
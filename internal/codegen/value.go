@@ -0,0 +1,152 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// primitiveValueFuncs maps the DAML types from pkg/types to the codec functions that convert them.
+var primitiveValueFuncs = map[string]string{
+	"UNIT":        "Unit",
+	"BOOL":        "Bool",
+	"INT64":       "Int64",
+	"TEXT":        "Text",
+	"string":      "String",
+	"PARTY":       "Party",
+	"CONTRACT_ID": "ContractID",
+	"NUMERIC":     "Numeric",
+	"DECIMAL":     "Numeric",
+	"DATE":        "Date",
+	"TIMESTAMP":   "Timestamp",
+	"RELTIME":     "RelTime",
+}
+
+// dynamicTypes are the untyped DAML types, which the generated code converts with codec.ProtoCodec.
+var dynamicTypes = map[string]bool{
+	"interface{}":   true,
+	"LIST":          true,
+	"MAP":           true,
+	"OPTIONAL":      true,
+	"GENMAP":        true,
+	"TEXTMAP":       true,
+	"SET":           true,
+	"TUPLE2":        true,
+	"TUPLE3":        true,
+	"ANY":           true,
+	"BIGNUMERIC":    true,
+	"ROUNDING_MODE": true,
+}
+
+// toValueExpr returns Go code converting expr, of the generated Go type goType, to a *v2.Value, as a
+// (value, error) pair.
+func toValueExpr(goType, expr string) string {
+	if f, ok := primitiveValueFuncs[goType]; ok {
+		return fmt.Sprintf("codec.%sValue(%s)", f, expr)
+	}
+	if dynamicTypes[goType] {
+		return fmt.Sprintf("codec.DynamicValue(%s)", expr)
+	}
+	if elem, ok := strings.CutPrefix(goType, "[]"); ok {
+		return fmt.Sprintf("codec.ListValue(%s, %s)", expr, toValueFunc(elem))
+	}
+	if elem, ok := strings.CutPrefix(goType, "*"); ok {
+		return fmt.Sprintf("codec.OptionalValue(%s, %s)", expr, toValueFunc(elem))
+	}
+	if name, args, ok := splitGenericType(goType); ok {
+		funcs := make([]string, len(args))
+		for i, arg := range args {
+			funcs[i] = toValueFunc(arg)
+		}
+		if name == "Optional" {
+			name = "OptionalOf"
+		}
+		return fmt.Sprintf("codec.%sValue(%s, %s)", name, expr, strings.Join(funcs, ", "))
+	}
+	if strings.HasPrefix(expr, "*") {
+		expr = "(" + expr + ")"
+	}
+	return expr + ".ToValue()"
+}
+
+// toValueFunc returns a Go func(goType) (*v2.Value, error).
+func toValueFunc(goType string) string {
+	if f, ok := primitiveValueFuncs[goType]; ok {
+		return fmt.Sprintf("codec.%sValue", f)
+	}
+	if !dynamicTypes[goType] && isGeneratedType(goType) {
+		return goType + ".ToValue"
+	}
+	return fmt.Sprintf("func(v %s) (*v2.Value, error) { return %s }", goType, toValueExpr(goType, "v"))
+}
+
+// fromValueExpr returns Go code decoding the *v2.Value expr to goType, as a (value, error) pair.
+func fromValueExpr(goType, expr string) string {
+	if f, ok := primitiveValueFuncs[goType]; ok {
+		return fmt.Sprintf("codec.%sFromValue(%s)", f, expr)
+	}
+	if dynamicTypes[goType] {
+		return fmt.Sprintf("codec.DynamicFromValue[%s](%s)", goType, expr)
+	}
+	if elem, ok := strings.CutPrefix(goType, "[]"); ok {
+		return fmt.Sprintf("codec.ListFromValue(%s, %s)", expr, fromValueFunc(elem))
+	}
+	if elem, ok := strings.CutPrefix(goType, "*"); ok {
+		return fmt.Sprintf("codec.OptionalFromValue(%s, %s)", expr, fromValueFunc(elem))
+	}
+	if name, args, ok := splitGenericType(goType); ok {
+		funcs := make([]string, len(args))
+		for i, arg := range args {
+			funcs[i] = fromValueFunc(arg)
+		}
+		if name == "Optional" {
+			name = "OptionalOf"
+		}
+		return fmt.Sprintf("codec.%sFromValue(%s, %s)", name, expr, strings.Join(funcs, ", "))
+	}
+	return fmt.Sprintf("codec.GeneratedFromValue[%s](%s)", goType, expr)
+}
+
+// fromValueFunc returns a Go func(*v2.Value) (goType, error).
+func fromValueFunc(goType string) string {
+	if f, ok := primitiveValueFuncs[goType]; ok {
+		return fmt.Sprintf("codec.%sFromValue", f)
+	}
+	if dynamicTypes[goType] {
+		return fmt.Sprintf("codec.DynamicFromValue[%s]", goType)
+	}
+	if isGeneratedType(goType) {
+		return fmt.Sprintf("codec.GeneratedFromValue[%s]", goType)
+	}
+	return fmt.Sprintf("func(pb *v2.Value) (%s, error) { return %s }", goType, fromValueExpr(goType, "pb"))
+}
+
+// splitGenericType splits a type such as GenMap[PARTY, []INT64] into its name and type arguments.
+func splitGenericType(goType string) (string, []string, bool) {
+	open := strings.Index(goType, "[")
+	if open <= 0 || !strings.HasSuffix(goType, "]") {
+		return "", nil, false
+	}
+
+	var args []string
+	depth, start := 0, open+1
+	inner := goType[:len(goType)-1]
+	for i := start; i < len(inner); i++ {
+		switch inner[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(inner[start:]))
+	return goType[:open], args, true
+}
+
+func isGeneratedType(goType string) bool {
+	return !strings.ContainsAny(goType, "[]*{ ")
+}
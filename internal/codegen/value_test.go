@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToValueExpr(t *testing.T) {
+	tests := []struct {
+		goType   string
+		expected string
+	}{
+		{"PARTY", "codec.PartyValue(t.F)"},
+		{"GENMAP", "codec.DynamicValue(t.F)"},
+		{"Asset", "t.F.ToValue()"},
+		{"*INT64", "codec.OptionalValue(t.F, codec.Int64Value)"},
+		{"[]Asset", "codec.ListValue(t.F, Asset.ToValue)"},
+		{"GenMap[PARTY, []INT64]", "codec.GenMapValue(t.F, codec.PartyValue, func(v []INT64) (*v2.Value, error) { return codec.ListValue(v, codec.Int64Value) })"},
+		{"Optional[TEXT]", "codec.OptionalOfValue(t.F, codec.TextValue)"},
+		{"EntryList[Asset, NUMERIC]", "codec.EntryListValue(t.F, Asset.ToValue, codec.NumericValue)"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, toValueExpr(tt.goType, "t.F"), tt.goType)
+	}
+	require.Equal(t, "(*v.F).ToValue()", toValueExpr("Asset", "*v.F"))
+}
+
+func TestFromValueExpr(t *testing.T) {
+	tests := []struct {
+		goType   string
+		expected string
+	}{
+		{"DECIMAL", "codec.NumericFromValue(pb)"},
+		{"interface{}", "codec.DynamicFromValue[interface{}](pb)"},
+		{"Asset", "codec.GeneratedFromValue[Asset](pb)"},
+		{"*Asset", "codec.OptionalFromValue(pb, codec.GeneratedFromValue[Asset])"},
+		{"Tuple2[TEXT, *INT64]", "codec.Tuple2FromValue(pb, codec.TextFromValue, func(pb *v2.Value) (*INT64, error) { return codec.OptionalFromValue(pb, codec.Int64FromValue) })"},
+		{"Set[PARTY]", "codec.SetFromValue(pb, codec.PartyFromValue)"},
+		{"EntryList[[]TEXT, INT64]", "codec.EntryListFromValue(pb, func(pb *v2.Value) ([]TEXT, error) { return codec.ListFromValue(pb, codec.TextFromValue) }, codec.Int64FromValue)"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, fromValueExpr(tt.goType, "pb"), tt.goType)
+	}
+}
@@ -0,0 +1,393 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWK is a single JSON Web Key as published by an identity provider.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKS is a parsed JSON Web Key Set with the public keys resolved. Keys holds only the signing keys.
+type JWKS struct {
+	Keys []JWK
+	keys []crypto.PublicKey
+}
+
+// FetchJWKS downloads and parses the key set at url. A nil httpClient uses http.DefaultClient.
+func FetchJWKS(ctx context.Context, httpClient *http.Client, url string) (*JWKS, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWKS URL %q: %w", url, err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS from %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS from %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS from %s: %w", url, err)
+	}
+
+	return ParseJWKS(data)
+}
+
+// ParseJWKS parses a key set, keeping only the keys that can verify tokens. Keys meant for
+// encryption, of unsupported types or with invalid material are skipped, as identity providers
+// commonly publish them alongside their signing keys; the set is rejected only if no signing
+// key remains.
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var raw struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	if len(raw.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no keys")
+	}
+
+	jwks := &JWKS{}
+	kids := make(map[string]bool)
+	var skipped []error
+	for i, k := range raw.Keys {
+		pub, err := signingKey(k)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("JWKS key %d (kid %q): %w", i, k.Kid, err))
+			continue
+		}
+		if k.Kid != "" {
+			if kids[k.Kid] {
+				return nil, fmt.Errorf("JWKS key %d: duplicate kid %q", i, k.Kid)
+			}
+			kids[k.Kid] = true
+		}
+		jwks.Keys = append(jwks.Keys, k)
+		jwks.keys = append(jwks.keys, pub)
+	}
+
+	if len(jwks.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no usable signing key: %w", errors.Join(skipped...))
+	}
+
+	return jwks, nil
+}
+
+// signingKey returns the public key of k if it can be used to verify signatures.
+func signingKey(k JWK) (crypto.PublicKey, error) {
+	if k.Use != "" && k.Use != "sig" {
+		return nil, fmt.Errorf("unsupported use %q", k.Use)
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if k.Alg != "" {
+		if err := checkAlgorithm(k.Alg, pub); err != nil {
+			return nil, err
+		}
+	}
+	return pub, nil
+}
+
+// PublicKey decodes the key material of an RSA, EC (P-256, P-384, P-521) or OKP (Ed25519) key.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		if n.BitLen() < 2048 {
+			return nil, fmt.Errorf("RSA key too short: %d bits", n.BitLen())
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA exponent %s", e)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Ed25519 key: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key length %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// JWTClaims holds the registered claims checked when verifying a token.
+type JWTClaims struct {
+	Issuer    string
+	Subject   string
+	Audience  []string
+	ExpiresAt *time.Time
+	NotBefore *time.Time
+	Raw       map[string]interface{}
+}
+
+// VerifyJWT checks the signature of token against the key set and returns its claims.
+// The key is selected by the kid header, or used directly when the set holds a single key.
+func (j *JWKS) VerifyJWT(token string) (*JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed JWT: expected 3 parts, got %d", len(parts))
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+
+	pub, err := j.keyFor(header.Kid, header.Alg)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT signature: %w", err)
+	}
+
+	if err := verifySignature(header.Alg, pub, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("malformed JWT payload: %w", err)
+	}
+
+	return claimsFromRaw(raw), nil
+}
+
+func (j *JWKS) keyFor(kid, alg string) (crypto.PublicKey, error) {
+	for i, k := range j.Keys {
+		if kid != "" && k.Kid != kid {
+			continue
+		}
+		if kid == "" && len(j.Keys) > 1 {
+			break
+		}
+		if k.Alg != "" && k.Alg != alg {
+			return nil, fmt.Errorf("JWT algorithm %s does not match key %q algorithm %s", alg, k.Kid, k.Alg)
+		}
+		return j.keys[i], nil
+	}
+	return nil, fmt.Errorf("no JWKS key matches JWT kid %q", kid)
+}
+
+func claimsFromRaw(raw map[string]interface{}) *JWTClaims {
+	claims := &JWTClaims{Raw: raw}
+	claims.Issuer, _ = raw["iss"].(string)
+	claims.Subject, _ = raw["sub"].(string)
+
+	switch aud := raw["aud"].(type) {
+	case string:
+		claims.Audience = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				claims.Audience = append(claims.Audience, s)
+			}
+		}
+	}
+
+	if exp, ok := raw["exp"].(float64); ok {
+		t := time.Unix(int64(exp), 0)
+		claims.ExpiresAt = &t
+	}
+	if nbf, ok := raw["nbf"].(float64); ok {
+		t := time.Unix(int64(nbf), 0)
+		claims.NotBefore = &t
+	}
+
+	return claims
+}
+
+func checkAlgorithm(alg string, pub crypto.PublicKey) error {
+	switch alg {
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		if _, ok := pub.(*rsa.PublicKey); ok {
+			return nil
+		}
+	case "ES256", "ES384", "ES512":
+		if ec, ok := pub.(*ecdsa.PublicKey); ok && ec.Curve == curveForAlg(alg) {
+			return nil
+		}
+	case "EdDSA":
+		if _, ok := pub.(ed25519.PublicKey); ok {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	return fmt.Errorf("algorithm %s does not match key type %T", alg, pub)
+}
+
+func curveForAlg(alg string) elliptic.Curve {
+	switch alg {
+	case "ES256":
+		return elliptic.P256()
+	case "ES384":
+		return elliptic.P384()
+	default:
+		return elliptic.P521()
+	}
+}
+
+func verifySignature(alg string, pub crypto.PublicKey, signingInput, signature []byte) error {
+	if err := checkAlgorithm(alg, pub); err != nil {
+		return err
+	}
+
+	if alg == "EdDSA" {
+		if !ed25519.Verify(pub.(ed25519.PublicKey), signingInput, signature) {
+			return fmt.Errorf("invalid JWT signature")
+		}
+		return nil
+	}
+
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	default:
+		hash = crypto.SHA512
+	}
+	digest := hashBytes(hash, signingInput)
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		var err error
+		if strings.HasPrefix(alg, "PS") {
+			err = rsa.VerifyPSS(key, hash, digest, signature, nil)
+		} else {
+			err = rsa.VerifyPKCS1v15(key, hash, digest, signature)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid JWT signature: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid JWT signature length %d", len(signature))
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return fmt.Errorf("invalid JWT signature")
+		}
+	}
+
+	return nil
+}
+
+func hashBytes(hash crypto.Hash, data []byte) []byte {
+	switch hash {
+	case crypto.SHA256:
+		sum := sha256.Sum256(data)
+		return sum[:]
+	case crypto.SHA384:
+		sum := sha512.Sum384(data)
+		return sum[:]
+	default:
+		sum := sha512.Sum512(data)
+		return sum[:]
+	}
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func signTestJWT(t *testing.T, header, claims map[string]interface{}, sign func([]byte) []byte) string {
+	t.Helper()
+
+	h, err := json.Marshal(header)
+	require.NoError(t, err)
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	input := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	return input + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(input)))
+}
+
+func TestFetchJWKSAndVerifyJWT(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "OKP", "crv": "Ed25519", "kid": "ed", "alg": "EdDSA", "x": base64.RawURLEncoding.EncodeToString(edPub)},
+			{
+				"kty": "EC", "crv": "P-256", "kid": "ec", "alg": "ES256", "use": "sig",
+				"x": base64.RawURLEncoding.EncodeToString(ecPriv.X.FillBytes(make([]byte, 32))),
+				"y": base64.RawURLEncoding.EncodeToString(ecPriv.Y.FillBytes(make([]byte, 32))),
+			},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	defer server.Close()
+
+	set, err := FetchJWKS(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	require.Len(t, set.Keys, 2)
+
+	claims := map[string]interface{}{
+		"iss": "https://idp.example.com",
+		"aud": []string{"https://daml.com/jwt/aud/participant/p1"},
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+
+	edToken := signTestJWT(t, map[string]interface{}{"alg": "EdDSA", "kid": "ed"}, claims, func(in []byte) []byte {
+		return ed25519.Sign(edPriv, in)
+	})
+	parsed, err := set.VerifyJWT(edToken)
+	require.NoError(t, err)
+	require.Equal(t, "https://idp.example.com", parsed.Issuer)
+	require.Equal(t, []string{"https://daml.com/jwt/aud/participant/p1"}, parsed.Audience)
+
+	ecToken := signTestJWT(t, map[string]interface{}{"alg": "ES256", "kid": "ec"}, claims, func(in []byte) []byte {
+		digest := sha256.Sum256(in)
+		r, s, err := ecdsa.Sign(rand.Reader, ecPriv, digest[:])
+		require.NoError(t, err)
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	})
+	_, err = set.VerifyJWT(ecToken)
+	require.NoError(t, err)
+
+	wrongKid := signTestJWT(t, map[string]interface{}{"alg": "EdDSA", "kid": "ec"}, claims, func(in []byte) []byte {
+		return ed25519.Sign(edPriv, in)
+	})
+	_, err = set.VerifyJWT(wrongKid)
+	require.Error(t, err)
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "no keys", data: `{"keys":[]}`},
+		{name: "unsupported key type", data: `{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`},
+		{name: "only encryption keys", data: `{"keys":[{"kty":"OKP","crv":"Ed25519","use":"enc","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`},
+		{name: "algorithm mismatch", data: `{"keys":[{"kty":"OKP","crv":"Ed25519","alg":"RS256","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`},
+		{name: "duplicate kid", data: `{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"a","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},{"kty":"OKP","crv":"Ed25519","kid":"a","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`},
+		{name: "EC point off curve", data: `{"keys":[{"kty":"EC","crv":"P-256","x":"AQ","y":"AQ"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJWKS([]byte(tt.data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseJWKS_SkipsUnusableKeys(t *testing.T) {
+	set, err := ParseJWKS([]byte(`{"keys":[
+		{"kty":"RSA","kid":"enc","use":"enc","n":"AQ","e":"AQAB"},
+		{"kty":"oct","kid":"hmac","k":"c2VjcmV0"},
+		{"kty":"OKP","crv":"Ed25519","kid":"ed","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, set.Keys, 1)
+	require.Equal(t, "ed", set.Keys[0].Kid)
+
+	_, err = set.keyFor("", "EdDSA")
+	require.NoError(t, err)
+
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"oct","kid":"hmac","k":"c2VjcmV0"},{"kty":"EC","crv":"P-256","kid":"bad","x":"AQ","y":"AQ"}]}`))
+	require.ErrorContains(t, err, `kid "hmac"`)
+	require.ErrorContains(t, err, `kid "bad"`)
+}
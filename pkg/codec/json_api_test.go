@@ -0,0 +1,154 @@
+package codec_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCanonicalGoldenVectors_MatchJSONAPI creates all-kinds-of contracts through the JSON
+// Ledger API with field values taken from the golden vectors, and checks that the API
+// accepts them and returns the same encodings in the created event.
+func TestCanonicalGoldenVectors_MatchJSONAPI(t *testing.T) {
+	cl := testutil.RequireClient(t)
+	addr := testutil.GetJSONAPIAddr()
+	if addr == "" {
+		t.Skip("the Canton sandbox does not expose the JSON Ledger API")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	raw, err := os.ReadFile("../../test-data/lf_json_encoding.golden.json")
+	require.NoError(t, err)
+	var golden struct {
+		Vectors []struct {
+			Name string          `json:"name"`
+			JSON json.RawMessage `json:"json"`
+		} `json:"vectors"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &golden))
+	vectors := make(map[string]json.RawMessage, len(golden.Vectors))
+	for _, v := range golden.Vectors {
+		vectors[v.Name] = v.JSON
+	}
+
+	dar, err := os.ReadFile("../../test-data/all-kinds-of-1.0.0.dar")
+	require.NoError(t, err)
+	require.NoError(t, cl.PackageMng.UploadDarFile(ctx, dar, fmt.Sprintf("json-api-golden-%d", time.Now().UnixNano())))
+
+	user, err := cl.UserMng.GetUser(ctx, "app-provider")
+	require.NoError(t, err)
+	party, err := json.Marshal(user.PrimaryParty)
+	require.NoError(t, err)
+
+	pair := func(left, right json.RawMessage) json.RawMessage {
+		data, err := json.Marshal(map[string]json.RawMessage{"left": left, "right": right})
+		require.NoError(t, err)
+		return data
+	}
+	intPair := pair(vectors["int64"], vectors["optional_some"])
+	nestedPair := pair(intPair, intPair)
+
+	tests := []struct {
+		template string
+		args     map[string]json.RawMessage
+	}{
+		{
+			template: "OneOfEverything",
+			args: map[string]json.RawMessage{
+				"operator":        party,
+				"someBoolean":     json.RawMessage(`true`),
+				"someInteger":     vectors["int64"],
+				"someDecimal":     vectors["numeric"],
+				"someMaybe":       vectors["optional_some"],
+				"someMaybeNot":    vectors["optional_none"],
+				"someText":        vectors["text"],
+				"someDate":        vectors["date"],
+				"someDatetime":    vectors["timestamp"],
+				"someSimpleList":  vectors["list"],
+				"someSimplePair":  intPair,
+				"someNestedPair":  nestedPair,
+				"someUglyNesting": json.RawMessage(fmt.Sprintf(`{"tag":"Both","value":{"tag":"Left","value":%s}}`, nestedPair)),
+				"someMeasurement": vectors["numeric_integer"],
+				"someEnum":        vectors["enum"],
+				"theUnit":         vectors["unit"],
+			},
+		},
+		{
+			template: "MappyContract",
+			args: map[string]json.RawMessage{
+				"operator": party,
+				"value":    vectors["text_map"],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			created := createThroughJSONAPI(ctx, t, addr, user.PrimaryParty, "#all-kinds-of:AllKindsOf:"+tt.template, tt.args)
+			for field, want := range tt.args {
+				require.JSONEq(t, string(want), string(created[field]), field)
+			}
+		})
+	}
+}
+
+// createThroughJSONAPI submits a create command to the JSON Ledger API and returns the
+// create argument of the resulting event.
+func createThroughJSONAPI(ctx context.Context, t *testing.T, addr, party, templateID string, args map[string]json.RawMessage) map[string]json.RawMessage {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]interface{}{
+		"commands": map[string]interface{}{
+			"commands": []interface{}{
+				map[string]interface{}{
+					"CreateCommand": map[string]interface{}{
+						"templateId":      templateID,
+						"createArguments": args,
+					},
+				},
+			},
+			"userId":    "app-provider",
+			"commandId": fmt.Sprintf("json-api-golden-%d", time.Now().UnixNano()),
+			"actAs":     []string{party},
+		},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+addr+"/v2/commands/submit-and-wait-for-transaction", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(data))
+
+	var result struct {
+		Transaction struct {
+			Events []struct {
+				CreatedEvent *struct {
+					CreateArgument map[string]json.RawMessage `json:"createArgument"`
+				} `json:"CreatedEvent"`
+			} `json:"events"`
+		} `json:"transaction"`
+	}
+	require.NoError(t, json.Unmarshal(data, &result))
+	for _, event := range result.Transaction.Events {
+		if event.CreatedEvent != nil {
+			return event.CreatedEvent.CreateArgument
+		}
+	}
+	require.FailNow(t, "no created event in the JSON API response", string(data))
+	return nil
+}
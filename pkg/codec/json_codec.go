@@ -3,8 +3,9 @@ package codec
 import (
 	"encoding/json"
 	"fmt"
-	"math/big"
+	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -26,24 +27,27 @@ type JsonCodec struct {
 	// excludeNullValuesInRecords controls whether fields with null values in records are excluded from JSON (true)
 	// or included with a null value (false)
 	ExcludeNullValuesInRecords bool
+
+	// Canonical switches to the Daml-LF JSON encoding used by the JSON Ledger API v2: nested optionals
+	// as [] / [x], GenMap as a list of [key, value] pairs and timestamps with only the needed fraction digits
+	Canonical bool
 }
 
 func isTuple2(v reflect.Value) bool {
-	if v.Kind() == reflect.Struct && v.NumField() == 2 {
-		t := v.Type()
-		return t.Field(0).Name == "First" && t.Field(1).Name == "Second"
-	}
-	return false
+	return v.Kind() == reflect.Struct && v.NumField() == 2 && isTupleType(v.Type())
 }
 
 func isTuple3(v reflect.Value) bool {
-	if v.Kind() == reflect.Struct && v.NumField() == 3 {
-		t := v.Type()
-		return t.Field(0).Name == "First" && t.Field(1).Name == "Second" && t.Field(2).Name == "Third"
-	}
-	return false
+	return v.Kind() == reflect.Struct && v.NumField() == 3 && isTupleType(v.Type())
 }
 
+var (
+	anyTupleType    = reflect.TypeOf((*types.AnyTuple)(nil)).Elem()
+	anyOptionalType = reflect.TypeOf((*types.AnyOptional)(nil)).Elem()
+	anyGenMapType   = reflect.TypeOf((*types.AnyGenMap)(nil)).Elem()
+	anySetType      = reflect.TypeOf((*types.AnySet)(nil)).Elem()
+)
+
 // NewJsonCodec creates a new JsonCodec with default settings following transcode patterns
 func NewJsonCodec() *JsonCodec {
 	return &JsonCodec{
@@ -53,6 +57,15 @@ func NewJsonCodec() *JsonCodec {
 	}
 }
 
+// NewCanonicalJsonCodec creates a JsonCodec producing the Daml-LF JSON encoding accepted by the JSON Ledger API v2
+func NewCanonicalJsonCodec() *JsonCodec {
+	return &JsonCodec{
+		EncodeNumericAsString: true,
+		EncodeInt64AsString:   true,
+		Canonical:             true,
+	}
+}
+
 // NewJsonCodecWithOptions creates a JsonCodec with custom options
 func NewJsonCodecWithOptions(encodeNumericAsString, encodeInt64AsString, excludeNullValues bool) *JsonCodec {
 	return &JsonCodec{
@@ -96,14 +109,16 @@ func (codec *JsonCodec) toDynamicValue(value interface{}) (interface{}, error) {
 		return codec.int64ToDynamicValue(v), nil
 	case types.BOOL:
 		return codec.boolToDynamicValue(v), nil
-	case types.NUMERIC:
+	case types.Numeric:
 		return codec.numericToDynamicValue(v), nil
-	case types.DECIMAL:
-		return codec.decimalToDynamicValue(v), nil
 	case types.TIMESTAMP:
-		return codec.timestampToDynamicValue(v), nil
+		return codec.timestampToDynamicValue(time.Time(v))
+	case time.Time:
+		return codec.timestampToDynamicValue(v)
 	case types.DATE:
-		return codec.dateToDynamicValue(v), nil
+		return codec.dateToDynamicValue(types.DateOf(time.Time(v)))
+	case types.Date:
+		return codec.dateToDynamicValue(v)
 	case types.UNIT:
 		return codec.unitToDynamicValue(v), nil
 	case types.CONTRACT_ID:
@@ -114,6 +129,8 @@ func (codec *JsonCodec) toDynamicValue(value interface{}) (interface{}, error) {
 		return codec.setToDynamicValue(v)
 	case types.GENMAP:
 		return codec.genMapToDynamicValue(v)
+	case []types.AnyEntry:
+		return codec.entriesToDynamicValue(v)
 	case types.TEXTMAP:
 		return codec.textMapToDynamicValue(v)
 	case types.MAP:
@@ -135,9 +152,21 @@ func (codec *JsonCodec) toDynamicValue(value interface{}) (interface{}, error) {
 		if rv.IsNil() {
 			return nil, nil
 		}
+		if codec.Canonical && isNestedOptional(rv.Elem()) {
+			return codec.nestedOptionalToDynamicValue(rv.Elem())
+		}
 		return codec.toDynamicValue(rv.Elem().Interface())
 	}
 
+	switch v := value.(type) {
+	case types.AnyOptional:
+		return codec.optionalToDynamicValue(v)
+	case types.AnyGenMap:
+		return codec.anyGenMapToDynamicValue(v)
+	case types.AnySet:
+		return codec.anySetToDynamicValue(v)
+	}
+
 	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
 		return codec.listToDynamicValueFromReflect(rv)
 	}
@@ -200,42 +229,34 @@ func (codec *JsonCodec) boolToDynamicValue(b types.BOOL) bool {
 	return bool(b)
 }
 
-func (codec *JsonCodec) numericToDynamicValue(n types.NUMERIC) interface{} {
-	if string(n) == "" {
+func (codec *JsonCodec) numericToDynamicValue(n types.Numeric) interface{} {
+	if n.IsZero() {
 		return nil
 	}
 	if codec.EncodeNumericAsString {
-		return string(n)
-	}
-	// Parse string as float for numeric encoding
-	if f, err := strconv.ParseFloat(string(n), 64); err == nil {
-		return f
+		return n.String()
 	}
-	// Fallback to string if parsing fails
-	return string(n)
-}
-
-func (codec *JsonCodec) decimalToDynamicValue(d types.DECIMAL) interface{} {
-	return codec.bigIntToDynamicValue((*big.Int)(d))
+	f, _ := n.Rat().Float64()
+	return f
 }
 
-func (codec *JsonCodec) bigIntToDynamicValue(bi *big.Int) interface{} {
-	if bi == nil {
-		return nil
+// timestampToDynamicValue formats t in UTC, failing outside the DAML range or on sub-microsecond
+// precision the ledger would drop.
+func (codec *JsonCodec) timestampToDynamicValue(t time.Time) (string, error) {
+	if err := types.ValidateTimestamp(t); err != nil {
+		return "", err
 	}
-	if codec.EncodeNumericAsString {
-		return bi.String()
+	if codec.Canonical {
+		return t.UTC().Format(time.RFC3339Nano), nil
 	}
-	f, _ := new(big.Float).SetInt(bi).Float64()
-	return f
-}
-
-func (codec *JsonCodec) timestampToDynamicValue(t types.TIMESTAMP) string {
-	return time.Time(t).Format("2006-01-02T15:04:05.000000Z")
+	return t.UTC().Format("2006-01-02T15:04:05.000000Z"), nil
 }
 
-func (codec *JsonCodec) dateToDynamicValue(d types.DATE) string {
-	return time.Time(d).Format("2006-01-02")
+func (codec *JsonCodec) dateToDynamicValue(d types.Date) (string, error) {
+	if err := d.Validate(); err != nil {
+		return "", err
+	}
+	return d.String(), nil
 }
 
 func (codec *JsonCodec) unitToDynamicValue(_ types.UNIT) map[string]interface{} {
@@ -302,7 +323,115 @@ func (codec *JsonCodec) tuple3ToDynamicValueFromReflect(rv reflect.Value) (inter
 }
 
 func (codec *JsonCodec) genMapToDynamicValue(gm types.GENMAP) (interface{}, error) {
-	return codec.mapToDynamicValueGeneric(gm)
+	if !codec.Canonical {
+		return codec.mapToDynamicValueGeneric(gm)
+	}
+
+	keys := make([]string, 0, len(gm))
+	for k := range gm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]interface{}, len(keys))
+	for i, k := range keys {
+		converted, err := codec.toDynamicValue(gm[k])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = []interface{}{k, converted}
+	}
+	return result, nil
+}
+
+// isNestedOptional reports whether the value inside a present optional is itself an optional.
+func isNestedOptional(elem reflect.Value) bool {
+	if elem.Kind() == reflect.Interface && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Ptr
+}
+
+// nestedOptionalToDynamicValue encodes Some(inner) where inner is an optional: [] for Some(None), [x] for Some(Some(x)).
+func (codec *JsonCodec) nestedOptionalToDynamicValue(inner reflect.Value) (interface{}, error) {
+	if inner.Kind() == reflect.Interface {
+		inner = inner.Elem()
+	}
+	if inner.IsNil() {
+		return []interface{}{}, nil
+	}
+
+	var converted interface{}
+	var err error
+	if isNestedOptional(inner.Elem()) {
+		converted, err = codec.nestedOptionalToDynamicValue(inner.Elem())
+	} else {
+		converted, err = codec.toDynamicValue(inner.Elem().Interface())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{converted}, nil
+}
+
+// optionalToDynamicValue encodes None as null and Some(x) as x; in canonical mode an optional
+// holding an optional becomes [] for Some(None) and [x] for Some(Some(x)).
+func (codec *JsonCodec) optionalToDynamicValue(opt types.AnyOptional) (interface{}, error) {
+	value, some := opt.AnyValue()
+	if !some {
+		return nil, nil
+	}
+	inner, nested := value.(types.AnyOptional)
+	if !codec.Canonical || !nested {
+		return codec.toDynamicValue(value)
+	}
+	if _, innerSome := inner.AnyValue(); !innerSome {
+		return []interface{}{}, nil
+	}
+
+	converted, err := codec.optionalToDynamicValue(inner)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{converted}, nil
+}
+
+// anyGenMapToDynamicValue encodes a typed GenMap as [key, value] pairs in entry order, in every mode,
+// since its keys need not be text.
+func (codec *JsonCodec) anyGenMapToDynamicValue(gm types.AnyGenMap) (interface{}, error) {
+	return codec.entriesToDynamicValue(gm.AnyEntries())
+}
+
+// entriesToDynamicValue encodes GenMap entries as [key, value] pairs, keeping their order.
+func (codec *JsonCodec) entriesToDynamicValue(entries []types.AnyEntry) (interface{}, error) {
+	result := make([]interface{}, len(entries))
+	for i, e := range entries {
+		key, err := codec.toDynamicValue(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := codec.toDynamicValue(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = []interface{}{key, value}
+	}
+	return result, nil
+}
+
+// anySetToDynamicValue encodes a typed Set as a list, or in canonical mode as the DA.Set.Set
+// record whose map field holds [item, {}] pairs.
+func (codec *JsonCodec) anySetToDynamicValue(set types.AnySet) (interface{}, error) {
+	items, err := codec.listToDynamicValueFromReflect(reflect.ValueOf(set.AnyItems()))
+	if err != nil || !codec.Canonical {
+		return items, err
+	}
+
+	pairs := items.([]interface{})
+	for i, item := range pairs {
+		pairs[i] = []interface{}{item, map[string]interface{}{}}
+	}
+	return map[string]interface{}{"map": pairs}, nil
 }
 
 func (codec *JsonCodec) textMapToDynamicValue(tm types.TEXTMAP) (interface{}, error) {
@@ -530,16 +659,13 @@ func (codec *JsonCodec) assignValue(jsonValue interface{}, target reflect.Value)
 		}
 		return fmt.Errorf("expected bool for BOOL, got %T", jsonValue)
 
-	case reflect.TypeOf(types.NUMERIC("")):
+	case reflect.TypeOf(types.Numeric{}):
 		return codec.assignNumericValue(jsonValue, target)
 
-	case reflect.TypeOf(types.DECIMAL(nil)):
-		return codec.assignDecimalValue(jsonValue, target)
-
-	case reflect.TypeOf(types.TIMESTAMP{}):
+	case reflect.TypeOf(types.TIMESTAMP{}), reflect.TypeOf(time.Time{}):
 		return codec.assignTimestampValue(jsonValue, target)
 
-	case reflect.TypeOf(types.DATE{}):
+	case reflect.TypeOf(types.DATE{}), reflect.TypeOf(types.Date{}):
 		return codec.assignDateValue(jsonValue, target)
 
 	case reflect.TypeOf(types.UNIT{}):
@@ -562,6 +688,9 @@ func (codec *JsonCodec) assignValue(jsonValue interface{}, target reflect.Value)
 	case reflect.TypeOf(types.GENMAP{}):
 		return codec.assignGenMapValue(jsonValue, target)
 
+	case reflect.TypeOf([]types.AnyEntry{}):
+		return codec.assignEntriesValue(jsonValue, target)
+
 	case reflect.TypeOf(types.TEXTMAP{}):
 		return codec.assignTextMapValue(jsonValue, target)
 
@@ -576,6 +705,9 @@ func (codec *JsonCodec) assignValue(jsonValue interface{}, target reflect.Value)
 			target.Set(reflect.Zero(target.Type()))
 			return nil
 		}
+		if codec.Canonical && target.Type().Elem().Kind() == reflect.Ptr {
+			return codec.assignNestedOptionalValue(jsonValue, target)
+		}
 
 		newElem := reflect.New(target.Type().Elem())
 		if err := codec.assignValue(jsonValue, newElem.Elem()); err != nil {
@@ -585,10 +717,24 @@ func (codec *JsonCodec) assignValue(jsonValue interface{}, target reflect.Value)
 		return nil
 	}
 
+	// before slices, since an EntryList is a slice
+	switch {
+	case target.Type().Implements(anyOptionalType):
+		return codec.assignOptionalValue(jsonValue, target)
+	case target.Type().Implements(anyGenMapType):
+		return codec.assignAnyGenMapValue(jsonValue, target)
+	case target.Type().Implements(anySetType):
+		return codec.assignAnySetValue(jsonValue, target)
+	}
+
 	if target.Kind() == reflect.Slice {
 		return codec.assignSliceValue(jsonValue, target)
 	}
 
+	if target.Kind() == reflect.Map && target.Type().Key().Kind() == reflect.String {
+		return codec.assignMapValueFromReflect(jsonValue, target)
+	}
+
 	if isTuple2(target) {
 		return codec.assignTuple2Value(jsonValue, target)
 	}
@@ -596,14 +742,14 @@ func (codec *JsonCodec) assignValue(jsonValue interface{}, target reflect.Value)
 		return codec.assignTuple3Value(jsonValue, target)
 	}
 
-	if target.Kind() == reflect.Struct {
-		return codec.assignStructValue(jsonValue, target)
-	}
-
 	if target.Type().Implements(reflect.TypeOf((*types.VARIANT)(nil)).Elem()) {
 		return codec.assignVariantValue(jsonValue, target)
 	}
 
+	if target.Kind() == reflect.Struct {
+		return codec.assignStructValue(jsonValue, target)
+	}
+
 	if target.Type().Implements(reflect.TypeOf((*types.ENUM)(nil)).Elem()) {
 		return codec.assignEnumValue(jsonValue, target)
 	}
@@ -665,117 +811,274 @@ func (codec *JsonCodec) assignInt64Value(jsonValue interface{}, target reflect.V
 }
 
 func (codec *JsonCodec) assignNumericValue(jsonValue interface{}, target reflect.Value) error {
+	var literal string
 	switch v := jsonValue.(type) {
 	case string:
-		// NUMERIC is now a string, so we can assign it directly
-		target.Set(reflect.ValueOf(types.NUMERIC(v)))
-		return nil
+		literal = v
 	case float64:
-		// Convert float to string representation
-		target.Set(reflect.ValueOf(types.NUMERIC(strconv.FormatFloat(v, 'f', -1, 64))))
-		return nil
+		literal = strconv.FormatFloat(v, 'f', -1, 64)
 	case int64:
-		// Convert int to string representation
-		target.Set(reflect.ValueOf(types.NUMERIC(strconv.FormatInt(v, 10))))
+		literal = strconv.FormatInt(v, 10)
+	case types.Numeric:
+		target.Set(reflect.ValueOf(v))
 		return nil
 	default:
 		return fmt.Errorf("expected string or number for NUMERIC, got %T", jsonValue)
 	}
-}
 
-func (codec *JsonCodec) assignDecimalValue(jsonValue interface{}, target reflect.Value) error {
-	return codec.assignBigIntValue(jsonValue, target, "DECIMAL", func(bi *big.Int) reflect.Value {
-		return reflect.ValueOf(types.DECIMAL(bi))
-	})
+	n, err := types.ParseNumeric(literal)
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.ValueOf(n))
+	return nil
 }
 
-func (codec *JsonCodec) assignBigIntValue(jsonValue interface{}, target reflect.Value, typeName string, converter func(*big.Int) reflect.Value) error {
+// assignTimestampValue decodes an RFC 3339 string, or a number of microseconds since the epoch,
+// into a types.TIMESTAMP or time.Time in UTC.
+func (codec *JsonCodec) assignTimestampValue(jsonValue interface{}, target reflect.Value) error {
+	var t time.Time
 	switch v := jsonValue.(type) {
 	case string:
-		if bi, ok := new(big.Int).SetString(v, 10); ok {
-			target.Set(converter(bi))
-			return nil
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp format: %s", v)
 		}
-		if rat, ok := new(big.Rat).SetString(v); ok {
-			scaledInt := new(big.Int)
-			scaledInt.Mul(rat.Num(), big.NewInt(10000000000))
-			scaledInt.Div(scaledInt, rat.Denom())
-			target.Set(converter(scaledInt))
-			return nil
+		if err := types.ValidateTimestamp(parsed); err != nil {
+			return err
 		}
-		return fmt.Errorf("invalid string format for %s: %s", typeName, v)
-	case float64:
-		if v == float64(int64(v)) {
-			bi := big.NewInt(int64(v))
-			target.Set(converter(bi))
-			return nil
+		t = parsed.UTC()
+	case float64, int64:
+		var err error
+		if t, err = types.TimestampFromMicros(jsonInteger(v)); err != nil {
+			return err
 		}
-		rat := new(big.Rat).SetFloat64(v)
-		scaledInt := new(big.Int)
-		scaledInt.Mul(rat.Num(), big.NewInt(10000000000))
-		scaledInt.Div(scaledInt, rat.Denom())
-		target.Set(converter(scaledInt))
-		return nil
-	case int64:
-		bi := big.NewInt(v)
-		target.Set(converter(bi))
-		return nil
 	default:
-		return fmt.Errorf("expected string or number for %s, got %T", typeName, jsonValue)
+		return fmt.Errorf("expected string or number for TIMESTAMP, got %T", jsonValue)
 	}
+	target.Set(reflect.ValueOf(t).Convert(target.Type()))
+	return nil
 }
 
-func (codec *JsonCodec) assignTimestampValue(jsonValue interface{}, target reflect.Value) error {
-	switch v := jsonValue.(type) {
-	case string:
-		if t, err := time.Parse("2006-01-02T15:04:05.000000Z", v); err == nil {
-			target.Set(reflect.ValueOf(types.TIMESTAMP(t)))
-			return nil
-		}
-		if t, err := time.Parse(time.RFC3339, v); err == nil {
-			target.Set(reflect.ValueOf(types.TIMESTAMP(t)))
-			return nil
-		}
-		return fmt.Errorf("invalid timestamp format: %s", v)
+// jsonInteger converts a JSON number, or an integer left by a Go-side conversion, to int64.
+func jsonInteger(v interface{}) int64 {
+	switch n := v.(type) {
 	case float64:
-		t := time.Unix(int64(v)/1000000, (int64(v)%1000000)*1000)
-		target.Set(reflect.ValueOf(types.TIMESTAMP(t)))
-		return nil
+		return int64(n)
+	case int32:
+		return int64(n)
 	case int64:
-		t := time.Unix(v/1000000, (v%1000000)*1000)
-		target.Set(reflect.ValueOf(types.TIMESTAMP(t)))
-		return nil
-	default:
-		return fmt.Errorf("expected string or number for TIMESTAMP, got %T", jsonValue)
+		return n
 	}
+	return 0
 }
 
+// assignDateValue decodes a YYYY-MM-DD string, or a number of days since the epoch, into a
+// types.DATE at midnight UTC or a types.Date.
 func (codec *JsonCodec) assignDateValue(jsonValue interface{}, target reflect.Value) error {
+	var d types.Date
 	switch v := jsonValue.(type) {
 	case string:
-		if t, err := time.Parse("2006-01-02", v); err == nil {
-			target.Set(reflect.ValueOf(types.DATE(t)))
-			return nil
+		parsed, err := types.ParseDate(v)
+		if err != nil {
+			return err
+		}
+		d = parsed
+	case float64, int64, int32:
+		days := jsonInteger(v)
+		if days < math.MinInt32 || days > math.MaxInt32 {
+			return fmt.Errorf("date %d days since the epoch is outside the DAML range", days)
+		}
+		var err error
+		if d, err = types.DateFromDays(int32(days)); err != nil {
+			return err
 		}
-		return fmt.Errorf("invalid date format: %s", v)
-	case float64:
-		t := time.Unix(int64(v)*86400, 0).UTC()
-		target.Set(reflect.ValueOf(types.DATE(t)))
-		return nil
-	case int64:
-		t := time.Unix(v*86400, 0).UTC()
-		target.Set(reflect.ValueOf(types.DATE(t)))
-		return nil
-	case int32:
-		t := time.Unix(int64(v)*86400, 0).UTC()
-		target.Set(reflect.ValueOf(types.DATE(t)))
-		return nil
 	default:
 		return fmt.Errorf("expected string or number for DATE, got %T", jsonValue)
 	}
+
+	if target.Type() == reflect.TypeOf(types.Date{}) {
+		target.Set(reflect.ValueOf(d))
+	} else {
+		target.Set(reflect.ValueOf(types.DATE(d.In(time.UTC))))
+	}
+	return nil
+}
+
+func (codec *JsonCodec) assignNestedOptionalValue(jsonValue interface{}, target reflect.Value) error {
+	inner, some, err := unwrapNestedOptional(jsonValue)
+	if err != nil {
+		return err
+	}
+
+	newElem := reflect.New(target.Type().Elem())
+	if some {
+		if err := codec.assignValue(inner, newElem.Elem()); err != nil {
+			return err
+		}
+	}
+	target.Set(newElem)
+	return nil
+}
+
+// unwrapNestedOptional returns x for [x] and reports false for [].
+func unwrapNestedOptional(jsonValue interface{}) (interface{}, bool, error) {
+	arr, ok := jsonValue.([]interface{})
+	if !ok || len(arr) > 1 {
+		return nil, false, fmt.Errorf("expected [] or [value] for nested optional, got %v", jsonValue)
+	}
+	if len(arr) == 0 {
+		return nil, false, nil
+	}
+	if arr[0] == nil {
+		return nil, false, fmt.Errorf("nested optional [null] is not a valid encoding")
+	}
+	return arr[0], true, nil
+}
+
+func (codec *JsonCodec) assignOptionalValue(jsonValue interface{}, target reflect.Value) error {
+	opt := reflect.Zero(target.Type()).Interface().(types.AnyOptional)
+	elem := reflect.New(opt.ValueType()).Elem()
+
+	if codec.Canonical && opt.ValueType().Implements(anyOptionalType) {
+		inner, some, err := unwrapNestedOptional(jsonValue)
+		if err != nil {
+			return err
+		}
+		if some {
+			if err := codec.assignValue(inner, elem); err != nil {
+				return err
+			}
+		}
+	} else if err := codec.assignValue(jsonValue, elem); err != nil {
+		return err
+	}
+
+	result, err := opt.WithAnyValue(elem.Interface())
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.ValueOf(result))
+	return nil
+}
+
+// assignAnyGenMapValue decodes [key, value] pairs into a typed GenMap. An object is also accepted
+// when the key type is text.
+func (codec *JsonCodec) assignAnyGenMapValue(jsonValue interface{}, target reflect.Value) error {
+	gm := reflect.Zero(target.Type()).Interface().(types.AnyGenMap)
+
+	var pairs []interface{}
+	switch v := jsonValue.(type) {
+	case []interface{}:
+		pairs = v
+	case map[string]interface{}:
+		if gm.KeyType().Kind() != reflect.String {
+			return fmt.Errorf("expected [key, value] pairs for GenMap with %v keys, got object", gm.KeyType())
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			pairs = append(pairs, []interface{}{k, v[k]})
+		}
+	default:
+		return fmt.Errorf("expected array for GenMap, got %T", jsonValue)
+	}
+
+	entries := make([]types.AnyEntry, len(pairs))
+	for i, p := range pairs {
+		pair, ok := p.([]interface{})
+		if !ok || len(pair) != 2 {
+			return fmt.Errorf("GenMap entry %d is not a [key, value] pair", i)
+		}
+		key := reflect.New(gm.KeyType()).Elem()
+		if err := codec.assignValue(pair[0], key); err != nil {
+			return fmt.Errorf("failed to assign GenMap key %d: %w", i, err)
+		}
+		value := reflect.New(gm.ValueType()).Elem()
+		if err := codec.assignValue(pair[1], value); err != nil {
+			return fmt.Errorf("failed to assign GenMap value %d: %w", i, err)
+		}
+		entries[i] = types.AnyEntry{Key: key.Interface(), Value: value.Interface()}
+	}
+
+	result, err := gm.WithAnyEntries(entries)
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.ValueOf(result))
+	return nil
+}
+
+func (codec *JsonCodec) assignAnySetValue(jsonValue interface{}, target reflect.Value) error {
+	var arr []interface{}
+	switch v := jsonValue.(type) {
+	case []interface{}:
+		arr = v
+	case map[string]interface{}:
+		switch m := v["map"].(type) {
+		case []interface{}:
+			for i, p := range m {
+				pair, ok := p.([]interface{})
+				if !ok || len(pair) != 2 {
+					return fmt.Errorf("Set entry %d is not an [item, {}] pair", i)
+				}
+				arr = append(arr, pair[0])
+			}
+		case map[string]interface{}:
+			// a DA.Set.Set record read from the ledger has its map keyed by text
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				arr = append(arr, k)
+			}
+		default:
+			return fmt.Errorf("expected map field with [item, {}] pairs for Set")
+		}
+	default:
+		return fmt.Errorf("expected array for Set, got %T", jsonValue)
+	}
+
+	set := reflect.Zero(target.Type()).Interface().(types.AnySet)
+	items := make([]interface{}, len(arr))
+	for i, v := range arr {
+		item := reflect.New(set.ValueType()).Elem()
+		if err := codec.assignValue(v, item); err != nil {
+			return fmt.Errorf("failed to assign Set item %d: %w", i, err)
+		}
+		items[i] = item.Interface()
+	}
+
+	result, err := set.WithAnyItems(items)
+	if err != nil {
+		return err
+	}
+	target.Set(reflect.ValueOf(result))
+	return nil
 }
 
 func (codec *JsonCodec) assignGenMapValue(jsonValue interface{}, target reflect.Value) error {
+	if pairs, ok := jsonValue.([]interface{}); ok {
+		result := make(types.GENMAP, len(pairs))
+		for i, p := range pairs {
+			pair, ok := p.([]interface{})
+			if !ok || len(pair) != 2 {
+				return fmt.Errorf("GENMAP entry %d is not a [key, value] pair", i)
+			}
+			key, ok := pair[0].(string)
+			if !ok {
+				key = fmt.Sprintf("%v", pair[0])
+			}
+			result[key] = pair[1]
+		}
+		target.Set(reflect.ValueOf(result))
+		return nil
+	}
 	if m, ok := jsonValue.(map[string]interface{}); ok {
 		result := make(types.GENMAP)
 		for k, v := range m {
@@ -787,6 +1090,24 @@ func (codec *JsonCodec) assignGenMapValue(jsonValue interface{}, target reflect.
 	return fmt.Errorf("expected object for GENMAP, got %T", jsonValue)
 }
 
+// assignEntriesValue decodes [key, value] pairs into untyped GenMap entries, keeping their order.
+func (codec *JsonCodec) assignEntriesValue(jsonValue interface{}, target reflect.Value) error {
+	pairs, ok := jsonValue.([]interface{})
+	if !ok {
+		return fmt.Errorf("expected array for GenMap entries, got %T", jsonValue)
+	}
+	entries := make([]types.AnyEntry, len(pairs))
+	for i, p := range pairs {
+		pair, ok := p.([]interface{})
+		if !ok || len(pair) != 2 {
+			return fmt.Errorf("GenMap entry %d is not a [key, value] pair", i)
+		}
+		entries[i] = types.AnyEntry{Key: pair[0], Value: pair[1]}
+	}
+	target.Set(reflect.ValueOf(entries))
+	return nil
+}
+
 func (codec *JsonCodec) assignTextMapValue(jsonValue interface{}, target reflect.Value) error {
 	if m, ok := jsonValue.(map[string]interface{}); ok {
 		result := make(types.TEXTMAP)
@@ -815,6 +1136,25 @@ func (codec *JsonCodec) assignMapValue(jsonValue interface{}, target reflect.Val
 	return fmt.Errorf("expected object for MAP, got %T", jsonValue)
 }
 
+// assignMapValueFromReflect decodes an object into any map keyed by text, such as TextMap[V].
+func (codec *JsonCodec) assignMapValueFromReflect(jsonValue interface{}, target reflect.Value) error {
+	m, ok := jsonValue.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object for map, got %T", jsonValue)
+	}
+
+	result := reflect.MakeMapWithSize(target.Type(), len(m))
+	for k, v := range m {
+		value := reflect.New(target.Type().Elem()).Elem()
+		if err := codec.assignValue(v, value); err != nil {
+			return fmt.Errorf("failed to assign map value %s: %w", k, err)
+		}
+		result.SetMapIndex(reflect.ValueOf(k).Convert(target.Type().Key()), value)
+	}
+	target.Set(result)
+	return nil
+}
+
 func (codec *JsonCodec) assignListValue(jsonValue interface{}, target reflect.Value) error {
 	if arr, ok := jsonValue.([]interface{}); ok {
 		result := make(types.LIST, len(arr))
@@ -961,6 +1301,11 @@ func (codec *JsonCodec) assignVariantValue(jsonValue interface{}, target reflect
 			return fmt.Errorf("variant missing tag field")
 		}
 
+		// Generated variants are structs with one pointer field per constructor
+		if target.Kind() == reflect.Struct {
+			return codec.assignVariantConstructor(tag, m["value"], target)
+		}
+
 		// For other variant types, try to use built-in UnmarshalJSON if available
 		if target.Type().Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
 			jsonBytes, err := json.Marshal(jsonValue)
@@ -983,6 +1328,33 @@ func (codec *JsonCodec) assignVariantValue(jsonValue interface{}, target reflect
 	return fmt.Errorf("expected object for variant, got %T", jsonValue)
 }
 
+func (codec *JsonCodec) assignVariantConstructor(tag string, value interface{}, target reflect.Value) error {
+	targetType := target.Type()
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Field(i)
+		if !field.CanSet() || field.Kind() != reflect.Ptr {
+			continue
+		}
+
+		name := targetType.Field(i).Name
+		if jsonTag := targetType.Field(i).Tag.Get("json"); jsonTag != "" && jsonTag != "-" {
+			name, _, _ = strings.Cut(jsonTag, ",")
+		}
+		if name != tag {
+			continue
+		}
+
+		target.Set(reflect.Zero(targetType))
+		newElem := reflect.New(field.Type().Elem())
+		if err := codec.assignValue(value, newElem.Elem()); err != nil {
+			return fmt.Errorf("failed to assign variant %s: %w", tag, err)
+		}
+		target.Field(i).Set(newElem)
+		return nil
+	}
+	return fmt.Errorf("variant %v has no constructor %s", targetType, tag)
+}
+
 func (codec *JsonCodec) assignEnumValue(jsonValue interface{}, target reflect.Value) error {
 	if str, ok := jsonValue.(string); ok {
 		if target.Type().Kind() == reflect.String {
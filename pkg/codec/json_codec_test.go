@@ -2,7 +2,7 @@ package codec
 
 import (
 	"encoding/json"
-	"math/big"
+	"os"
 	"reflect"
 	"testing"
 	"time"
@@ -42,13 +42,13 @@ func TestJsonCodec_Marshall_BasicTypes(t *testing.T) {
 		},
 		{
 			name:     "NUMERIC as string (default)",
-			input:    NUMERIC("123456789"),
+			input:    MustParseNumeric("123456789"),
 			expected: `"123456789"`,
 		},
 		{
 			name:     "DECIMAL as string (default)",
-			input:    DECIMAL(big.NewInt(987654321)),
-			expected: `"987654321"`,
+			input:    MustParseNumeric("0.0987654321"),
+			expected: `"0.0987654321"`,
 		},
 		{
 			name:     "TIMESTAMP as ISO string",
@@ -96,7 +96,7 @@ func TestJsonCodec_Marshall_NumericAsNumber(t *testing.T) {
 		},
 		{
 			name:     "NUMERIC as number",
-			input:    NUMERIC("123"),
+			input:    MustParseNumeric("123"),
 			expected: `123`,
 		},
 	}
@@ -163,7 +163,7 @@ func TestJsonCodec_Marshall_Records(t *testing.T) {
 		Name:     TEXT("Alice"),
 		Age:      INT64(30),
 		Active:   BOOL(true),
-		Balance:  NUMERIC("1000"),
+		Balance:  MustParseNumeric("1000"),
 		Optional: &optionalValue,
 	}
 
@@ -188,7 +188,7 @@ func TestJsonCodec_Marshall_RecordWithNilOptional(t *testing.T) {
 		Name:     TEXT("Bob"),
 		Age:      INT64(25),
 		Active:   BOOL(false),
-		Balance:  NUMERIC("500"),
+		Balance:  MustParseNumeric("500"),
 		Optional: nil, // nil optional
 	}
 
@@ -213,7 +213,7 @@ func TestJsonCodec_Marshall_ExcludeNullValues(t *testing.T) {
 		Name:     TEXT("Charlie"),
 		Age:      INT64(35),
 		Active:   BOOL(true),
-		Balance:  NUMERIC("750"),
+		Balance:  MustParseNumeric("750"),
 		Optional: nil, // nil optional
 	}
 
@@ -282,7 +282,7 @@ func TestJsonCodec_Marshall_ComplexNested(t *testing.T) {
 			Name:     TEXT("Nested"),
 			Age:      INT64(40),
 			Active:   BOOL(true),
-			Balance:  NUMERIC("2000"),
+			Balance:  MustParseNumeric("2000"),
 			Optional: &optionalText,
 		},
 	}
@@ -354,13 +354,13 @@ func TestJsonCodec_Unmarshall_BasicTypes(t *testing.T) {
 			name:     "NUMERIC from string",
 			json:     `"123456789"`,
 			target:   new(NUMERIC),
-			expected: NUMERIC("123456789"),
+			expected: MustParseNumeric("123456789"),
 		},
 		{
 			name:     "NUMERIC from number",
 			json:     `123`,
 			target:   new(NUMERIC),
-			expected: NUMERIC("123"),
+			expected: MustParseNumeric("123"),
 		},
 		{
 			name:     "TIMESTAMP from ISO string",
@@ -466,7 +466,7 @@ func TestJsonCodec_Unmarshall_Records(t *testing.T) {
 	assert.Equal(t, TEXT("Alice"), result.Name)
 	assert.Equal(t, INT64(30), result.Age)
 	assert.Equal(t, BOOL(true), result.Active)
-	assert.Equal(t, NUMERIC("1000"), result.Balance)
+	assert.Equal(t, MustParseNumeric("1000"), result.Balance)
 	require.NotNil(t, result.Optional)
 	assert.Equal(t, TEXT("present"), *result.Optional)
 }
@@ -488,7 +488,7 @@ func TestJsonCodec_Unmarshall_RecordWithNilOptional(t *testing.T) {
 	assert.Equal(t, TEXT("Bob"), result.Name)
 	assert.Equal(t, INT64(25), result.Age)
 	assert.Equal(t, BOOL(false), result.Active)
-	assert.Equal(t, NUMERIC("500"), result.Balance)
+	assert.Equal(t, MustParseNumeric("500"), result.Balance)
 	assert.Nil(t, result.Optional)
 }
 
@@ -500,7 +500,7 @@ func TestJsonCodec_RoundTrip_Marshall_Unmarshall(t *testing.T) {
 		Name:     TEXT("Alice"),
 		Age:      INT64(30),
 		Active:   BOOL(true),
-		Balance:  NUMERIC("1000"),
+		Balance:  MustParseNumeric("1000"),
 		Optional: &optionalValue,
 	}
 
@@ -514,7 +514,7 @@ func TestJsonCodec_RoundTrip_Marshall_Unmarshall(t *testing.T) {
 	assert.Equal(t, original.Age, result.Age)
 	assert.Equal(t, original.Active, result.Active)
 
-	assert.Equal(t, string(original.Balance), string(result.Balance))
+	assert.Equal(t, original.Balance.String(), result.Balance.String())
 	require.NotNil(t, result.Optional)
 	assert.Equal(t, *original.Optional, *result.Optional)
 }
@@ -526,7 +526,7 @@ func TestJsonCodec_RoundTrip_WithNumericAsNumber(t *testing.T) {
 		Name:     TEXT("Bob"),
 		Age:      INT64(25),
 		Active:   BOOL(false),
-		Balance:  NUMERIC("500"),
+		Balance:  MustParseNumeric("500"),
 		Optional: nil,
 	}
 
@@ -540,7 +540,7 @@ func TestJsonCodec_RoundTrip_WithNumericAsNumber(t *testing.T) {
 	assert.Equal(t, original.Name, result.Name)
 	assert.Equal(t, original.Age, result.Age)
 	assert.Equal(t, original.Active, result.Active)
-	assert.Equal(t, string(original.Balance), string(result.Balance))
+	assert.Equal(t, original.Balance.String(), result.Balance.String())
 	assert.Nil(t, result.Optional)
 }
 
@@ -757,3 +757,332 @@ func TestJsonCodec_RoundTrip_RELTIME_SET(t *testing.T) {
 // 	require.NoError(t, err)
 // 	assert.Equal(t, original, result)
 // }
+
+func TestJsonCodec_NumericScale(t *testing.T) {
+	n := MustParseNumeric("-0.0000000200")
+	require.Equal(t, int32(10), n.Scale())
+	require.Equal(t, "-0.0000000200", n.String())
+
+	codec := NewJsonCodec()
+	data, err := codec.Marshall(n)
+	require.NoError(t, err)
+	require.Equal(t, `"-0.0000000200"`, string(data))
+
+	var decoded NUMERIC
+	require.NoError(t, codec.Unmarshall(data, &decoded))
+	require.True(t, n.Equal(decoded))
+}
+
+type TestShape struct {
+	Circle *NUMERIC `json:"Circle,omitempty"`
+	Square *INT64   `json:"Square,omitempty"`
+}
+
+func (s TestShape) GetVariantTag() string {
+	if s.Circle != nil {
+		return "Circle"
+	}
+	if s.Square != nil {
+		return "Square"
+	}
+	return ""
+}
+
+func (s TestShape) GetVariantValue() interface{} {
+	if s.Circle != nil {
+		return s.Circle
+	}
+	if s.Square != nil {
+		return s.Square
+	}
+	return nil
+}
+
+var _ VARIANT = TestShape{}
+
+type TestHolding struct {
+	Owner  PARTY   `json:"owner"`
+	Amount NUMERIC `json:"amount"`
+	Note   *TEXT   `json:"note"`
+	Tags   []TEXT  `json:"tags"`
+	Meta   GENMAP  `json:"meta"`
+}
+
+func TestJsonCodec_Canonical_GoldenVectors(t *testing.T) {
+	raw, err := os.ReadFile("../../test-data/lf_json_encoding.golden.json")
+	require.NoError(t, err)
+
+	var golden struct {
+		Vectors []struct {
+			Name string          `json:"name"`
+			JSON json.RawMessage `json:"json"`
+		} `json:"vectors"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &golden))
+	vectors := golden.Vectors
+
+	int64Ptr := func(v INT64) *INT64 { return &v }
+	noInt := (*INT64)(nil)
+	circle := MustParseNumeric("0.5")
+
+	values := map[string]interface{}{
+		"unit":                    UNIT{},
+		"int64":                   INT64(-42),
+		"numeric":                 MustParseNumeric("0.0000000200"),
+		"numeric_integer":         MustParseNumeric("17"),
+		"text":                    TEXT(`a "quoted" text`),
+		"party":                   PARTY("Alice::1220aa"),
+		"contract_id":             CONTRACT_ID("0041c1b4e8f1"),
+		"date":                    DATE(time.Date(2019, 6, 18, 0, 0, 0, 0, time.UTC)),
+		"timestamp":               TIMESTAMP(time.Date(1990, 11, 9, 4, 30, 23, 123456000, time.UTC)),
+		"timestamp_whole_seconds": TIMESTAMP(time.Date(1990, 11, 9, 4, 30, 23, 0, time.UTC)),
+		"optional_none":           noInt,
+		"optional_some":           int64Ptr(42),
+		"optional_some_none":      &noInt,
+		"optional_some_some":      func() **INT64 { p := int64Ptr(42); return &p }(),
+		"optional_some_some_none": func() ***INT64 { p := &noInt; return &p }(),
+		"list":                    []INT64{1, 2, 3},
+		"text_map":                TEXTMAP{"a": "1", "b": "2"},
+		"gen_map":                 GENMAP{"b": INT64(2), "a": INT64(1)},
+		"gen_map_empty":           GENMAP{},
+		"enum":                    TestColorRed,
+		"variant":                 TestShape{Circle: &circle},
+		"tuple2":                  TUPLE2{First: PARTY("Alice::1220aa"), Second: INT64(1)},
+		"record": TestHolding{
+			Owner:  "Alice::1220aa",
+			Amount: MustParseNumeric("1.50"),
+			Tags:   []TEXT{},
+			Meta:   GENMAP{"k": TEXT("v")},
+		},
+	}
+
+	codec := NewCanonicalJsonCodec()
+	for _, vector := range vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			value, ok := values[vector.Name]
+			require.True(t, ok, "no Go value for golden vector")
+
+			encoded, err := codec.Marshall(value)
+			require.NoError(t, err)
+			require.JSONEq(t, string(vector.JSON), string(encoded))
+
+			decoded := reflect.New(reflect.TypeOf(value))
+			require.NoError(t, codec.Unmarshall(vector.JSON, decoded.Interface()))
+			reencoded, err := codec.Marshall(decoded.Elem().Interface())
+			require.NoError(t, err)
+			require.JSONEq(t, string(vector.JSON), string(reencoded))
+		})
+	}
+}
+
+func TestJsonCodec_DateAndTimestamp(t *testing.T) {
+	codec := NewJsonCodec()
+	berlin := time.FixedZone("CET", 3600)
+
+	encoded, err := codec.Marshall(TIMESTAMP(time.Date(2024, 5, 1, 1, 30, 0, 5000, berlin)))
+	require.NoError(t, err)
+	require.Equal(t, `"2024-05-01T00:30:00.000005Z"`, string(encoded))
+
+	_, err = codec.Marshall(TIMESTAMP(time.Date(2024, 5, 1, 0, 0, 0, 1, time.UTC)))
+	require.ErrorContains(t, err, "sub-microsecond precision")
+	_, err = codec.Marshall(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorContains(t, err, "outside the DAML range")
+	_, err = codec.Marshall(Date{Year: 0, Month: time.December, Day: 31})
+	require.ErrorContains(t, err, "outside the DAML range")
+
+	encoded, err = codec.Marshall(Date{Year: 2024, Month: time.February, Day: 29})
+	require.NoError(t, err)
+	require.Equal(t, `"2024-02-29"`, string(encoded))
+
+	var d Date
+	require.NoError(t, codec.Unmarshall([]byte(`"0001-01-01"`), &d))
+	require.Equal(t, MinDate, d)
+	require.NoError(t, codec.Unmarshall([]byte(`19844`), &d))
+	require.Equal(t, Date{Year: 2024, Month: time.May, Day: 1}, d)
+	require.Error(t, codec.Unmarshall([]byte(`"2023-02-29"`), &d))
+	require.Error(t, codec.Unmarshall([]byte(`2932897`), &d))
+
+	var ts time.Time
+	require.NoError(t, codec.Unmarshall([]byte(`"2024-05-01T02:30:00.123456+02:00"`), &ts))
+	require.Equal(t, time.Date(2024, 5, 1, 0, 30, 0, 123456000, time.UTC), ts)
+	require.ErrorContains(t, codec.Unmarshall([]byte(`"2024-05-01T00:30:00.1234567Z"`), &ts), "sub-microsecond")
+	require.ErrorContains(t, codec.Unmarshall([]byte(`"10000-01-01T00:00:00Z"`), &ts), "invalid timestamp")
+
+	var date DATE
+	require.NoError(t, codec.Unmarshall([]byte(`"9999-12-31"`), &date))
+	require.Equal(t, DATE(time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)), date)
+}
+
+func TestJsonCodec_Canonical_NestedOptionalErrors(t *testing.T) {
+	codec := NewCanonicalJsonCodec()
+
+	var nested **INT64
+	require.Error(t, codec.Unmarshall([]byte(`["1", "2"]`), &nested))
+	require.Error(t, codec.Unmarshall([]byte(`[null]`), &nested))
+	require.Error(t, codec.Unmarshall([]byte(`"1"`), &nested))
+
+	require.NoError(t, codec.Unmarshall([]byte(`[]`), &nested))
+	require.NotNil(t, nested)
+	require.Nil(t, *nested)
+}
+
+func TestJsonCodec_GenericTypes_GoldenVectors(t *testing.T) {
+	raw, err := os.ReadFile("../../test-data/lf_json_encoding.golden.json")
+	require.NoError(t, err)
+
+	var golden struct {
+		Vectors []struct {
+			Name string          `json:"name"`
+			JSON json.RawMessage `json:"json"`
+		} `json:"vectors"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &golden))
+	vectors := golden.Vectors
+
+	values := map[string]interface{}{
+		"optional_none":           None[INT64](),
+		"optional_some":           Some(INT64(42)),
+		"optional_some_none":      Some(None[INT64]()),
+		"optional_some_some":      Some(Some(INT64(42))),
+		"optional_some_some_none": Some(Some(None[INT64]())),
+		"text_map":                TextMap[INT64]{"a": 1, "b": 2},
+		"gen_map":                 NewGenMap(MapEntry[TEXT, INT64]{Key: "a", Value: 1}, MapEntry[TEXT, INT64]{Key: "b", Value: 2}),
+		"gen_map_empty":           GenMap[TEXT, INT64]{},
+		"tuple2":                  Tuple2[PARTY, INT64]{First: "Alice::1220aa", Second: 1},
+	}
+
+	codec := NewCanonicalJsonCodec()
+	for _, vector := range vectors {
+		value, ok := values[vector.Name]
+		if !ok {
+			continue
+		}
+		t.Run(vector.Name, func(t *testing.T) {
+			encoded, err := codec.Marshall(value)
+			require.NoError(t, err)
+			require.JSONEq(t, string(vector.JSON), string(encoded))
+
+			decoded := reflect.New(reflect.TypeOf(value))
+			require.NoError(t, codec.Unmarshall(vector.JSON, decoded.Interface()))
+			require.Equal(t, value, decoded.Elem().Interface())
+		})
+	}
+}
+
+func TestJsonCodec_GenericTypes(t *testing.T) {
+	codec := NewJsonCodec()
+
+	t.Run("GenMap keeps entry order and non-text keys", func(t *testing.T) {
+		m := NewGenMap(MapEntry[INT64, TEXT]{Key: 3, Value: "c"}, MapEntry[INT64, TEXT]{Key: 1, Value: "a"})
+		encoded, err := codec.Marshall(m)
+		require.NoError(t, err)
+		require.JSONEq(t, `[["3","c"],["1","a"]]`, string(encoded))
+
+		var decoded GenMap[INT64, TEXT]
+		require.NoError(t, codec.Unmarshall(encoded, &decoded))
+		require.Equal(t, []INT64{3, 1}, decoded.Keys())
+		v, ok := decoded.Get(1)
+		require.True(t, ok)
+		require.Equal(t, TEXT("a"), v)
+
+		require.Error(t, codec.Unmarshall([]byte(`{"1":"a"}`), &decoded))
+	})
+
+	t.Run("EntryList keeps entries with non-comparable keys", func(t *testing.T) {
+		l := EntryList[[]TEXT, INT64]{{Key: []TEXT{"b"}, Value: 2}, {Key: []TEXT{"a", "c"}, Value: 1}}
+		encoded, err := codec.Marshall(l)
+		require.NoError(t, err)
+		require.JSONEq(t, `[[["b"],"2"],[["a","c"],"1"]]`, string(encoded))
+
+		var decoded EntryList[[]TEXT, INT64]
+		require.NoError(t, codec.Unmarshall(encoded, &decoded))
+		require.Equal(t, l, decoded)
+		v, ok := decoded.Get([]TEXT{"a", "c"})
+		require.True(t, ok)
+		require.Equal(t, INT64(1), v)
+
+		var entries []AnyEntry
+		require.NoError(t, codec.Unmarshall(encoded, &entries))
+		require.Equal(t, []AnyEntry{{Key: []interface{}{"b"}, Value: "2"}, {Key: []interface{}{"a", "c"}, Value: "1"}}, entries)
+		again, err := codec.Marshall(entries)
+		require.NoError(t, err)
+		require.JSONEq(t, string(encoded), string(again))
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		s := NewSet[PARTY]("Bob", "Alice", "Bob")
+		require.Equal(t, 2, s.Len())
+
+		encoded, err := codec.Marshall(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `["Bob","Alice"]`, string(encoded))
+
+		canonical, err := NewCanonicalJsonCodec().Marshall(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"map":[["Bob",{}],["Alice",{}]]}`, string(canonical))
+
+		for _, data := range []string{string(encoded), string(canonical)} {
+			var decoded Set[PARTY]
+			require.NoError(t, codec.Unmarshall([]byte(data), &decoded))
+			require.Equal(t, s, decoded)
+		}
+	})
+
+	t.Run("record with generic fields", func(t *testing.T) {
+		type holding struct {
+			Owner  PARTY                          `json:"owner"`
+			Note   Optional[TEXT]                 `json:"note"`
+			Lots   GenMap[TEXT, NUMERIC]          `json:"lots"`
+			Triple Tuple3[TEXT, BOOL, INT64]      `json:"triple"`
+			Nested Optional[Tuple2[PARTY, INT64]] `json:"nested"`
+		}
+		value := holding{
+			Owner:  "Alice",
+			Lots:   NewGenMap(MapEntry[TEXT, NUMERIC]{Key: "x", Value: MustParseNumeric("1.50")}),
+			Triple: Tuple3[TEXT, BOOL, INT64]{First: "a", Second: true, Third: 3},
+			Nested: Some(Tuple2[PARTY, INT64]{First: "Bob", Second: 2}),
+		}
+
+		encoded, err := codec.Marshall(value)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"owner": "Alice",
+			"note": null,
+			"lots": [["x", "1.50"]],
+			"triple": {"_1": "a", "_2": true, "_3": "3"},
+			"nested": {"_1": "Bob", "_2": "2"}
+		}`, string(encoded))
+
+		var decoded holding
+		require.NoError(t, codec.Unmarshall(encoded, &decoded))
+		require.Equal(t, value, decoded)
+	})
+
+	t.Run("Optional accessors", func(t *testing.T) {
+		require.Equal(t, INT64(5), None[INT64]().OrElse(5))
+		v, ok := Some(INT64(1)).Get()
+		require.True(t, ok)
+		require.Equal(t, INT64(1), v)
+	})
+
+	t.Run("encoding/json", func(t *testing.T) {
+		value := struct {
+			A Optional[Optional[Optional[TEXT]]] `json:"a"`
+			B GenMap[INT64, TEXT]                `json:"b"`
+			C Set[TEXT]                          `json:"c"`
+		}{
+			A: Some(Some(None[TEXT]())),
+			B: NewGenMap(MapEntry[INT64, TEXT]{Key: 2, Value: "x"}),
+			C: NewSet[TEXT]("y"),
+		}
+
+		encoded, err := json.Marshal(value)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"a":[[]],"b":[[2,"x"]],"c":["y"]}`, string(encoded))
+
+		decoded := value
+		decoded.A, decoded.B, decoded.C = None[Optional[Optional[TEXT]]](), GenMap[INT64, TEXT]{}, Set[TEXT]{}
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+		require.Equal(t, value, decoded)
+	})
+}
@@ -0,0 +1,29 @@
+package codec_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/testutil"
+)
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	err := testutil.Setup(ctx)
+	if errors.Is(err, testutil.ErrDockerUnavailable) {
+		fmt.Fprintf(os.Stderr, "running without the Canton sandbox: %v\n", err)
+	} else if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+
+	testutil.Teardown()
+	os.Exit(code)
+}
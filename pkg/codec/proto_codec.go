@@ -0,0 +1,1065 @@
+package codec
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
+	"github.com/noders-team/go-daml/pkg/types"
+	"github.com/shopspring/decimal"
+)
+
+// ProtoCodec converts Go values, including generated template and data types, directly to and from
+// Ledger API v2.Value and v2.Record messages and their protobuf encoding. The conversion of each Go
+// type is planned once with reflection and cached.
+type ProtoCodec struct{}
+
+// NewProtoCodec creates a ProtoCodec. All codecs share the same cache of type plans.
+func NewProtoCodec() *ProtoCodec {
+	return &ProtoCodec{}
+}
+
+// ToValue converts value, or the value a non-nil pointer points to, to a v2.Value.
+func (codec *ProtoCodec) ToValue(value interface{}) (*v2.Value, error) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("cannot convert nil to a DAML value")
+	}
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("cannot convert nil %T to a DAML value", value)
+		}
+		rv = rv.Elem()
+	}
+
+	pb, err := planFor(rv.Type()).encode(rv)
+	if err != nil {
+		return nil, err
+	}
+	if pb == nil {
+		return nil, fmt.Errorf("cannot convert nil to a DAML value")
+	}
+	return pb, nil
+}
+
+// ToRecord converts a struct to a v2.Record.
+func (codec *ProtoCodec) ToRecord(value interface{}) (*v2.Record, error) {
+	pb, err := codec.ToValue(value)
+	if err != nil {
+		return nil, err
+	}
+	record := pb.GetRecord()
+	if record == nil {
+		return nil, fmt.Errorf("%T is not a record", value)
+	}
+	return record, nil
+}
+
+// FromValue decodes pb into the value target points to.
+func (codec *ProtoCodec) FromValue(pb *v2.Value, target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+	return planFor(rv.Elem().Type()).decode(pb, rv.Elem())
+}
+
+// FromRecord decodes record into the struct target points to.
+func (codec *ProtoCodec) FromRecord(record *v2.Record, target interface{}) error {
+	return codec.FromValue(&v2.Value{Sum: &v2.Value_Record{Record: record}}, target)
+}
+
+// Marshall encodes value as the protobuf bytes of a v2.Value. Maps are written in key order so the
+// output is deterministic.
+func (codec *ProtoCodec) Marshall(value interface{}) ([]byte, error) {
+	pb, err := codec.ToValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(pb)
+}
+
+// Unmarshall decodes the protobuf bytes of a v2.Value into target.
+func (codec *ProtoCodec) Unmarshall(data []byte, target interface{}) error {
+	var pb v2.Value
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	return codec.FromValue(&pb, target)
+}
+
+// MarshallRecord encodes a struct as the protobuf bytes of a v2.Record.
+func (codec *ProtoCodec) MarshallRecord(value interface{}) ([]byte, error) {
+	record, err := codec.ToRecord(value)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(record)
+}
+
+// UnmarshallRecord decodes the protobuf bytes of a v2.Record into the struct target points to.
+func (codec *ProtoCodec) UnmarshallRecord(data []byte, target interface{}) error {
+	var record v2.Record
+	if err := proto.Unmarshal(data, &record); err != nil {
+		return fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+	return codec.FromRecord(&record, target)
+}
+
+// IsDynamic reports whether values of type t can hold interface values, whose DAML type is only
+// known at run time.
+func (codec *ProtoCodec) IsDynamic(t reflect.Type) bool {
+	if d, ok := dynamicTypes.Load(t); ok {
+		return d.(bool)
+	}
+	d := isDynamic(t, make(map[reflect.Type]bool))
+	dynamicTypes.Store(t, d)
+	return d
+}
+
+func isDynamic(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch {
+	case t.Kind() == reflect.Interface:
+		return true
+	case t.Implements(valueEncoderType) && reflect.PointerTo(t).Implements(valueDecoderType):
+		return false
+	case t.Implements(anyOptionalType):
+		return isDynamic(reflect.Zero(t).Interface().(types.AnyOptional).ValueType(), seen)
+	case t.Implements(anySetType):
+		return isDynamic(reflect.Zero(t).Interface().(types.AnySet).ValueType(), seen)
+	case t.Implements(anyGenMapType):
+		m := reflect.Zero(t).Interface().(types.AnyGenMap)
+		return isDynamic(m.KeyType(), seen) || isDynamic(m.ValueType(), seen)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return isDynamic(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if label, _ := fieldLabel(f); f.IsExported() && label != "-" && isDynamic(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typePlan converts one Go type. encode may return a nil value for a nil interface, which records skip.
+type typePlan struct {
+	encode func(rv reflect.Value) (*v2.Value, error)
+	decode func(pb *v2.Value, rv reflect.Value) error
+}
+
+type fieldPlan struct {
+	index     int
+	label     string
+	omitEmpty bool
+	plan      *typePlan
+}
+
+var (
+	plans        sync.Map // reflect.Type -> *typePlan
+	dynamicTypes sync.Map // reflect.Type -> bool
+	plansMu      sync.Mutex
+
+	variantType      = reflect.TypeOf((*types.VARIANT)(nil)).Elem()
+	enumType         = reflect.TypeOf((*types.ENUM)(nil)).Elem()
+	anyEntriesType   = reflect.TypeOf([]types.AnyEntry(nil))
+	valueEncoderType = reflect.TypeOf((*ValueEncoder)(nil)).Elem()
+	valueDecoderType = reflect.TypeOf((*ValueDecoder)(nil)).Elem()
+	bigIntType       = reflect.TypeOf((*big.Int)(nil))
+	timeType         = reflect.TypeOf(time.Time{})
+	unitValue        = &v2.Value{Sum: &v2.Value_Unit{Unit: &emptypb.Empty{}}}
+)
+
+func planFor(t reflect.Type) *typePlan {
+	if p, ok := plans.Load(t); ok {
+		return p.(*typePlan)
+	}
+
+	plansMu.Lock()
+	defer plansMu.Unlock()
+	// plans of recursive types are published only once all of them are complete
+	building := make(map[reflect.Type]*typePlan)
+	p := buildPlan(t, building)
+	for bt, bp := range building {
+		plans.Store(bt, bp)
+	}
+	return p
+}
+
+func buildPlan(t reflect.Type, building map[reflect.Type]*typePlan) *typePlan {
+	if p, ok := plans.Load(t); ok {
+		return p.(*typePlan)
+	}
+	if p, ok := building[t]; ok {
+		return p
+	}
+	p := &typePlan{}
+	building[t] = p
+
+	switch t {
+	case reflect.TypeOf(types.Numeric{}):
+		p.encode, p.decode = encodeNumeric, decodeNumeric
+		return p
+	case reflect.TypeOf(decimal.Decimal{}):
+		p.encode, p.decode = encodeDecimal, decodeDecimal
+		return p
+	case bigIntType:
+		p.encode, p.decode = encodeBigInt, decodeBigInt
+		return p
+	case reflect.TypeOf(types.TIMESTAMP{}), timeType:
+		p.encode, p.decode = encodeTimestamp, decodeTimestamp
+		return p
+	case reflect.TypeOf(types.DATE{}):
+		p.encode, p.decode = encodeDate, decodeDate
+		return p
+	case reflect.TypeOf(types.Date{}):
+		p.encode, p.decode = encodeCivilDate, decodeCivilDate
+		return p
+	case reflect.TypeOf(types.UNIT{}):
+		p.encode = func(reflect.Value) (*v2.Value, error) { return unitValue, nil }
+		p.decode = func(pb *v2.Value, _ reflect.Value) error { return expectSum(pb, pb.GetUnit() != nil, "Unit") }
+		return p
+	case reflect.TypeOf(types.RELTIME(0)):
+		p.encode, p.decode = encodeReltime, decodeReltime
+		return p
+	case reflect.TypeOf(types.PARTY("")):
+		p.encode = func(rv reflect.Value) (*v2.Value, error) {
+			return &v2.Value{Sum: &v2.Value_Party{Party: rv.String()}}, nil
+		}
+		p.decode = decodeString
+		return p
+	case reflect.TypeOf(types.CONTRACT_ID("")):
+		p.encode = func(rv reflect.Value) (*v2.Value, error) {
+			return &v2.Value{Sum: &v2.Value_ContractId{ContractId: rv.String()}}, nil
+		}
+		p.decode = decodeString
+		return p
+	case reflect.TypeOf(types.GENMAP{}):
+		buildTextKeyedGenMapPlan(p, t, building)
+		return p
+	case anyEntriesType:
+		buildAnyEntriesPlan(p, building)
+		return p
+	}
+
+	switch {
+	case t.Implements(valueEncoderType) && reflect.PointerTo(t).Implements(valueDecoderType):
+		buildGeneratedPlan(p)
+	case t.Implements(anyOptionalType):
+		buildOptionalPlan(p, t, building)
+	case t.Implements(anyGenMapType):
+		buildGenMapPlan(p, t, building)
+	case t.Implements(anySetType):
+		buildSetPlan(p, t, building)
+	case t.Implements(enumType) && t.Kind() == reflect.String:
+		p.encode = func(rv reflect.Value) (*v2.Value, error) {
+			return &v2.Value{Sum: &v2.Value_Enum{Enum: &v2.Enum{Constructor: rv.Interface().(types.ENUM).GetEnumConstructor()}}}, nil
+		}
+		p.decode = decodeString
+	case t.Implements(variantType) && t.Kind() == reflect.Struct:
+		buildVariantPlan(p, t, building)
+	default:
+		buildKindPlan(p, t, building)
+	}
+	return p
+}
+
+func buildKindPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		buildPointerPlan(p, t, building)
+	case reflect.Interface:
+		buildDynamicPlan(p, t)
+	case reflect.Slice, reflect.Array:
+		buildListPlan(p, t, building)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			p.encode, p.decode = unsupportedPlan(t)
+			return
+		}
+		buildTextMapPlan(p, t, building)
+	case reflect.Struct:
+		buildRecordPlan(p, t, building)
+	case reflect.String:
+		p.encode = func(rv reflect.Value) (*v2.Value, error) {
+			return &v2.Value{Sum: &v2.Value_Text{Text: rv.String()}}, nil
+		}
+		p.decode = decodeString
+	case reflect.Bool:
+		p.encode = func(rv reflect.Value) (*v2.Value, error) {
+			return &v2.Value{Sum: &v2.Value_Bool{Bool: rv.Bool()}}, nil
+		}
+		p.decode = func(pb *v2.Value, rv reflect.Value) error {
+			b, ok := pb.GetSum().(*v2.Value_Bool)
+			if !ok {
+				return unexpectedSum(pb, "Bool")
+			}
+			rv.SetBool(b.Bool)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.encode = func(rv reflect.Value) (*v2.Value, error) {
+			return &v2.Value{Sum: &v2.Value_Int64{Int64: rv.Int()}}, nil
+		}
+		p.decode = func(pb *v2.Value, rv reflect.Value) error {
+			i, ok := pb.GetSum().(*v2.Value_Int64)
+			if !ok {
+				return unexpectedSum(pb, "Int64")
+			}
+			if rv.OverflowInt(i.Int64) {
+				return fmt.Errorf("%d overflows %v", i.Int64, rv.Type())
+			}
+			rv.SetInt(i.Int64)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		p.encode = func(rv reflect.Value) (*v2.Value, error) {
+			if rv.Uint() > math.MaxInt64 {
+				return nil, fmt.Errorf("%d overflows Int64", rv.Uint())
+			}
+			return &v2.Value{Sum: &v2.Value_Int64{Int64: int64(rv.Uint())}}, nil
+		}
+		p.decode = func(pb *v2.Value, rv reflect.Value) error {
+			i, ok := pb.GetSum().(*v2.Value_Int64)
+			if !ok {
+				return unexpectedSum(pb, "Int64")
+			}
+			if i.Int64 < 0 || rv.OverflowUint(uint64(i.Int64)) {
+				return fmt.Errorf("%d overflows %v", i.Int64, rv.Type())
+			}
+			rv.SetUint(uint64(i.Int64))
+			return nil
+		}
+	default:
+		p.encode, p.decode = unsupportedPlan(t)
+	}
+}
+
+func unsupportedPlan(t reflect.Type) (func(reflect.Value) (*v2.Value, error), func(*v2.Value, reflect.Value) error) {
+	err := fmt.Errorf("unsupported type %v", t)
+	return func(reflect.Value) (*v2.Value, error) { return nil, err },
+		func(*v2.Value, reflect.Value) error { return err }
+}
+
+// buildGeneratedPlan uses the ToValue and FromValue methods of generated types.
+func buildGeneratedPlan(p *typePlan) {
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		return rv.Interface().(ValueEncoder).ToValue()
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		return rv.Addr().Interface().(ValueDecoder).FromValue(pb)
+	}
+}
+
+// buildPointerPlan treats *T as Optional T.
+func buildPointerPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	elem := buildPlan(t.Elem(), building)
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		if rv.IsNil() {
+			return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{}}}, nil
+		}
+		value, err := elem.encode(rv.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{Value: value}}}, nil
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		opt, ok := pb.GetSum().(*v2.Value_Optional)
+		if !ok {
+			return unexpectedSum(pb, "Optional")
+		}
+		if opt.Optional.GetValue() == nil {
+			rv.Set(reflect.Zero(t))
+			return nil
+		}
+		value := reflect.New(t.Elem())
+		if err := elem.decode(opt.Optional.GetValue(), value.Elem()); err != nil {
+			return err
+		}
+		rv.Set(value)
+		return nil
+	}
+}
+
+func buildOptionalPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	zero := reflect.Zero(t).Interface().(types.AnyOptional)
+	elemType := zero.ValueType()
+	elem := buildPlan(elemType, building)
+
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		v, some := rv.Interface().(types.AnyOptional).AnyValue()
+		if !some {
+			return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{}}}, nil
+		}
+		value, err := elem.encode(valueOfType(v, elemType))
+		if err != nil {
+			return nil, err
+		}
+		return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{Value: value}}}, nil
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		opt, ok := pb.GetSum().(*v2.Value_Optional)
+		if !ok {
+			return unexpectedSum(pb, "Optional")
+		}
+		if opt.Optional.GetValue() == nil {
+			rv.Set(reflect.Zero(t))
+			return nil
+		}
+		value := reflect.New(elemType).Elem()
+		if err := elem.decode(opt.Optional.GetValue(), value); err != nil {
+			return err
+		}
+		result, err := zero.WithAnyValue(value.Interface())
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(result))
+		return nil
+	}
+}
+
+// buildDynamicPlan handles interface types by planning the dynamic type of each value. Values
+// decoded into an empty interface use the types from pkg/types, with records as maps.
+func buildDynamicPlan(p *typePlan, t reflect.Type) {
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return planFor(rv.Elem().Type()).encode(rv.Elem())
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		if t.NumMethod() != 0 {
+			return fmt.Errorf("cannot decode into non-empty interface %v", t)
+		}
+		value, err := interfaceFromValue(pb)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			rv.Set(reflect.Zero(t))
+			return nil
+		}
+		rv.Set(reflect.ValueOf(value))
+		return nil
+	}
+}
+
+func buildListPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	elem := buildPlan(t.Elem(), building)
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		elements := make([]*v2.Value, rv.Len())
+		for i := range elements {
+			value, err := elem.encode(rv.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("list element %d: %w", i, err)
+			}
+			elements[i] = value
+		}
+		return &v2.Value{Sum: &v2.Value_List{List: &v2.List{Elements: elements}}}, nil
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		list, ok := pb.GetSum().(*v2.Value_List)
+		if !ok {
+			return unexpectedSum(pb, "List")
+		}
+		elements := list.List.GetElements()
+		if t.Kind() == reflect.Array {
+			if len(elements) != t.Len() {
+				return fmt.Errorf("expected %d list elements for %v, got %d", t.Len(), t, len(elements))
+			}
+		} else {
+			rv.Set(reflect.MakeSlice(t, len(elements), len(elements)))
+		}
+		for i, e := range elements {
+			if err := elem.decode(e, rv.Index(i)); err != nil {
+				return fmt.Errorf("list element %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+}
+
+// buildTextMapPlan maps any map keyed by text, such as TextMap[V], to a TextMap.
+func buildTextMapPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	elem := buildPlan(t.Elem(), building)
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		keys := sortedMapKeys(rv)
+		entries := make([]*v2.TextMap_Entry, 0, len(keys))
+		for _, k := range keys {
+			value, err := elem.encode(rv.MapIndex(k))
+			if err != nil {
+				return nil, fmt.Errorf("map entry %s: %w", k.String(), err)
+			}
+			entries = append(entries, &v2.TextMap_Entry{Key: k.String(), Value: value})
+		}
+		return &v2.Value{Sum: &v2.Value_TextMap{TextMap: &v2.TextMap{Entries: entries}}}, nil
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		tm, ok := pb.GetSum().(*v2.Value_TextMap)
+		if !ok {
+			return unexpectedSum(pb, "TextMap")
+		}
+		result := reflect.MakeMapWithSize(t, len(tm.TextMap.GetEntries()))
+		for _, e := range tm.TextMap.GetEntries() {
+			value := reflect.New(t.Elem()).Elem()
+			if err := elem.decode(e.GetValue(), value); err != nil {
+				return fmt.Errorf("map entry %s: %w", e.GetKey(), err)
+			}
+			result.SetMapIndex(reflect.ValueOf(e.GetKey()).Convert(t.Key()), value)
+		}
+		rv.Set(result)
+		return nil
+	}
+}
+
+// buildTextKeyedGenMapPlan handles the untyped GENMAP, a GenMap whose keys are text.
+func buildTextKeyedGenMapPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	elem := buildPlan(t.Elem(), building)
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		keys := sortedMapKeys(rv)
+		entries := make([]*v2.GenMap_Entry, 0, len(keys))
+		for _, k := range keys {
+			value, err := elem.encode(rv.MapIndex(k))
+			if err != nil {
+				return nil, fmt.Errorf("map entry %s: %w", k.String(), err)
+			}
+			entries = append(entries, &v2.GenMap_Entry{Key: &v2.Value{Sum: &v2.Value_Text{Text: k.String()}}, Value: value})
+		}
+		return &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: entries}}}, nil
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		gm, ok := pb.GetSum().(*v2.Value_GenMap)
+		if !ok {
+			return unexpectedSum(pb, "GenMap")
+		}
+		result := reflect.MakeMapWithSize(t, len(gm.GenMap.GetEntries()))
+		for i, e := range gm.GenMap.GetEntries() {
+			key, ok := stringFromValue(e.GetKey())
+			if !ok {
+				return fmt.Errorf("GenMap entry %d has a %T key, which needs a GenMap[K, V]", i, e.GetKey().GetSum())
+			}
+			value := reflect.New(t.Elem()).Elem()
+			if err := elem.decode(e.GetValue(), value); err != nil {
+				return fmt.Errorf("map entry %s: %w", key, err)
+			}
+			result.SetMapIndex(reflect.ValueOf(key), value)
+		}
+		rv.Set(result)
+		return nil
+	}
+}
+
+func buildGenMapPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	zero := reflect.Zero(t).Interface().(types.AnyGenMap)
+	keyType, valueType := zero.KeyType(), zero.ValueType()
+	keyPlan, valuePlan := buildPlan(keyType, building), buildPlan(valueType, building)
+
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		return encodeGenMapEntries(rv.Interface().(types.AnyGenMap).AnyEntries(), keyType, valueType, keyPlan, valuePlan)
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		entries, err := decodeGenMapEntries(pb, keyType, valueType, keyPlan, valuePlan)
+		if err != nil {
+			return err
+		}
+		result, err := zero.WithAnyEntries(entries)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(result))
+		return nil
+	}
+}
+
+// buildAnyEntriesPlan handles []types.AnyEntry, the form a GenMap takes when decoded into an interface.
+func buildAnyEntriesPlan(p *typePlan, building map[reflect.Type]*typePlan) {
+	dynamicType := reflect.TypeOf((*interface{})(nil)).Elem()
+	dynamic := buildPlan(dynamicType, building)
+
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		return encodeGenMapEntries(rv.Interface().([]types.AnyEntry), dynamicType, dynamicType, dynamic, dynamic)
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		entries, err := decodeGenMapEntries(pb, dynamicType, dynamicType, dynamic, dynamic)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(entries))
+		return nil
+	}
+}
+
+func encodeGenMapEntries(entries []types.AnyEntry, keyType, valueType reflect.Type, keyPlan, valuePlan *typePlan) (*v2.Value, error) {
+	result := make([]*v2.GenMap_Entry, len(entries))
+	for i, e := range entries {
+		key, err := keyPlan.encode(valueOfType(e.Key, keyType))
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d key: %w", i, err)
+		}
+		value, err := valuePlan.encode(valueOfType(e.Value, valueType))
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d value: %w", i, err)
+		}
+		result[i] = &v2.GenMap_Entry{Key: key, Value: value}
+	}
+	return &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: result}}}, nil
+}
+
+func decodeGenMapEntries(pb *v2.Value, keyType, valueType reflect.Type, keyPlan, valuePlan *typePlan) ([]types.AnyEntry, error) {
+	gm, ok := pb.GetSum().(*v2.Value_GenMap)
+	if !ok {
+		return nil, unexpectedSum(pb, "GenMap")
+	}
+	entries := make([]types.AnyEntry, len(gm.GenMap.GetEntries()))
+	for i, e := range gm.GenMap.GetEntries() {
+		key := reflect.New(keyType).Elem()
+		if err := keyPlan.decode(e.GetKey(), key); err != nil {
+			return nil, fmt.Errorf("GenMap entry %d key: %w", i, err)
+		}
+		value := reflect.New(valueType).Elem()
+		if err := valuePlan.decode(e.GetValue(), value); err != nil {
+			return nil, fmt.Errorf("GenMap entry %d value: %w", i, err)
+		}
+		entries[i] = types.AnyEntry{Key: key.Interface(), Value: value.Interface()}
+	}
+	return entries, nil
+}
+
+// buildSetPlan encodes a Set as the DA.Set.Set record, whose map field is a GenMap from the items to unit.
+func buildSetPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	zero := reflect.Zero(t).Interface().(types.AnySet)
+	itemType := zero.ValueType()
+	item := buildPlan(itemType, building)
+
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		items := rv.Interface().(types.AnySet).AnyItems()
+		entries := make([]*v2.GenMap_Entry, len(items))
+		for i, it := range items {
+			key, err := item.encode(valueOfType(it, itemType))
+			if err != nil {
+				return nil, fmt.Errorf("set item %d: %w", i, err)
+			}
+			entries[i] = &v2.GenMap_Entry{Key: key, Value: unitValue}
+		}
+		genMap := &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: entries}}}
+		return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: []*v2.RecordField{{Label: "map", Value: genMap}}}}}, nil
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		record, ok := pb.GetSum().(*v2.Value_Record)
+		if !ok || len(record.Record.GetFields()) != 1 {
+			return unexpectedSum(pb, "DA.Set.Set record")
+		}
+		gm, ok := record.Record.GetFields()[0].GetValue().GetSum().(*v2.Value_GenMap)
+		if !ok {
+			return unexpectedSum(record.Record.GetFields()[0].GetValue(), "GenMap")
+		}
+
+		items := make([]interface{}, len(gm.GenMap.GetEntries()))
+		for i, e := range gm.GenMap.GetEntries() {
+			value := reflect.New(itemType).Elem()
+			if err := item.decode(e.GetKey(), value); err != nil {
+				return fmt.Errorf("set item %d: %w", i, err)
+			}
+			items[i] = value.Interface()
+		}
+		result, err := zero.WithAnyItems(items)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(result))
+		return nil
+	}
+}
+
+// buildVariantPlan handles generated variants: structs with one pointer field per constructor, named
+// by the field's json tag.
+func buildVariantPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	fields := make(map[string]fieldPlan)
+	var order []fieldPlan
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Type.Kind() != reflect.Ptr {
+			continue
+		}
+		label, _ := fieldLabel(f)
+		fp := fieldPlan{index: i, label: label, plan: buildPlan(f.Type.Elem(), building)}
+		fields[label] = fp
+		order = append(order, fp)
+	}
+
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		for _, fp := range order {
+			field := rv.Field(fp.index)
+			if field.IsNil() {
+				continue
+			}
+			value, err := fp.plan.encode(field.Elem())
+			if err != nil {
+				return nil, fmt.Errorf("variant %s: %w", fp.label, err)
+			}
+			return &v2.Value{Sum: &v2.Value_Variant{Variant: &v2.Variant{Constructor: fp.label, Value: value}}}, nil
+		}
+		return nil, fmt.Errorf("variant %v has no constructor set", t)
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		variant, ok := pb.GetSum().(*v2.Value_Variant)
+		if !ok {
+			return unexpectedSum(pb, "Variant")
+		}
+		fp, ok := fields[variant.Variant.GetConstructor()]
+		if !ok {
+			return fmt.Errorf("variant %v has no constructor %s", t, variant.Variant.GetConstructor())
+		}
+		value := reflect.New(t.Field(fp.index).Type.Elem())
+		if err := fp.plan.decode(variant.Variant.GetValue(), value.Elem()); err != nil {
+			return fmt.Errorf("variant %s: %w", fp.label, err)
+		}
+		rv.Set(reflect.Zero(t))
+		rv.Field(fp.index).Set(value)
+		return nil
+	}
+}
+
+// buildRecordPlan labels fields by their json tag, or _1, _2, _3 for tuples. Encoding skips zero
+// fields tagged omitempty and nil interfaces; decoding matches fields by label, or by position
+// when the ledger sends records without labels.
+func buildRecordPlan(p *typePlan, t reflect.Type, building map[reflect.Type]*typePlan) {
+	tuple := isTupleType(t)
+	var fields []fieldPlan
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		label, omitEmpty := fieldLabel(f)
+		if label == "-" {
+			continue
+		}
+		if tuple {
+			label = fmt.Sprintf("_%d", i+1)
+		}
+		fields = append(fields, fieldPlan{index: i, label: label, omitEmpty: omitEmpty, plan: buildPlan(f.Type, building)})
+	}
+	byLabel := make(map[string]fieldPlan, len(fields))
+	for _, fp := range fields {
+		byLabel[fp.label] = fp
+	}
+
+	p.encode = func(rv reflect.Value) (*v2.Value, error) {
+		recordFields := make([]*v2.RecordField, 0, len(fields))
+		for _, fp := range fields {
+			field := rv.Field(fp.index)
+			if fp.omitEmpty && field.IsZero() {
+				continue
+			}
+			value, err := fp.plan.encode(field)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", fp.label, err)
+			}
+			if value == nil {
+				continue
+			}
+			recordFields = append(recordFields, &v2.RecordField{Label: fp.label, Value: value})
+		}
+		return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: recordFields}}}, nil
+	}
+	p.decode = func(pb *v2.Value, rv reflect.Value) error {
+		record, ok := pb.GetSum().(*v2.Value_Record)
+		if !ok {
+			return unexpectedSum(pb, "Record")
+		}
+		for i, rf := range record.Record.GetFields() {
+			fp, ok := byLabel[rf.GetLabel()]
+			if rf.GetLabel() == "" && i < len(fields) {
+				fp, ok = fields[i], true
+			}
+			if !ok {
+				continue
+			}
+			if err := fp.plan.decode(rf.GetValue(), rv.Field(fp.index)); err != nil {
+				return fmt.Errorf("field %s: %w", fp.label, err)
+			}
+		}
+		return nil
+	}
+}
+
+func fieldLabel(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		return f.Name, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(options, "omitempty")
+}
+
+func isTupleType(t reflect.Type) bool {
+	return t.Implements(anyTupleType)
+}
+
+func encodeNumeric(rv reflect.Value) (*v2.Value, error) {
+	return NumericValue(rv.Interface().(types.Numeric))
+}
+
+func decodeNumeric(pb *v2.Value, rv reflect.Value) error {
+	n, err := numericFromValue(pb)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(n))
+	return nil
+}
+
+func encodeDecimal(rv reflect.Value) (*v2.Value, error) {
+	n, err := types.NewNumericFromDecimal(rv.Interface().(decimal.Decimal))
+	if err != nil {
+		return nil, err
+	}
+	return encodeNumeric(reflect.ValueOf(n))
+}
+
+func decodeDecimal(pb *v2.Value, rv reflect.Value) error {
+	n, err := numericFromValue(pb)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(n.Decimal()))
+	return nil
+}
+
+// encodeBigInt treats a bare *big.Int as the unscaled value of a legacy Decimal.
+func encodeBigInt(rv reflect.Value) (*v2.Value, error) {
+	if rv.IsNil() {
+		return nil, fmt.Errorf("numeric value is not set")
+	}
+	n, err := types.NewNumeric(rv.Interface().(*big.Int), types.DecimalScale)
+	if err != nil {
+		return nil, err
+	}
+	return encodeNumeric(reflect.ValueOf(n))
+}
+
+func decodeBigInt(pb *v2.Value, rv reflect.Value) error {
+	n, err := numericFromValue(pb)
+	if err != nil {
+		return err
+	}
+	legacy, err := n.Rescale(types.DecimalScale)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(legacy.Unscaled()))
+	return nil
+}
+
+func numericFromValue(pb *v2.Value) (types.Numeric, error) {
+	n, ok := pb.GetSum().(*v2.Value_Numeric)
+	if !ok {
+		return types.Numeric{}, unexpectedSum(pb, "Numeric")
+	}
+	return types.ParseNumeric(n.Numeric)
+}
+
+// encodeTimestamp encodes a types.TIMESTAMP or a time.Time.
+func encodeTimestamp(rv reflect.Value) (*v2.Value, error) {
+	return TimestampValue(types.TIMESTAMP(rv.Convert(timeType).Interface().(time.Time)))
+}
+
+func decodeTimestamp(pb *v2.Value, rv reflect.Value) error {
+	ts, err := TimestampFromValue(pb)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(ts).Convert(rv.Type()))
+	return nil
+}
+
+func encodeDate(rv reflect.Value) (*v2.Value, error) {
+	return DateValue(rv.Interface().(types.DATE))
+}
+
+func encodeCivilDate(rv reflect.Value) (*v2.Value, error) {
+	return CivilDateValue(rv.Interface().(types.Date))
+}
+
+func decodeCivilDate(pb *v2.Value, rv reflect.Value) error {
+	d, err := CivilDateFromValue(pb)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(d))
+	return nil
+}
+
+func decodeDate(pb *v2.Value, rv reflect.Value) error {
+	d, err := DateFromValue(pb)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(d))
+	return nil
+}
+
+func encodeReltime(rv reflect.Value) (*v2.Value, error) {
+	return RelTimeValue(rv.Interface().(types.RELTIME))
+}
+
+func decodeReltime(pb *v2.Value, rv reflect.Value) error {
+	d, err := RelTimeFromValue(pb)
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(d))
+	return nil
+}
+
+func decodeString(pb *v2.Value, rv reflect.Value) error {
+	s, ok := stringFromValue(pb)
+	if !ok {
+		return unexpectedSum(pb, "Text")
+	}
+	rv.SetString(s)
+	return nil
+}
+
+// stringFromValue returns the text of a Text, Party, ContractId or Enum value.
+func stringFromValue(pb *v2.Value) (string, bool) {
+	switch v := pb.GetSum().(type) {
+	case *v2.Value_Text:
+		return v.Text, true
+	case *v2.Value_Party:
+		return v.Party, true
+	case *v2.Value_ContractId:
+		return v.ContractId, true
+	case *v2.Value_Enum:
+		return v.Enum.GetConstructor(), true
+	default:
+		return "", false
+	}
+}
+
+// interfaceFromValue converts pb to the matching type from pkg/types. Records become maps keyed by
+// label, variants maps with tag and value, and GenMaps []types.AnyEntry.
+func interfaceFromValue(pb *v2.Value) (interface{}, error) {
+	switch v := pb.GetSum().(type) {
+	case *v2.Value_Unit:
+		return types.UNIT{}, nil
+	case *v2.Value_Bool:
+		return types.BOOL(v.Bool), nil
+	case *v2.Value_Int64:
+		return types.INT64(v.Int64), nil
+	case *v2.Value_Text:
+		return types.TEXT(v.Text), nil
+	case *v2.Value_Party:
+		return types.PARTY(v.Party), nil
+	case *v2.Value_ContractId:
+		return types.CONTRACT_ID(v.ContractId), nil
+	case *v2.Value_Numeric:
+		return types.ParseNumeric(v.Numeric)
+	case *v2.Value_Date:
+		return DateFromValue(pb)
+	case *v2.Value_Timestamp:
+		return TimestampFromValue(pb)
+	case *v2.Value_Enum:
+		return v.Enum.GetConstructor(), nil
+	case *v2.Value_Optional:
+		if v.Optional.GetValue() == nil {
+			return nil, nil
+		}
+		return interfaceFromValue(v.Optional.GetValue())
+	case *v2.Value_List:
+		result := make([]interface{}, len(v.List.GetElements()))
+		for i, e := range v.List.GetElements() {
+			value, err := interfaceFromValue(e)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+	case *v2.Value_TextMap:
+		result := make(types.TEXTMAP, len(v.TextMap.GetEntries()))
+		for _, e := range v.TextMap.GetEntries() {
+			value, err := interfaceFromValue(e.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			result[e.GetKey()] = value
+		}
+		return result, nil
+	case *v2.Value_GenMap:
+		dynamic := reflect.TypeOf((*interface{})(nil)).Elem()
+		plan := planFor(dynamic)
+		return decodeGenMapEntries(pb, dynamic, dynamic, plan, plan)
+	case *v2.Value_Record:
+		result := make(map[string]interface{}, len(v.Record.GetFields()))
+		for _, f := range v.Record.GetFields() {
+			value, err := interfaceFromValue(f.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			result[f.GetLabel()] = value
+		}
+		return result, nil
+	case *v2.Value_Variant:
+		value, err := interfaceFromValue(v.Variant.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"tag": v.Variant.GetConstructor(), "value": value}, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %T", pb.GetSum())
+	}
+}
+
+func sortedMapKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+// valueOfType returns x as a value of type t, so nil works for interface types.
+func valueOfType(x interface{}, t reflect.Type) reflect.Value {
+	v := reflect.New(t).Elem()
+	if x != nil {
+		v.Set(reflect.ValueOf(x))
+	}
+	return v
+}
+
+func expectSum(pb *v2.Value, ok bool, expected string) error {
+	if !ok {
+		return unexpectedSum(pb, expected)
+	}
+	return nil
+}
+
+func unexpectedSum(pb *v2.Value, expected string) error {
+	if pb == nil || pb.GetSum() == nil {
+		return fmt.Errorf("expected %s, got no value", expected)
+	}
+	return fmt.Errorf("expected %s, got %s", expected, strings.TrimPrefix(fmt.Sprintf("%T", pb.GetSum()), "*v2.Value_"))
+}
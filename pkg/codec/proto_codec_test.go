@@ -0,0 +1,282 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
+	. "github.com/noders-team/go-daml/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+type protoColor string
+
+func (c protoColor) GetEnumConstructor() string { return string(c) }
+func (c protoColor) GetEnumTypeID() string      { return "Colors:Color" }
+
+type protoShape struct {
+	Circle *NUMERIC    `json:"Circle,omitempty"`
+	Nested *protoShape `json:"Nested,omitempty"`
+}
+
+func (s protoShape) GetVariantTag() string {
+	if s.Circle != nil {
+		return "Circle"
+	}
+	return "Nested"
+}
+
+func (s protoShape) GetVariantValue() interface{} {
+	if s.Circle != nil {
+		return s.Circle
+	}
+	return s.Nested
+}
+
+type protoEverything struct {
+	Owner     PARTY                    `json:"owner"`
+	Flag      BOOL                     `json:"flag"`
+	Count     INT64                    `json:"count"`
+	Amount    NUMERIC                  `json:"amount"`
+	Note      TEXT                     `json:"note"`
+	Maybe     *INT64                   `json:"maybe"`
+	MaybeNot  *INT64                   `json:"maybeNot"`
+	Day       DATE                     `json:"day"`
+	At        TIMESTAMP                `json:"at"`
+	Wait      RELTIME                  `json:"wait"`
+	Tags      []TEXT                   `json:"tags"`
+	Color     protoColor               `json:"color"`
+	Shape     protoShape               `json:"shape"`
+	Unit      UNIT                     `json:"unit"`
+	Cid       CONTRACT_ID              `json:"cid"`
+	Opt       Optional[TEXT]           `json:"opt"`
+	Scores    TextMap[INT64]           `json:"scores"`
+	ByParty   GenMap[PARTY, INT64]     `json:"byParty"`
+	ByTags    EntryList[[]TEXT, INT64] `json:"byTags"`
+	Observers Set[PARTY]               `json:"observers"`
+	Pair      Tuple2[PARTY, NUMERIC]   `json:"pair"`
+	Skipped   TEXT                     `json:"-"`
+}
+
+func newProtoEverything() protoEverything {
+	maybe := INT64(7)
+	radius := MustParseNumeric("0.5")
+	return protoEverything{
+		Owner:     "Alice::1220aa",
+		Flag:      true,
+		Count:     -42,
+		Amount:    MustParseNumeric("1.50"),
+		Note:      "note",
+		Maybe:     &maybe,
+		Day:       DATE(time.Date(2019, 6, 18, 0, 0, 0, 0, time.UTC)),
+		At:        TIMESTAMP(time.Date(1990, 11, 9, 4, 30, 23, 123456000, time.UTC)),
+		Wait:      RELTIME(90 * time.Second),
+		Tags:      []TEXT{"a", "b"},
+		Color:     "Red",
+		Shape:     protoShape{Nested: &protoShape{Circle: &radius}},
+		Unit:      UNIT{},
+		Cid:       "0041c1b4e8f1",
+		Opt:       Some[TEXT]("x"),
+		Scores:    TextMap[INT64]{"b": 2, "a": 1},
+		ByParty:   NewGenMap(MapEntry[PARTY, INT64]{Key: "Bob", Value: 1}, MapEntry[PARTY, INT64]{Key: "Alice", Value: 2}),
+		ByTags:    EntryList[[]TEXT, INT64]{{Key: []TEXT{"z", "a"}, Value: 1}, {Key: []TEXT{}, Value: 2}},
+		Observers: NewSet[PARTY]("Carol"),
+		Pair:      Tuple2[PARTY, NUMERIC]{First: "Alice::1220aa", Second: MustParseNumeric("1")},
+		Skipped:   "skipped",
+	}
+}
+
+func TestProtoCodec_RoundTrip(t *testing.T) {
+	codec := NewProtoCodec()
+	in := newProtoEverything()
+
+	record, err := codec.ToRecord(&in)
+	require.NoError(t, err)
+	require.Len(t, record.Fields, 21)
+
+	fields := make(map[string]*v2.Value)
+	for _, f := range record.Fields {
+		fields[f.Label] = f.Value
+	}
+	require.Equal(t, "Alice::1220aa", fields["owner"].GetParty())
+	require.Equal(t, "1.50", fields["amount"].GetNumeric())
+	require.Equal(t, int64(7), fields["maybe"].GetOptional().GetValue().GetInt64())
+	require.Nil(t, fields["maybeNot"].GetOptional().GetValue())
+	require.Equal(t, int32(18065), fields["day"].GetDate())
+	require.Equal(t, int64(658125023123456), fields["at"].GetTimestamp())
+	require.Equal(t, int64(90_000_000), fields["wait"].GetInt64())
+	require.Equal(t, "Red", fields["color"].GetEnum().GetConstructor())
+	require.Equal(t, "Nested", fields["shape"].GetVariant().GetConstructor())
+	require.Equal(t, "Circle", fields["shape"].GetVariant().GetValue().GetVariant().GetConstructor())
+	require.Equal(t, "a", fields["scores"].GetTextMap().GetEntries()[0].GetKey())
+	require.Equal(t, "Bob", fields["byParty"].GetGenMap().GetEntries()[0].GetKey().GetParty())
+	require.Equal(t, "z", fields["byTags"].GetGenMap().GetEntries()[0].GetKey().GetList().GetElements()[0].GetText())
+	require.Equal(t, "map", fields["observers"].GetRecord().GetFields()[0].GetLabel())
+	require.Equal(t, "_2", fields["pair"].GetRecord().GetFields()[1].GetLabel())
+
+	var out protoEverything
+	require.NoError(t, codec.FromRecord(record, &out))
+	in.Skipped = ""
+	require.Equal(t, in, out)
+
+	data, err := codec.MarshallRecord(in)
+	require.NoError(t, err)
+	again, err := codec.MarshallRecord(in)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+
+	var decoded protoEverything
+	require.NoError(t, codec.UnmarshallRecord(data, &decoded))
+	require.Equal(t, in, decoded)
+}
+
+func TestProtoCodec_Values(t *testing.T) {
+	codec := NewProtoCodec()
+
+	t.Run("positional record fields", func(t *testing.T) {
+		type pair struct {
+			Left  TEXT  `json:"left"`
+			Right INT64 `json:"right"`
+		}
+		record := &v2.Record{Fields: []*v2.RecordField{
+			{Value: &v2.Value{Sum: &v2.Value_Text{Text: "a"}}},
+			{Value: &v2.Value{Sum: &v2.Value_Int64{Int64: 1}}},
+		}}
+		var out pair
+		require.NoError(t, codec.FromRecord(record, &out))
+		require.Equal(t, pair{Left: "a", Right: 1}, out)
+	})
+
+	t.Run("records named like tuples", func(t *testing.T) {
+		type ranking struct {
+			First  INT64 `json:"first"`
+			Second INT64 `json:"second"`
+		}
+		record, err := codec.ToRecord(ranking{First: 1, Second: 2})
+		require.NoError(t, err)
+		require.Equal(t, "first", record.Fields[0].Label)
+		require.Equal(t, "second", record.Fields[1].Label)
+
+		record, err = codec.ToRecord(Tuple2[INT64, INT64]{First: 1, Second: 2})
+		require.NoError(t, err)
+		require.Equal(t, "_1", record.Fields[0].Label)
+		require.Equal(t, "_2", record.Fields[1].Label)
+	})
+
+	t.Run("interface target", func(t *testing.T) {
+		data, err := codec.Marshall(NewGenMap(MapEntry[INT64, TEXT]{Key: 3, Value: "c"}))
+		require.NoError(t, err)
+
+		var out interface{}
+		require.NoError(t, codec.Unmarshall(data, &out))
+		require.Equal(t, []AnyEntry{{Key: INT64(3), Value: TEXT("c")}}, out)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := codec.ToValue(nil)
+		require.Error(t, err)
+
+		_, err = codec.ToValue(protoEverything{})
+		require.ErrorContains(t, err, "field amount")
+
+		_, err = codec.ToValue(1.5)
+		require.ErrorContains(t, err, "unsupported type")
+
+		var out struct {
+			Count int8 `json:"count"`
+		}
+		record := &v2.Record{Fields: []*v2.RecordField{{Label: "count", Value: &v2.Value{Sum: &v2.Value_Int64{Int64: 300}}}}}
+		require.ErrorContains(t, codec.FromRecord(record, &out), "overflows")
+
+		var shape protoShape
+		variant := &v2.Value{Sum: &v2.Value_Variant{Variant: &v2.Variant{Constructor: "Square"}}}
+		require.ErrorContains(t, codec.FromValue(variant, &shape), "no constructor Square")
+		require.Error(t, codec.FromValue(variant, shape))
+	})
+
+	t.Run("encoder errors", func(t *testing.T) {
+		_, err := NumericValue(NUMERIC{})
+		require.ErrorContains(t, err, "numeric value is not set")
+
+		_, err = ListValue([]NUMERIC{MustParseNumeric("1.5"), {}}, NumericValue)
+		require.ErrorContains(t, err, "list element 1: numeric value is not set")
+
+		_, err = Tuple2Value(Tuple2[TEXT, NUMERIC]{First: "a"}, TextValue, NumericValue)
+		require.ErrorContains(t, err, "field _2")
+
+		_, err = DynamicValue(1.5)
+		require.ErrorContains(t, err, "unsupported type")
+
+		_, err = CivilDateValue(Date{Year: 10000, Month: time.January, Day: 1})
+		require.ErrorContains(t, err, "outside the DAML range")
+		_, err = TimestampValue(TIMESTAMP(time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC)))
+		require.ErrorContains(t, err, "sub-microsecond precision")
+	})
+
+	t.Run("dates and timestamps", func(t *testing.T) {
+		type times struct {
+			Day  Date      `json:"day"`
+			At   time.Time `json:"at"`
+			Then DATE      `json:"then"`
+		}
+		in := times{
+			Day:  Date{Year: 1969, Month: time.December, Day: 31},
+			At:   time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC),
+			Then: DATE(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+		record, err := codec.ToRecord(in)
+		require.NoError(t, err)
+		require.Equal(t, int32(-1), record.Fields[0].Value.GetDate())
+		require.Equal(t, int32(-719162), record.Fields[2].Value.GetDate())
+
+		var out times
+		require.NoError(t, codec.FromRecord(record, &out))
+		require.Equal(t, in, out)
+
+		in.At = in.At.Add(time.Nanosecond)
+		_, err = codec.ToRecord(in)
+		require.ErrorContains(t, err, "outside the DAML range")
+		in.At = time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC)
+		_, err = codec.ToRecord(in)
+		require.ErrorContains(t, err, "sub-microsecond precision")
+
+		_, err = DateFromValue(&v2.Value{Sum: &v2.Value_Date{Date: 2932897}})
+		require.ErrorContains(t, err, "outside the DAML range")
+		_, err = TimestampFromValue(&v2.Value{Sum: &v2.Value_Timestamp{Timestamp: MinTimestamp.UnixMicro() - 1}})
+		require.ErrorContains(t, err, "outside the DAML range")
+	})
+
+	t.Run("dynamic types", func(t *testing.T) {
+		require.False(t, codec.IsDynamic(reflect.TypeFor[protoEverything]()))
+		require.True(t, codec.IsDynamic(reflect.TypeFor[struct {
+			Value map[string]interface{} `json:"value"`
+		}]()))
+	})
+}
+
+func BenchmarkProtoCodec_ToRecord(b *testing.B) {
+	codec := NewProtoCodec()
+	in := newProtoEverything()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := codec.ToRecord(in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProtoCodec_FromRecord(b *testing.B) {
+	codec := NewProtoCodec()
+	record, err := codec.ToRecord(newProtoEverything())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var out protoEverything
+		if err := codec.FromRecord(record, &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,531 @@
+package codec
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
+	"github.com/noders-team/go-daml/pkg/types"
+)
+
+// The functions in this file are the building blocks of the ToValue and FromValue methods of
+// generated code. They convert values without reflection; only fields of untyped DAML types
+// go through ProtoCodec.
+
+// ValueEncoder is implemented by generated records, variants and enums.
+type ValueEncoder interface {
+	ToValue() (*v2.Value, error)
+}
+
+// ValueDecoder is implemented by pointers to generated records, variants and enums.
+type ValueDecoder interface {
+	FromValue(pb *v2.Value) error
+}
+
+var defaultProtoCodec = NewProtoCodec()
+
+func UnitValue(types.UNIT) (*v2.Value, error) {
+	return unitValue, nil
+}
+
+func BoolValue(b types.BOOL) (*v2.Value, error) {
+	return &v2.Value{Sum: &v2.Value_Bool{Bool: bool(b)}}, nil
+}
+
+func Int64Value(i types.INT64) (*v2.Value, error) {
+	return &v2.Value{Sum: &v2.Value_Int64{Int64: int64(i)}}, nil
+}
+
+func TextValue(s types.TEXT) (*v2.Value, error) {
+	return StringValue(string(s))
+}
+
+// StringValue encodes a plain Go string as Text.
+func StringValue(s string) (*v2.Value, error) {
+	return &v2.Value{Sum: &v2.Value_Text{Text: s}}, nil
+}
+
+func PartyValue(p types.PARTY) (*v2.Value, error) {
+	return &v2.Value{Sum: &v2.Value_Party{Party: string(p)}}, nil
+}
+
+func ContractIDValue(cid types.CONTRACT_ID) (*v2.Value, error) {
+	return &v2.Value{Sum: &v2.Value_ContractId{ContractId: string(cid)}}, nil
+}
+
+// NumericValue encodes n with its own scale, failing if n is unset or out of range.
+func NumericValue(n types.Numeric) (*v2.Value, error) {
+	if n.IsZero() {
+		return nil, fmt.Errorf("numeric value is not set")
+	}
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+	return &v2.Value{Sum: &v2.Value_Numeric{Numeric: n.String()}}, nil
+}
+
+// DateValue encodes the calendar date of d in its own location, failing outside the DAML range.
+func DateValue(d types.DATE) (*v2.Value, error) {
+	return CivilDateValue(types.DateOf(time.Time(d)))
+}
+
+// CivilDateValue encodes d, failing outside the DAML range.
+func CivilDateValue(d types.Date) (*v2.Value, error) {
+	days, err := d.Days()
+	if err != nil {
+		return nil, err
+	}
+	return &v2.Value{Sum: &v2.Value_Date{Date: days}}, nil
+}
+
+// TimestampValue encodes t in microseconds, failing if t would lose precision or falls outside
+// the DAML range.
+func TimestampValue(t types.TIMESTAMP) (*v2.Value, error) {
+	micros, err := types.TimestampMicros(time.Time(t))
+	if err != nil {
+		return nil, err
+	}
+	return &v2.Value{Sum: &v2.Value_Timestamp{Timestamp: micros}}, nil
+}
+
+func RelTimeValue(d types.RELTIME) (*v2.Value, error) {
+	return &v2.Value{Sum: &v2.Value_Int64{Int64: int64(time.Duration(d) / time.Microsecond)}}, nil
+}
+
+func EnumValue(constructor string) *v2.Value {
+	return &v2.Value{Sum: &v2.Value_Enum{Enum: &v2.Enum{Constructor: constructor}}}
+}
+
+func VariantValue(constructor string, value *v2.Value) *v2.Value {
+	return &v2.Value{Sum: &v2.Value_Variant{Variant: &v2.Variant{Constructor: constructor, Value: value}}}
+}
+
+func RecordField(label string, value *v2.Value) *v2.RecordField {
+	return &v2.RecordField{Label: label, Value: value}
+}
+
+func RecordValue(fields ...*v2.RecordField) *v2.Value {
+	return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: fields}}}
+}
+
+// OptionalValue encodes a pointer field, nil being None.
+func OptionalValue[T any](p *T, encode func(T) (*v2.Value, error)) (*v2.Value, error) {
+	if p == nil {
+		return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{}}}, nil
+	}
+	v, err := encode(*p)
+	if err != nil {
+		return nil, err
+	}
+	return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{Value: v}}}, nil
+}
+
+func OptionalOfValue[T any](o types.Optional[T], encode func(T) (*v2.Value, error)) (*v2.Value, error) {
+	v, some := o.Get()
+	if !some {
+		return OptionalValue[T](nil, encode)
+	}
+	return OptionalValue(&v, encode)
+}
+
+func ListValue[T any](items []T, encode func(T) (*v2.Value, error)) (*v2.Value, error) {
+	elements := make([]*v2.Value, len(items))
+	for i, item := range items {
+		e, err := encode(item)
+		if err != nil {
+			return nil, fmt.Errorf("list element %d: %w", i, err)
+		}
+		elements[i] = e
+	}
+	return &v2.Value{Sum: &v2.Value_List{List: &v2.List{Elements: elements}}}, nil
+}
+
+// TextMapValue encodes the entries in key order.
+func TextMapValue[V any](m types.TextMap[V], encode func(V) (*v2.Value, error)) (*v2.Value, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]*v2.TextMap_Entry, len(keys))
+	for i, k := range keys {
+		v, err := encode(m[k])
+		if err != nil {
+			return nil, fmt.Errorf("map entry %s: %w", k, err)
+		}
+		entries[i] = &v2.TextMap_Entry{Key: k, Value: v}
+	}
+	return &v2.Value{Sum: &v2.Value_TextMap{TextMap: &v2.TextMap{Entries: entries}}}, nil
+}
+
+func GenMapValue[K comparable, V any](m types.GenMap[K, V], encodeKey func(K) (*v2.Value, error), encodeValue func(V) (*v2.Value, error)) (*v2.Value, error) {
+	entries := make([]*v2.GenMap_Entry, 0, m.Len())
+	for k, v := range m.All() {
+		e, err := genMapEntry(len(entries), k, v, encodeKey, encodeValue)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: entries}}}, nil
+}
+
+func EntryListValue[K, V any](l types.EntryList[K, V], encodeKey func(K) (*v2.Value, error), encodeValue func(V) (*v2.Value, error)) (*v2.Value, error) {
+	entries := make([]*v2.GenMap_Entry, len(l))
+	for i, e := range l {
+		entry, err := genMapEntry(i, e.Key, e.Value, encodeKey, encodeValue)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: entries}}}, nil
+}
+
+func genMapEntry[K, V any](i int, k K, v V, encodeKey func(K) (*v2.Value, error), encodeValue func(V) (*v2.Value, error)) (*v2.GenMap_Entry, error) {
+	key, err := encodeKey(k)
+	if err != nil {
+		return nil, fmt.Errorf("GenMap entry %d key: %w", i, err)
+	}
+	value, err := encodeValue(v)
+	if err != nil {
+		return nil, fmt.Errorf("GenMap entry %d value: %w", i, err)
+	}
+	return &v2.GenMap_Entry{Key: key, Value: value}, nil
+}
+
+// SetValue encodes s as the DA.Set.Set record.
+func SetValue[T comparable](s types.Set[T], encode func(T) (*v2.Value, error)) (*v2.Value, error) {
+	entries := make([]*v2.GenMap_Entry, 0, s.Len())
+	for i, item := range s.Items() {
+		key, err := encode(item)
+		if err != nil {
+			return nil, fmt.Errorf("set element %d: %w", i, err)
+		}
+		entries = append(entries, &v2.GenMap_Entry{Key: key, Value: unitValue})
+	}
+	return RecordValue(RecordField("map", &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: entries}}})), nil
+}
+
+func Tuple2Value[A, B any](t types.Tuple2[A, B], encodeA func(A) (*v2.Value, error), encodeB func(B) (*v2.Value, error)) (*v2.Value, error) {
+	first, err := encodeA(t.First)
+	if err != nil {
+		return nil, fmt.Errorf("field _1: %w", err)
+	}
+	second, err := encodeB(t.Second)
+	if err != nil {
+		return nil, fmt.Errorf("field _2: %w", err)
+	}
+	return RecordValue(RecordField("_1", first), RecordField("_2", second)), nil
+}
+
+func Tuple3Value[A, B, C any](t types.Tuple3[A, B, C], encodeA func(A) (*v2.Value, error), encodeB func(B) (*v2.Value, error), encodeC func(C) (*v2.Value, error)) (*v2.Value, error) {
+	first, err := encodeA(t.First)
+	if err != nil {
+		return nil, fmt.Errorf("field _1: %w", err)
+	}
+	second, err := encodeB(t.Second)
+	if err != nil {
+		return nil, fmt.Errorf("field _2: %w", err)
+	}
+	third, err := encodeC(t.Third)
+	if err != nil {
+		return nil, fmt.Errorf("field _3: %w", err)
+	}
+	return RecordValue(RecordField("_1", first), RecordField("_2", second), RecordField("_3", third)), nil
+}
+
+// DynamicValue encodes a field of an untyped DAML type with ProtoCodec.
+func DynamicValue(v interface{}) (*v2.Value, error) {
+	return defaultProtoCodec.ToValue(v)
+}
+
+func UnitFromValue(pb *v2.Value) (types.UNIT, error) {
+	return types.UNIT{}, expectSum(pb, pb.GetUnit() != nil, "Unit")
+}
+
+func BoolFromValue(pb *v2.Value) (types.BOOL, error) {
+	b, ok := pb.GetSum().(*v2.Value_Bool)
+	if !ok {
+		return false, unexpectedSum(pb, "Bool")
+	}
+	return types.BOOL(b.Bool), nil
+}
+
+func Int64FromValue(pb *v2.Value) (types.INT64, error) {
+	i, ok := pb.GetSum().(*v2.Value_Int64)
+	if !ok {
+		return 0, unexpectedSum(pb, "Int64")
+	}
+	return types.INT64(i.Int64), nil
+}
+
+func TextFromValue(pb *v2.Value) (types.TEXT, error) {
+	s, err := StringFromValue(pb)
+	return types.TEXT(s), err
+}
+
+// StringFromValue accepts Text, Party, ContractId and Enum values.
+func StringFromValue(pb *v2.Value) (string, error) {
+	s, ok := stringFromValue(pb)
+	if !ok {
+		return "", unexpectedSum(pb, "Text")
+	}
+	return s, nil
+}
+
+func PartyFromValue(pb *v2.Value) (types.PARTY, error) {
+	s, err := StringFromValue(pb)
+	return types.PARTY(s), err
+}
+
+func ContractIDFromValue(pb *v2.Value) (types.CONTRACT_ID, error) {
+	s, err := StringFromValue(pb)
+	return types.CONTRACT_ID(s), err
+}
+
+func NumericFromValue(pb *v2.Value) (types.Numeric, error) {
+	return numericFromValue(pb)
+}
+
+// DateFromValue decodes a Date as midnight UTC.
+func DateFromValue(pb *v2.Value) (types.DATE, error) {
+	d, err := CivilDateFromValue(pb)
+	if err != nil {
+		return types.DATE{}, err
+	}
+	return types.DATE(d.In(time.UTC)), nil
+}
+
+// CivilDateFromValue decodes a Date, failing outside the DAML range.
+func CivilDateFromValue(pb *v2.Value) (types.Date, error) {
+	d, ok := pb.GetSum().(*v2.Value_Date)
+	if !ok {
+		return types.Date{}, unexpectedSum(pb, "Date")
+	}
+	return types.DateFromDays(d.Date)
+}
+
+// TimestampFromValue decodes a Timestamp as a UTC time.
+func TimestampFromValue(pb *v2.Value) (types.TIMESTAMP, error) {
+	ts, ok := pb.GetSum().(*v2.Value_Timestamp)
+	if !ok {
+		return types.TIMESTAMP{}, unexpectedSum(pb, "Timestamp")
+	}
+	t, err := types.TimestampFromMicros(ts.Timestamp)
+	return types.TIMESTAMP(t), err
+}
+
+func RelTimeFromValue(pb *v2.Value) (types.RELTIME, error) {
+	i, ok := pb.GetSum().(*v2.Value_Int64)
+	if !ok {
+		return 0, unexpectedSum(pb, "Int64")
+	}
+	return types.RELTIME(time.Duration(i.Int64) * time.Microsecond), nil
+}
+
+func EnumFromValue(pb *v2.Value) (string, error) {
+	e, ok := pb.GetSum().(*v2.Value_Enum)
+	if !ok {
+		return "", unexpectedSum(pb, "Enum")
+	}
+	return e.Enum.GetConstructor(), nil
+}
+
+func VariantFromValue(pb *v2.Value) (string, *v2.Value, error) {
+	v, ok := pb.GetSum().(*v2.Value_Variant)
+	if !ok {
+		return "", nil, unexpectedSum(pb, "Variant")
+	}
+	return v.Variant.GetConstructor(), v.Variant.GetValue(), nil
+}
+
+// RecordFieldsFromValue returns the values of the fields with the given labels, in that order.
+// Fields without labels are matched by position; missing fields are nil.
+func RecordFieldsFromValue(pb *v2.Value, labels ...string) ([]*v2.Value, error) {
+	record, ok := pb.GetSum().(*v2.Value_Record)
+	if !ok {
+		return nil, unexpectedSum(pb, "Record")
+	}
+
+	values := make([]*v2.Value, len(labels))
+	for i, f := range record.Record.GetFields() {
+		if f.GetLabel() == "" {
+			if i < len(values) {
+				values[i] = f.GetValue()
+			}
+			continue
+		}
+		for j, label := range labels {
+			if label == f.GetLabel() {
+				values[j] = f.GetValue()
+				break
+			}
+		}
+	}
+	return values, nil
+}
+
+// OptionalFromValue decodes an Optional into a pointer; a missing value is None.
+func OptionalFromValue[T any](pb *v2.Value, decode func(*v2.Value) (T, error)) (*T, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	opt, ok := pb.GetSum().(*v2.Value_Optional)
+	if !ok {
+		return nil, unexpectedSum(pb, "Optional")
+	}
+	if opt.Optional.GetValue() == nil {
+		return nil, nil
+	}
+	v, err := decode(opt.Optional.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func OptionalOfFromValue[T any](pb *v2.Value, decode func(*v2.Value) (T, error)) (types.Optional[T], error) {
+	p, err := OptionalFromValue(pb, decode)
+	if err != nil || p == nil {
+		return types.None[T](), err
+	}
+	return types.Some(*p), nil
+}
+
+func ListFromValue[T any](pb *v2.Value, decode func(*v2.Value) (T, error)) ([]T, error) {
+	list, ok := pb.GetSum().(*v2.Value_List)
+	if !ok {
+		return nil, unexpectedSum(pb, "List")
+	}
+	items := make([]T, len(list.List.GetElements()))
+	for i, e := range list.List.GetElements() {
+		item, err := decode(e)
+		if err != nil {
+			return nil, fmt.Errorf("list element %d: %w", i, err)
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func TextMapFromValue[V any](pb *v2.Value, decode func(*v2.Value) (V, error)) (types.TextMap[V], error) {
+	tm, ok := pb.GetSum().(*v2.Value_TextMap)
+	if !ok {
+		return nil, unexpectedSum(pb, "TextMap")
+	}
+	m := make(types.TextMap[V], len(tm.TextMap.GetEntries()))
+	for _, e := range tm.TextMap.GetEntries() {
+		v, err := decode(e.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("map entry %s: %w", e.GetKey(), err)
+		}
+		m[e.GetKey()] = v
+	}
+	return m, nil
+}
+
+func GenMapFromValue[K comparable, V any](pb *v2.Value, decodeKey func(*v2.Value) (K, error), decodeValue func(*v2.Value) (V, error)) (types.GenMap[K, V], error) {
+	var m types.GenMap[K, V]
+	gm, ok := pb.GetSum().(*v2.Value_GenMap)
+	if !ok {
+		return m, unexpectedSum(pb, "GenMap")
+	}
+	for i, e := range gm.GenMap.GetEntries() {
+		k, err := decodeKey(e.GetKey())
+		if err != nil {
+			return m, fmt.Errorf("GenMap entry %d key: %w", i, err)
+		}
+		v, err := decodeValue(e.GetValue())
+		if err != nil {
+			return m, fmt.Errorf("GenMap entry %d value: %w", i, err)
+		}
+		m.Set(k, v)
+	}
+	return m, nil
+}
+
+func EntryListFromValue[K, V any](pb *v2.Value, decodeKey func(*v2.Value) (K, error), decodeValue func(*v2.Value) (V, error)) (types.EntryList[K, V], error) {
+	gm, ok := pb.GetSum().(*v2.Value_GenMap)
+	if !ok {
+		return nil, unexpectedSum(pb, "GenMap")
+	}
+	l := make(types.EntryList[K, V], len(gm.GenMap.GetEntries()))
+	for i, e := range gm.GenMap.GetEntries() {
+		k, err := decodeKey(e.GetKey())
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d key: %w", i, err)
+		}
+		v, err := decodeValue(e.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d value: %w", i, err)
+		}
+		l[i] = types.MapEntry[K, V]{Key: k, Value: v}
+	}
+	return l, nil
+}
+
+func SetFromValue[T comparable](pb *v2.Value, decode func(*v2.Value) (T, error)) (types.Set[T], error) {
+	var s types.Set[T]
+	fields, err := RecordFieldsFromValue(pb, "map")
+	if err != nil {
+		return s, err
+	}
+	items, err := GenMapFromValue(fields[0], decode, UnitFromValue)
+	if err != nil {
+		return s, err
+	}
+	return types.NewSet(items.Keys()...), nil
+}
+
+func Tuple2FromValue[A, B any](pb *v2.Value, decodeA func(*v2.Value) (A, error), decodeB func(*v2.Value) (B, error)) (types.Tuple2[A, B], error) {
+	var t types.Tuple2[A, B]
+	fields, err := RecordFieldsFromValue(pb, "_1", "_2")
+	if err != nil {
+		return t, err
+	}
+	if t.First, err = decodeA(fields[0]); err != nil {
+		return t, fmt.Errorf("field _1: %w", err)
+	}
+	if t.Second, err = decodeB(fields[1]); err != nil {
+		return t, fmt.Errorf("field _2: %w", err)
+	}
+	return t, nil
+}
+
+func Tuple3FromValue[A, B, C any](pb *v2.Value, decodeA func(*v2.Value) (A, error), decodeB func(*v2.Value) (B, error), decodeC func(*v2.Value) (C, error)) (types.Tuple3[A, B, C], error) {
+	var t types.Tuple3[A, B, C]
+	fields, err := RecordFieldsFromValue(pb, "_1", "_2", "_3")
+	if err != nil {
+		return t, err
+	}
+	if t.First, err = decodeA(fields[0]); err != nil {
+		return t, fmt.Errorf("field _1: %w", err)
+	}
+	if t.Second, err = decodeB(fields[1]); err != nil {
+		return t, fmt.Errorf("field _2: %w", err)
+	}
+	if t.Third, err = decodeC(fields[2]); err != nil {
+		return t, fmt.Errorf("field _3: %w", err)
+	}
+	return t, nil
+}
+
+// GeneratedFromValue decodes into a generated type through its FromValue method.
+func GeneratedFromValue[T any, PT interface {
+	*T
+	ValueDecoder
+}](pb *v2.Value) (T, error) {
+	var t T
+	err := PT(&t).FromValue(pb)
+	return t, err
+}
+
+// DynamicFromValue decodes a field of an untyped DAML type with ProtoCodec.
+func DynamicFromValue[T any](pb *v2.Value) (T, error) {
+	var t T
+	err := defaultProtoCodec.FromValue(pb, &t)
+	return t, err
+}
@@ -0,0 +1,387 @@
+package lf
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	daml "github.com/digital-asset/dazl-client/v8/go/api/com/daml/daml_lf_2_1"
+	"google.golang.org/protobuf/proto"
+)
+
+// darArchives returns the DALFs of a DAR, with the main DALF named in the manifest first.
+func darArchives(dar []byte) ([][]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(dar), int64(len(dar)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dar: %w", err)
+	}
+
+	var mainDalf string
+	for _, f := range r.File {
+		if f.Name == "META-INF/MANIFEST.MF" {
+			manifest, err := readZipFile(f)
+			if err != nil {
+				return nil, err
+			}
+			mainDalf = manifestMainDalf(string(manifest))
+		}
+	}
+
+	var archives [][]byte
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, ".dalf") {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if f.Name == mainDalf {
+			archives = append([][]byte{data}, archives...)
+		} else {
+			archives = append(archives, data)
+		}
+	}
+	if len(archives) == 0 {
+		return nil, errors.New("dar contains no dalf files")
+	}
+	return archives, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	return data, nil
+}
+
+func manifestMainDalf(manifest string) string {
+	// manifest lines are wrapped with a leading space on continuation lines
+	manifest = strings.ReplaceAll(manifest, "\r\n", "\n")
+	manifest = strings.ReplaceAll(manifest, "\n ", "")
+	for _, line := range strings.Split(manifest, "\n") {
+		if value, ok := strings.CutPrefix(line, "Main-Dalf:"); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func decodeArchive(dalf []byte) (*daml.Archive, error) {
+	var archive daml.Archive
+	if err := proto.Unmarshal(dalf, &archive); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archive: %w", err)
+	}
+	return &archive, nil
+}
+
+func decodePackage(packageID string, payload []byte) (*Package, error) {
+	var archivePayload daml.ArchivePayload
+	if err := proto.Unmarshal(payload, &archivePayload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archive payload: %w", err)
+	}
+
+	damlLf := archivePayload.GetDamlLf_2()
+	if damlLf == nil {
+		return nil, errors.New("unsupported daml version")
+	}
+
+	var pkg daml.Package
+	if err := proto.Unmarshal(damlLf, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal package: %w", err)
+	}
+
+	d := &decoder{id: packageID, pkg: &pkg, types: make([]*Type, len(pkg.InternedTypes))}
+	p := d.decode()
+	p.LanguageVersion = "2." + archivePayload.GetMinor()
+	return p, nil
+}
+
+// decoder resolves the interned strings, names and types of a package.
+type decoder struct {
+	id    string
+	pkg   *daml.Package
+	types []*Type
+}
+
+func (d *decoder) decode() *Package {
+	p := &Package{
+		ID:         d.id,
+		dataTypes:  make(map[string]*DataType),
+		synonyms:   make(map[string]*TypeSynonym),
+		templates:  make(map[string]*Template),
+		interfaces: make(map[string]*Interface),
+	}
+	if meta := d.pkg.GetMetadata(); meta != nil {
+		p.Name = d.str(meta.NameInternedStr)
+		p.Version = d.str(meta.VersionInternedStr)
+	}
+
+	for _, m := range d.pkg.Modules {
+		module := &Module{Name: d.dottedName(m.NameInternedDname)}
+		name := func(id int32) TypeConName {
+			return TypeConName{PackageID: d.id, Module: module.Name, Name: d.dottedName(id)}
+		}
+
+		for _, def := range m.DataTypes {
+			dataType := d.dataType(name(def.NameInternedDname), def)
+			module.DataTypes = append(module.DataTypes, dataType)
+			p.dataTypes[dataType.ID.QualifiedName()] = dataType
+		}
+		for _, def := range m.Synonyms {
+			synonym := &TypeSynonym{ID: name(def.NameInternedDname), Params: d.params(def.Params), Type: d.typ(def.Type)}
+			module.Synonyms = append(module.Synonyms, synonym)
+			p.synonyms[synonym.ID.QualifiedName()] = synonym
+		}
+		for _, def := range m.Templates {
+			template := &Template{ID: name(def.TyconInternedDname), Choices: d.choices(def.Choices)}
+			if def.Key != nil {
+				template.Key = d.typ(def.Key.Type)
+			}
+			for _, impl := range def.Implements {
+				template.Implements = append(template.Implements, d.typeConName(impl.GetInterface().GetModule(), impl.GetInterface().GetNameInternedDname()))
+			}
+			module.Templates = append(module.Templates, template)
+			p.templates[template.ID.QualifiedName()] = template
+		}
+		for _, def := range m.Interfaces {
+			iface := &Interface{ID: name(def.TyconInternedDname), Choices: d.choices(def.Choices), View: d.typ(def.View)}
+			for _, method := range def.Methods {
+				iface.Methods = append(iface.Methods, &Field{Name: d.str(method.MethodInternedName), Type: d.typ(method.Type)})
+			}
+			for _, required := range def.Requires {
+				iface.Requires = append(iface.Requires, d.typeConName(required.GetModule(), required.GetNameInternedDname()))
+			}
+			module.Interfaces = append(module.Interfaces, iface)
+			p.interfaces[iface.ID.QualifiedName()] = iface
+		}
+		p.Modules = append(p.Modules, module)
+	}
+
+	if imports := d.pkg.GetPackageImports(); imports != nil {
+		p.Dependencies = imports.ImportedPackages
+	} else {
+		p.Dependencies = dependencies(p)
+	}
+	return p
+}
+
+func (d *decoder) dataType(id TypeConName, def *daml.DefDataType) *DataType {
+	dataType := &DataType{ID: id, Params: d.params(def.Params), Serializable: def.Serializable}
+	switch cons := def.DataCons.(type) {
+	case *daml.DefDataType_Record:
+		dataType.Kind = DataRecord
+		dataType.Fields = d.fields(cons.Record.Fields)
+	case *daml.DefDataType_Variant:
+		dataType.Kind = DataVariant
+		dataType.Fields = d.fields(cons.Variant.Fields)
+	case *daml.DefDataType_Enum:
+		dataType.Kind = DataEnum
+		for _, constructor := range cons.Enum.ConstructorsInternedStr {
+			dataType.Constructors = append(dataType.Constructors, d.str(constructor))
+		}
+	default:
+		dataType.Kind = DataInterface
+	}
+	return dataType
+}
+
+func (d *decoder) fields(defs []*daml.FieldWithType) []*Field {
+	fields := make([]*Field, len(defs))
+	for i, def := range defs {
+		fields[i] = &Field{Name: d.str(def.FieldInternedStr), Type: d.typ(def.Type)}
+	}
+	return fields
+}
+
+func (d *decoder) params(defs []*daml.TypeVarWithKind) []string {
+	params := make([]string, len(defs))
+	for i, def := range defs {
+		params[i] = d.str(def.VarInternedStr)
+	}
+	return params
+}
+
+func (d *decoder) choices(defs []*daml.TemplateChoice) []*Choice {
+	choices := make([]*Choice, len(defs))
+	for i, def := range defs {
+		choices[i] = &Choice{
+			Name:       d.str(def.NameInternedStr),
+			Consuming:  def.Consuming,
+			ArgType:    d.typ(def.GetArgBinder().GetType()),
+			ReturnType: d.typ(def.RetType),
+		}
+	}
+	return choices
+}
+
+func (d *decoder) typ(t *daml.Type) *Type {
+	switch sum := t.GetSum().(type) {
+	case nil:
+		return nil
+	case *daml.Type_InternedType:
+		i := sum.InternedType
+		if i < 0 || int(i) >= len(d.types) {
+			return &Type{Kind: TypeUnsupported}
+		}
+		if d.types[i] == nil {
+			d.types[i] = d.typ(d.pkg.InternedTypes[i])
+		}
+		return d.types[i]
+	case *daml.Type_Tapp:
+		// flatten the curried application into the arguments of its head
+		lhs := d.typ(sum.Tapp.Lhs)
+		if lhs == nil {
+			return &Type{Kind: TypeUnsupported}
+		}
+		head := *lhs
+		head.Args = append(append([]*Type(nil), head.Args...), d.typ(sum.Tapp.Rhs))
+		return &head
+	case *daml.Type_Builtin_:
+		builtin, ok := builtinsFromProto[sum.Builtin.Builtin]
+		if !ok {
+			return &Type{Kind: TypeUnsupported}
+		}
+		return &Type{Kind: TypeBuiltin, Builtin: builtin, Args: d.typeList(sum.Builtin.Args)}
+	case *daml.Type_Con_:
+		return &Type{
+			Kind: TypeCon,
+			Con:  d.typeConName(sum.Con.GetTycon().GetModule(), sum.Con.GetTycon().GetNameInternedDname()),
+			Args: d.typeList(sum.Con.Args),
+		}
+	case *daml.Type_Syn_:
+		return &Type{
+			Kind: TypeSyn,
+			Con:  d.typeConName(sum.Syn.GetTysyn().GetModule(), sum.Syn.GetTysyn().GetNameInternedDname()),
+			Args: d.typeList(sum.Syn.Args),
+		}
+	case *daml.Type_Var_:
+		return &Type{Kind: TypeVar, Var: d.str(sum.Var.VarInternedStr), Args: d.typeList(sum.Var.Args)}
+	case *daml.Type_Nat:
+		return &Type{Kind: TypeNat, Nat: sum.Nat}
+	default:
+		return &Type{Kind: TypeUnsupported}
+	}
+}
+
+func (d *decoder) typeList(defs []*daml.Type) []*Type {
+	if len(defs) == 0 {
+		return nil
+	}
+	types := make([]*Type, len(defs))
+	for i, def := range defs {
+		types[i] = d.typ(def)
+	}
+	return types
+}
+
+func (d *decoder) str(id int32) string {
+	if id < 0 || int(id) >= len(d.pkg.InternedStrings) {
+		return ""
+	}
+	return d.pkg.InternedStrings[id]
+}
+
+func (d *decoder) dottedName(id int32) string {
+	if id < 0 || int(id) >= len(d.pkg.InternedDottedNames) {
+		return ""
+	}
+	segments := d.pkg.InternedDottedNames[id].SegmentsInternedStr
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = d.str(segment)
+	}
+	return strings.Join(parts, ".")
+}
+
+func (d *decoder) typeConName(module *daml.ModuleId, name int32) TypeConName {
+	return TypeConName{
+		PackageID: d.packageID(module.GetPackageId()),
+		Module:    d.dottedName(module.GetModuleNameInternedDname()),
+		Name:      d.dottedName(name),
+	}
+}
+
+func (d *decoder) packageID(ref *daml.SelfOrImportedPackageId) string {
+	switch sum := ref.GetSum().(type) {
+	case *daml.SelfOrImportedPackageId_ImportedPackageIdInternedStr:
+		return d.str(sum.ImportedPackageIdInternedStr)
+	case *daml.SelfOrImportedPackageId_PackageImportId:
+		imports := d.pkg.GetPackageImports().GetImportedPackages()
+		if sum.PackageImportId < 0 || int(sum.PackageImportId) >= len(imports) {
+			return ""
+		}
+		return imports[sum.PackageImportId]
+	default:
+		return d.id
+	}
+}
+
+// dependencies collects the other packages named in the types and interfaces of p.
+func dependencies(p *Package) []string {
+	seen := map[string]bool{p.ID: true, "": true}
+	var deps []string
+	add := func(name TypeConName) {
+		if !seen[name.PackageID] {
+			seen[name.PackageID] = true
+			deps = append(deps, name.PackageID)
+		}
+	}
+	var walk func(t *Type)
+	walk = func(t *Type) {
+		if t == nil {
+			return
+		}
+		if t.Kind == TypeCon || t.Kind == TypeSyn {
+			add(t.Con)
+		}
+		for _, arg := range t.Args {
+			walk(arg)
+		}
+	}
+	walkChoices := func(choices []*Choice) {
+		for _, choice := range choices {
+			walk(choice.ArgType)
+			walk(choice.ReturnType)
+		}
+	}
+
+	for _, module := range p.Modules {
+		for _, dataType := range module.DataTypes {
+			for _, field := range dataType.Fields {
+				walk(field.Type)
+			}
+		}
+		for _, synonym := range module.Synonyms {
+			walk(synonym.Type)
+		}
+		for _, template := range module.Templates {
+			walk(template.Key)
+			walkChoices(template.Choices)
+			for _, iface := range template.Implements {
+				add(iface)
+			}
+		}
+		for _, iface := range module.Interfaces {
+			walk(iface.View)
+			walkChoices(iface.Choices)
+			for _, method := range iface.Methods {
+				walk(method.Type)
+			}
+			for _, required := range iface.Requires {
+				add(required)
+			}
+		}
+	}
+	return deps
+}
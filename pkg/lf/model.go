@@ -0,0 +1,168 @@
+package lf
+
+// TypeConName identifies a data type, template, interface or type synonym.
+type TypeConName struct {
+	PackageID string
+	Module    string
+	Name      string
+}
+
+// String returns the name in template ID form, packageID:Module:Name.
+func (n TypeConName) String() string {
+	return n.PackageID + ":" + n.QualifiedName()
+}
+
+// QualifiedName returns Module:Name.
+func (n TypeConName) QualifiedName() string {
+	return n.Module + ":" + n.Name
+}
+
+type DataKind int
+
+const (
+	DataRecord DataKind = iota
+	DataVariant
+	DataEnum
+	DataInterface
+)
+
+func (k DataKind) String() string {
+	switch k {
+	case DataRecord:
+		return "record"
+	case DataVariant:
+		return "variant"
+	case DataEnum:
+		return "enum"
+	default:
+		return "interface"
+	}
+}
+
+type Package struct {
+	ID      string
+	Name    string
+	Version string
+	// LanguageVersion is the DAML-LF version of the archive, such as 2.1.
+	LanguageVersion string
+	Modules         []*Module
+	// Dependencies are the IDs of the packages whose definitions this package refers to.
+	Dependencies []string
+
+	dataTypes  map[string]*DataType
+	synonyms   map[string]*TypeSynonym
+	templates  map[string]*Template
+	interfaces map[string]*Interface
+}
+
+// DataType returns the data type with the given Module:Name, or nil.
+func (p *Package) DataType(name string) *DataType {
+	return p.dataTypes[name]
+}
+
+// Synonym returns the type synonym with the given Module:Name, or nil.
+func (p *Package) Synonym(name string) *TypeSynonym {
+	return p.synonyms[name]
+}
+
+// Template returns the template with the given Module:Name, or nil.
+func (p *Package) Template(name string) *Template {
+	return p.templates[name]
+}
+
+// Interface returns the interface with the given Module:Name, or nil.
+func (p *Package) Interface(name string) *Interface {
+	return p.interfaces[name]
+}
+
+// Module returns the module with the given dotted name, or nil.
+func (p *Package) Module(name string) *Module {
+	for _, module := range p.Modules {
+		if module.Name == name {
+			return module
+		}
+	}
+	return nil
+}
+
+type Module struct {
+	Name       string
+	DataTypes  []*DataType
+	Synonyms   []*TypeSynonym
+	Templates  []*Template
+	Interfaces []*Interface
+}
+
+type DataType struct {
+	ID           TypeConName
+	Params       []string
+	Serializable bool
+	Kind         DataKind
+	// Fields holds the fields of a record, or the constructors of a variant and their types.
+	Fields []*Field
+	// Constructors holds the constructors of an enum.
+	Constructors []string
+}
+
+// Field returns the record field or variant constructor with the given name, or nil.
+func (d *DataType) Field(name string) *Field {
+	for _, field := range d.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+type Field struct {
+	Name string
+	Type *Type
+}
+
+type TypeSynonym struct {
+	ID     TypeConName
+	Params []string
+	Type   *Type
+}
+
+type Template struct {
+	ID      TypeConName
+	Choices []*Choice
+	// Key is the type of the contract key, or nil if the template has none.
+	Key        *Type
+	Implements []TypeConName
+}
+
+// Choice returns the template's own choice with the given name, or nil.
+func (t *Template) Choice(name string) *Choice {
+	return findChoice(t.Choices, name)
+}
+
+type Choice struct {
+	Name       string
+	Consuming  bool
+	ArgType    *Type
+	ReturnType *Type
+}
+
+type Interface struct {
+	ID       TypeConName
+	Choices  []*Choice
+	Methods  []*Field
+	View     *Type
+	Requires []TypeConName
+}
+
+// Choice returns the interface choice with the given name, or nil.
+func (i *Interface) Choice(name string) *Choice {
+	return findChoice(i.Choices, name)
+}
+
+func findChoice(choices []*Choice, name string) *Choice {
+	for _, choice := range choices {
+		if choice.Name == name {
+			return choice
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package lf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/ledger"
+)
+
+// Registry holds decoded DAML-LF packages by package ID and resolves names across them.
+// It is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	packages map[string]*Package
+}
+
+func NewRegistry() *Registry {
+	return &Registry{packages: make(map[string]*Package)}
+}
+
+// AddArchive decodes a DALF, unless a package with its hash is already registered.
+func (r *Registry) AddArchive(dalf []byte) (*Package, error) {
+	archive, err := decodeArchive(dalf)
+	if err != nil {
+		return nil, err
+	}
+	return r.AddPackage(archive.Hash, archive.Payload)
+}
+
+// AddPackage decodes an archive payload as returned by PackageService.GetPackage, unless
+// the package is already registered.
+func (r *Registry) AddPackage(packageID string, archivePayload []byte) (*Package, error) {
+	if p, ok := r.Package(packageID); ok {
+		return p, nil
+	}
+
+	p, err := decodePackage(packageID, archivePayload)
+	if err != nil {
+		return nil, fmt.Errorf("package %s: %w", packageID, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.packages[packageID]; ok {
+		return existing, nil
+	}
+	r.packages[packageID] = p
+	return p, nil
+}
+
+// AddDar registers every DALF of a DAR and returns its main package.
+func (r *Registry) AddDar(dar []byte) (*Package, error) {
+	archives, err := darArchives(dar)
+	if err != nil {
+		return nil, err
+	}
+
+	var main *Package
+	for _, dalf := range archives {
+		p, err := r.AddArchive(dalf)
+		if err != nil {
+			return nil, err
+		}
+		if main == nil {
+			main = p
+		}
+	}
+	return main, nil
+}
+
+// LoadPackage fetches a package, and the packages it depends on, from the participant.
+// Registered packages are not fetched again.
+func (r *Registry) LoadPackage(ctx context.Context, svc ledger.PackageService, packageID string) (*Package, error) {
+	var main *Package
+	pending := []string{packageID}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		p, ok := r.Package(id)
+		if !ok {
+			resp, err := svc.GetPackage(ctx, &model.GetPackageRequest{PackageID: id})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get package %s: %w", id, err)
+			}
+			if p, err = r.AddPackage(id, resp.ArchivePayload); err != nil {
+				return nil, err
+			}
+			pending = append(pending, p.Dependencies...)
+		}
+		if main == nil {
+			main = p
+		}
+	}
+	return main, nil
+}
+
+// Package returns the package with the given ID.
+func (r *Registry) Package(packageID string) (*Package, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.packages[packageID]
+	return p, ok
+}
+
+// Packages returns the registered packages ordered by name and version.
+func (r *Registry) Packages() []*Package {
+	r.mu.RLock()
+	packages := make([]*Package, 0, len(r.packages))
+	for _, p := range r.packages {
+		packages = append(packages, p)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(packages, func(i, j int) bool {
+		a, b := packages[i], packages[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if c := compareVersions(a.Version, b.Version); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	})
+	return packages
+}
+
+// DataType returns the data type with the given name.
+func (r *Registry) DataType(name TypeConName) (*DataType, bool) {
+	p, ok := r.Package(name.PackageID)
+	if !ok || p.DataType(name.QualifiedName()) == nil {
+		return nil, false
+	}
+	return p.DataType(name.QualifiedName()), true
+}
+
+// Synonym returns the type synonym with the given name.
+func (r *Registry) Synonym(name TypeConName) (*TypeSynonym, bool) {
+	p, ok := r.Package(name.PackageID)
+	if !ok || p.Synonym(name.QualifiedName()) == nil {
+		return nil, false
+	}
+	return p.Synonym(name.QualifiedName()), true
+}
+
+// Template finds a template by ID. The package part of the ID may be a package ID, a
+// package name with or without the # prefix, or omitted; a package name resolves to the
+// highest registered version that defines the template.
+func (r *Registry) Template(templateID string) (*Template, error) {
+	p, name, err := r.lookup(templateID, func(p *Package, name string) bool { return p.Template(name) != nil })
+	if err != nil {
+		return nil, fmt.Errorf("template %w", err)
+	}
+	return p.Template(name), nil
+}
+
+// Interface finds an interface by ID, resolved like Template.
+func (r *Registry) Interface(interfaceID string) (*Interface, error) {
+	p, name, err := r.lookup(interfaceID, func(p *Package, name string) bool { return p.Interface(name) != nil })
+	if err != nil {
+		return nil, fmt.Errorf("interface %w", err)
+	}
+	return p.Interface(name), nil
+}
+
+// Choice finds a choice exercised through templateID, which may name a template or an
+// interface. Choices of the interfaces a template implements are included.
+func (r *Registry) Choice(templateID, choice string) (*Choice, error) {
+	p, name, err := r.lookup(templateID, func(p *Package, name string) bool {
+		return p.Template(name) != nil || p.Interface(name) != nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("template %w", err)
+	}
+
+	if iface := p.Interface(name); iface != nil {
+		if c := iface.Choice(choice); c != nil {
+			return c, nil
+		}
+		return nil, fmt.Errorf("choice %s not found on %s", choice, templateID)
+	}
+
+	template := p.Template(name)
+	if c := template.Choice(choice); c != nil {
+		return c, nil
+	}
+	for _, ifaceName := range template.Implements {
+		if ifacePkg, ok := r.Package(ifaceName.PackageID); ok {
+			if iface := ifacePkg.Interface(ifaceName.QualifiedName()); iface != nil && iface.Choice(choice) != nil {
+				return iface.Choice(choice), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("choice %s not found on %s", choice, templateID)
+}
+
+func (r *Registry) lookup(id string, defines func(p *Package, name string) bool) (*Package, string, error) {
+	var pkgRef, name string
+	switch parts := strings.Split(id, ":"); len(parts) {
+	case 3:
+		pkgRef, name = strings.TrimPrefix(parts[0], "#"), parts[1]+":"+parts[2]
+	case 2:
+		name = parts[0] + ":" + parts[1]
+	default:
+		return nil, "", fmt.Errorf("ID %q is invalid", id)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *Package
+	for _, p := range r.packages {
+		if pkgRef != "" && p.ID != pkgRef && p.Name != pkgRef {
+			continue
+		}
+		if !defines(p, name) {
+			continue
+		}
+		if found == nil || compareVersions(p.Version, found.Version) > 0 {
+			found = p
+		}
+	}
+	if found == nil {
+		return nil, "", fmt.Errorf("%s not found in registered packages", id)
+	}
+	return found, name, nil
+}
+
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			return x - y
+		}
+	}
+	return len(as) - len(bs)
+}
@@ -0,0 +1,150 @@
+package lf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	allKindsID  = "ddf0d6396a862eaa7f8d647e39d090a6b04c4a3fd6736aa1730ebc9fca6be664"
+	allKindsDar = "../../test-data/all-kinds-of-1.0.0.dar"
+)
+
+func readDar(t *testing.T, path string) []byte {
+	dar, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return dar
+}
+
+func TestRegistryAddDar(t *testing.T) {
+	r := NewRegistry()
+	main, err := r.AddDar(readDar(t, allKindsDar))
+	require.NoError(t, err)
+	require.Equal(t, allKindsID, main.ID)
+	require.Equal(t, "all-kinds-of", main.Name)
+	require.Equal(t, "1.0.0", main.Version)
+	require.Equal(t, "2.1", main.LanguageVersion)
+	require.NotNil(t, main.Module("AllKindsOf"))
+	require.Greater(t, len(r.Packages()), 1)
+
+	pair := main.DataType("AllKindsOf:MyPair")
+	require.Equal(t, DataRecord, pair.Kind)
+	require.Equal(t, []string{"a"}, pair.Params)
+	require.Equal(t, "left", pair.Fields[0].Name)
+	require.Equal(t, &Type{Kind: TypeVar, Var: "a"}, pair.Fields[0].Type)
+
+	color, ok := r.DataType(TypeConName{PackageID: allKindsID, Module: "AllKindsOf", Name: "Color"})
+	require.True(t, ok)
+	require.Equal(t, DataEnum, color.Kind)
+	require.Equal(t, []string{"Red", "Green", "Blue"}, color.Constructors)
+
+	vpair := main.DataType("AllKindsOf:VPair")
+	require.Equal(t, DataVariant, vpair.Kind)
+	require.Equal(t, "AllKindsOf:VPair a", vpair.Field("Both").Type.String())
+
+	template, err := r.Template("#all-kinds-of:AllKindsOf:OneOfEverything")
+	require.NoError(t, err)
+	require.Nil(t, template.Key)
+	fields := main.DataType(template.ID.QualifiedName()).Fields
+	types := make(map[string]string, len(fields))
+	for _, field := range fields {
+		types[field.Name] = field.Type.String()
+	}
+	require.Equal(t, "Numeric 10", types["someDecimal"])
+	require.Equal(t, "Numeric 10", types["someMeasurement"])
+	require.Equal(t, "Optional Int64", types["someMaybe"])
+	require.Equal(t, "List Int64", types["someSimpleList"])
+	require.Equal(t, "AllKindsOf:VPair (AllKindsOf:MyPair (AllKindsOf:MyPair Int64))", types["someUglyNesting"])
+
+	mappy, err := r.Template(allKindsID + ":AllKindsOf:MappyContract")
+	require.NoError(t, err)
+	require.Equal(t, "TextMap Text", main.DataType(mappy.ID.QualifiedName()).Field("value").Type.String())
+
+	choice, err := r.Choice("AllKindsOf:OneOfEverything", "Accept")
+	require.NoError(t, err)
+	require.True(t, choice.Consuming)
+	require.True(t, choice.ReturnType.IsBuiltin(BuiltinUnit))
+	require.Equal(t, TypeCon, choice.ArgType.Kind)
+
+	_, err = r.Choice("#all-kinds-of:AllKindsOf:OneOfEverything", "Reject")
+	require.ErrorContains(t, err, "choice Reject not found")
+	_, err = r.Template("#other:AllKindsOf:OneOfEverything")
+	require.ErrorContains(t, err, "not found in registered packages")
+}
+
+func TestRegistryInterfaces(t *testing.T) {
+	r := NewRegistry()
+	_, err := r.AddDar(readDar(t, "../../test-data/amulets-interface-test-1.0.0.dar"))
+	require.NoError(t, err)
+
+	iface, err := r.Interface("#amulets-interface-test:Interfaces:Transferable")
+	require.NoError(t, err)
+	require.Equal(t, "Interfaces:TransferableView", iface.View.String())
+	require.Len(t, iface.Methods, 2)
+
+	asset, err := r.Template("#amulets-interface-test:Interfaces:Asset")
+	require.NoError(t, err)
+	require.Equal(t, []TypeConName{iface.ID}, asset.Implements)
+
+	choice, err := r.Choice("#amulets-interface-test:Interfaces:Asset", "Transfer")
+	require.NoError(t, err)
+	require.Same(t, iface.Choice("Transfer"), choice)
+	require.Equal(t, "ContractId Interfaces:Transferable", choice.ReturnType.String())
+}
+
+type fakePackageService struct {
+	payloads map[string][]byte
+	fetched  []string
+}
+
+func (s *fakePackageService) ListPackages(context.Context, *model.ListPackagesRequest) (*model.ListPackagesResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *fakePackageService) GetPackage(_ context.Context, req *model.GetPackageRequest) (*model.GetPackageResponse, error) {
+	payload, ok := s.payloads[req.PackageID]
+	if !ok {
+		return nil, fmt.Errorf("package %s not found", req.PackageID)
+	}
+	s.fetched = append(s.fetched, req.PackageID)
+	return &model.GetPackageResponse{ArchivePayload: payload, Hash: req.PackageID, HashFunction: model.HashFunctionSHA256}, nil
+}
+
+func (s *fakePackageService) GetPackageStatus(context.Context, *model.GetPackageStatusRequest) (*model.GetPackageStatusResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestRegistryLoadPackage(t *testing.T) {
+	archives, err := darArchives(readDar(t, allKindsDar))
+	require.NoError(t, err)
+	svc := &fakePackageService{payloads: make(map[string][]byte)}
+	for _, dalf := range archives {
+		archive, err := decodeArchive(dalf)
+		require.NoError(t, err)
+		svc.payloads[archive.Hash] = archive.Payload
+	}
+
+	r := NewRegistry()
+	main, err := r.LoadPackage(context.Background(), svc, allKindsID)
+	require.NoError(t, err)
+	require.Equal(t, allKindsID, main.ID)
+	require.NotEmpty(t, main.Dependencies)
+	require.Len(t, svc.fetched, len(main.Dependencies)+1)
+	for _, dep := range main.Dependencies {
+		_, ok := r.Package(dep)
+		require.True(t, ok, dep)
+	}
+
+	again, err := r.LoadPackage(context.Background(), svc, allKindsID)
+	require.NoError(t, err)
+	require.Same(t, main, again)
+	require.Len(t, svc.fetched, len(main.Dependencies)+1)
+
+	_, err = r.LoadPackage(context.Background(), svc, "missing")
+	require.ErrorContains(t, err, "failed to get package missing")
+}
@@ -0,0 +1,77 @@
+package lf
+
+// maxTypeDepth bounds the expansion of type synonyms and variables.
+const maxTypeDepth = 64
+
+// TypeRef is a type together with the bindings of its type variables.
+type TypeRef struct {
+	Type *Type
+	Vars map[string]TypeRef
+}
+
+// Resolve expands type variables and synonyms until it reaches a builtin, a data type
+// or a Nat, and returns it together with its arguments. It fails on unbound variables,
+// synonyms of unregistered packages and unsupported types.
+func (r *Registry) Resolve(t TypeRef) (*Type, []TypeRef, bool) {
+	var args []TypeRef
+	wrap := func(ref TypeRef) []TypeRef {
+		refs := make([]TypeRef, 0, len(ref.Type.Args)+len(args))
+		for _, arg := range ref.Type.Args {
+			refs = append(refs, TypeRef{Type: arg, Vars: ref.Vars})
+		}
+		return append(refs, args...)
+	}
+
+	for depth := 0; depth < maxTypeDepth && t.Type != nil; depth++ {
+		switch t.Type.Kind {
+		case TypeVar:
+			bound, ok := t.Vars[t.Type.Var]
+			if !ok {
+				return nil, nil, false
+			}
+			args = wrap(t)
+			t = bound
+		case TypeSyn:
+			synonym, ok := r.Synonym(t.Type.Con)
+			if !ok {
+				return nil, nil, false
+			}
+			args = wrap(t)
+			vars := BindParams(synonym.Params, args)
+			if len(args) > len(synonym.Params) {
+				args = args[len(synonym.Params):]
+			} else {
+				args = nil
+			}
+			t = TypeRef{Type: synonym.Type, Vars: vars}
+		case TypeBuiltin, TypeCon, TypeNat:
+			return t.Type, wrap(t), true
+		default:
+			return nil, nil, false
+		}
+	}
+	return nil, nil, false
+}
+
+// NumericScale returns the scale of a Numeric applied to args.
+func (r *Registry) NumericScale(args []TypeRef) (int64, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	scale, _, ok := r.Resolve(args[0])
+	if !ok || scale.Kind != TypeNat {
+		return 0, false
+	}
+	return scale.Nat, true
+}
+
+// BindParams binds the type parameters of a data type or synonym to its arguments.
+func BindParams(params []string, args []TypeRef) map[string]TypeRef {
+	vars := make(map[string]TypeRef, len(params))
+	for i, param := range params {
+		if i < len(args) {
+			vars[param] = args[i]
+		}
+	}
+	return vars
+}
@@ -0,0 +1,148 @@
+package lf
+
+import (
+	"strconv"
+	"strings"
+
+	daml "github.com/digital-asset/dazl-client/v8/go/api/com/daml/daml_lf_2_1"
+)
+
+type TypeKind int
+
+const (
+	TypeBuiltin TypeKind = iota
+	TypeCon
+	TypeSyn
+	TypeVar
+	TypeNat
+	// TypeUnsupported covers the non-serializable forall and struct types.
+	TypeUnsupported
+)
+
+type BuiltinType int
+
+const (
+	BuiltinUnit BuiltinType = iota
+	BuiltinBool
+	BuiltinInt64
+	BuiltinText
+	BuiltinNumeric
+	BuiltinParty
+	BuiltinDate
+	BuiltinTimestamp
+	BuiltinContractID
+	BuiltinOptional
+	BuiltinList
+	BuiltinTextMap
+	BuiltinGenMap
+	BuiltinAny
+	BuiltinAnyException
+	BuiltinTypeRep
+	BuiltinArrow
+	BuiltinUpdate
+	BuiltinFailureCategory
+	BuiltinBigNumeric
+	BuiltinRoundingMode
+)
+
+var builtinNames = [...]string{
+	BuiltinUnit:            "Unit",
+	BuiltinBool:            "Bool",
+	BuiltinInt64:           "Int64",
+	BuiltinText:            "Text",
+	BuiltinNumeric:         "Numeric",
+	BuiltinParty:           "Party",
+	BuiltinDate:            "Date",
+	BuiltinTimestamp:       "Timestamp",
+	BuiltinContractID:      "ContractId",
+	BuiltinOptional:        "Optional",
+	BuiltinList:            "List",
+	BuiltinTextMap:         "TextMap",
+	BuiltinGenMap:          "GenMap",
+	BuiltinAny:             "Any",
+	BuiltinAnyException:    "AnyException",
+	BuiltinTypeRep:         "TypeRep",
+	BuiltinArrow:           "Arrow",
+	BuiltinUpdate:          "Update",
+	BuiltinFailureCategory: "FailureCategory",
+	BuiltinBigNumeric:      "BigNumeric",
+	BuiltinRoundingMode:    "RoundingMode",
+}
+
+func (b BuiltinType) String() string {
+	if b < 0 || int(b) >= len(builtinNames) {
+		return "BuiltinType(" + strconv.Itoa(int(b)) + ")"
+	}
+	return builtinNames[b]
+}
+
+var builtinsFromProto = map[daml.BuiltinType]BuiltinType{
+	daml.BuiltinType_UNIT:             BuiltinUnit,
+	daml.BuiltinType_BOOL:             BuiltinBool,
+	daml.BuiltinType_INT64:            BuiltinInt64,
+	daml.BuiltinType_TEXT:             BuiltinText,
+	daml.BuiltinType_NUMERIC:          BuiltinNumeric,
+	daml.BuiltinType_PARTY:            BuiltinParty,
+	daml.BuiltinType_DATE:             BuiltinDate,
+	daml.BuiltinType_TIMESTAMP:        BuiltinTimestamp,
+	daml.BuiltinType_CONTRACT_ID:      BuiltinContractID,
+	daml.BuiltinType_OPTIONAL:         BuiltinOptional,
+	daml.BuiltinType_LIST:             BuiltinList,
+	daml.BuiltinType_TEXTMAP:          BuiltinTextMap,
+	daml.BuiltinType_GENMAP:           BuiltinGenMap,
+	daml.BuiltinType_ANY:              BuiltinAny,
+	daml.BuiltinType_ANY_EXCEPTION:    BuiltinAnyException,
+	daml.BuiltinType_TYPE_REP:         BuiltinTypeRep,
+	daml.BuiltinType_ARROW:            BuiltinArrow,
+	daml.BuiltinType_UPDATE:           BuiltinUpdate,
+	daml.BuiltinType_FAILURE_CATEGORY: BuiltinFailureCategory,
+	daml.BuiltinType_BIGNUMERIC:       BuiltinBigNumeric,
+	daml.BuiltinType_ROUNDING_MODE:    BuiltinRoundingMode,
+}
+
+// Type is a DAML-LF type with interned strings and types resolved and type applications
+// flattened into Args, so Numeric 10 is a builtin with a single Nat argument.
+type Type struct {
+	Kind    TypeKind
+	Builtin BuiltinType // TypeBuiltin
+	Con     TypeConName // TypeCon and TypeSyn
+	Var     string      // TypeVar
+	Nat     int64       // TypeNat
+	Args    []*Type
+}
+
+// String renders t in DAML-LF syntax, e.g. Optional (AllKindsOf:MyPair Int64).
+func (t *Type) String() string {
+	if t == nil {
+		return "<nil>"
+	}
+
+	var head string
+	switch t.Kind {
+	case TypeBuiltin:
+		head = t.Builtin.String()
+	case TypeCon, TypeSyn:
+		head = t.Con.QualifiedName()
+	case TypeVar:
+		head = t.Var
+	case TypeNat:
+		return strconv.FormatInt(t.Nat, 10)
+	default:
+		head = "<unsupported>"
+	}
+
+	parts := []string{head}
+	for _, arg := range t.Args {
+		s := arg.String()
+		if len(arg.Args) > 0 {
+			s = "(" + s + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// IsBuiltin reports whether t is the builtin type b.
+func (t *Type) IsBuiltin(b BuiltinType) bool {
+	return t != nil && t.Kind == TypeBuiltin && t.Builtin == b
+}
@@ -38,6 +38,20 @@ type IdentityProviderAdmin struct{}
 
 func (IdentityProviderAdmin) isRightType() {}
 
+type CanReadAsAnyParty struct{}
+
+func (CanReadAsAnyParty) isRightType() {}
+
+type CanExecuteAs struct {
+	Party string
+}
+
+func (CanExecuteAs) isRightType() {}
+
+type CanExecuteAsAnyParty struct{}
+
+func (CanExecuteAsAnyParty) isRightType() {}
+
 type PartyDetails struct {
 	Party              string
 	IsLocal            bool
@@ -73,10 +87,51 @@ const (
 	CommandStateFailed
 )
 
+func (s CommandState) String() string {
+	switch s {
+	case CommandStatePending:
+		return "pending"
+	case CommandStateSucceeded:
+		return "succeeded"
+	case CommandStateFailed:
+		return "failed"
+	default:
+		return "unspecified"
+	}
+}
+
 type CommandStatus struct {
-	Started   *time.Time
-	Completed *time.Time
-	State     CommandState
+	Started           *time.Time
+	Completed         *time.Time
+	State             CommandState
+	CommandID         string
+	UpdateID          string
+	SubmissionID      string
+	Completion        *Completion
+	Error             *StatusError
+	Commands          []*Command
+	RequestStatistics *RequestStatistics
+	Updates           *CommandUpdates
+}
+
+type RequestStatistics struct {
+	Envelopes   uint32
+	RequestSize uint32
+	Recipients  uint32
+}
+
+type CommandUpdates struct {
+	Created       []*CommandContract
+	Archived      []*CommandContract
+	Exercised     uint32
+	Fetched       uint32
+	LookedUpByKey uint32
+}
+
+type CommandContract struct {
+	TemplateID  string
+	ContractID  string
+	ContractKey interface{}
 }
 
 type IdentityProviderConfig struct {
@@ -0,0 +1,256 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The identifier types below are plain strings so they convert to and from protobuf fields
+// without ceremony. A conversion does not check anything: build them with the Parse
+// functions, or call Validate. Before sending a request the services validate the party IDs
+// of commands, completion and package preference requests and user rights, the synchronizer
+// IDs of submissions and external party allocation, the identifiers of topology mappings and
+// stores, and the typed fields of the party hosting, key rotation and watcher requests.
+// Topology Filter fields are prefixes and are not validated.
+
+const (
+	uidDelimiter        = "::"
+	maxFingerprintLen   = 68
+	maxIdentifierLen    = 185
+	participantUIDAlias = "PAR::"
+)
+
+// Fingerprint identifies a public key, usually the hex encoding of its SHA-256 multihash (1220…).
+type Fingerprint string
+
+func ParseFingerprint(s string) (Fingerprint, error) {
+	f := Fingerprint(s)
+	if err := f.Validate(); err != nil {
+		return "", err
+	}
+	return f, nil
+}
+
+func (f Fingerprint) Validate() error {
+	if f == "" {
+		return fmt.Errorf("fingerprint is empty")
+	}
+	if len(f) > maxFingerprintLen {
+		return fmt.Errorf("fingerprint %q is longer than %d characters", string(f), maxFingerprintLen)
+	}
+	for _, r := range f {
+		if !isAlphanumeric(r) {
+			return fmt.Errorf("fingerprint %q contains invalid character %q", string(f), r)
+		}
+	}
+	return nil
+}
+
+func (f Fingerprint) String() string {
+	return string(f)
+}
+
+// Namespace is the fingerprint of the root key that owns a set of identifiers.
+type Namespace string
+
+func ParseNamespace(s string) (Namespace, error) {
+	if _, err := ParseFingerprint(s); err != nil {
+		return "", fmt.Errorf("invalid namespace: %w", err)
+	}
+	return Namespace(s), nil
+}
+
+func (n Namespace) Validate() error {
+	_, err := ParseNamespace(string(n))
+	return err
+}
+
+func (n Namespace) Fingerprint() Fingerprint {
+	return Fingerprint(n)
+}
+
+func (n Namespace) String() string {
+	return string(n)
+}
+
+// SynchronizerID is the unique identifier of a synchronizer, e.g. global::1220….
+type SynchronizerID string
+
+func ParseSynchronizerID(s string) (SynchronizerID, error) {
+	if _, _, err := splitUniqueIdentifier("synchronizer ID", s); err != nil {
+		return "", err
+	}
+	return SynchronizerID(s), nil
+}
+
+func (id SynchronizerID) Validate() error {
+	_, err := ParseSynchronizerID(string(id))
+	return err
+}
+
+func (id SynchronizerID) Identifier() string {
+	identifier, _, _ := splitUniqueIdentifier("synchronizer ID", string(id))
+	return identifier
+}
+
+func (id SynchronizerID) Namespace() Namespace {
+	_, namespace, _ := splitUniqueIdentifier("synchronizer ID", string(id))
+	return namespace
+}
+
+func (id SynchronizerID) String() string {
+	return string(id)
+}
+
+// ParticipantUID is the unique identifier of a participant, e.g. participant1::1220….
+// ParseParticipantUID also accepts the PAR:: prefixed participant ID.
+type ParticipantUID string
+
+func ParseParticipantUID(s string) (ParticipantUID, error) {
+	s = strings.TrimPrefix(s, participantUIDAlias)
+	if _, _, err := splitUniqueIdentifier("participant UID", s); err != nil {
+		return "", err
+	}
+	return ParticipantUID(s), nil
+}
+
+func (id ParticipantUID) Validate() error {
+	if strings.HasPrefix(string(id), participantUIDAlias) {
+		return fmt.Errorf("participant UID %q must not carry the %s prefix", string(id), participantUIDAlias)
+	}
+	_, err := ParseParticipantUID(string(id))
+	return err
+}
+
+func (id ParticipantUID) Identifier() string {
+	identifier, _, _ := splitUniqueIdentifier("participant UID", string(id))
+	return identifier
+}
+
+func (id ParticipantUID) Namespace() Namespace {
+	_, namespace, _ := splitUniqueIdentifier("participant UID", string(id))
+	return namespace
+}
+
+func (id ParticipantUID) String() string {
+	return string(id)
+}
+
+// PartyID is a party's unique identifier, hint::namespace.
+type PartyID string
+
+func ParsePartyID(s string) (PartyID, error) {
+	if _, _, err := splitUniqueIdentifier("party ID", s); err != nil {
+		return "", err
+	}
+	return PartyID(s), nil
+}
+
+func NewPartyID(hint string, namespace Namespace) (PartyID, error) {
+	return ParsePartyID(hint + uidDelimiter + string(namespace))
+}
+
+func (id PartyID) Validate() error {
+	_, err := ParsePartyID(string(id))
+	return err
+}
+
+// ValidatePartyIDs checks that every element of parties is a valid party ID.
+func ValidatePartyIDs(parties []string) error {
+	for _, party := range parties {
+		if err := PartyID(party).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (id PartyID) Hint() string {
+	hint, _, _ := splitUniqueIdentifier("party ID", string(id))
+	return hint
+}
+
+func (id PartyID) Namespace() Namespace {
+	_, namespace, _ := splitUniqueIdentifier("party ID", string(id))
+	return namespace
+}
+
+func (id PartyID) String() string {
+	return string(id)
+}
+
+func AuthorizedStore() *StoreID {
+	return &StoreID{Value: "authorized"}
+}
+
+func SynchronizerStore(id SynchronizerID) *StoreID {
+	return &StoreID{Value: "synchronizer:" + string(id)}
+}
+
+func TemporaryStore(name string) *StoreID {
+	return &StoreID{Value: "temporary:" + name}
+}
+
+// Validate checks that the store is authorized, synchronizer:<synchronizer ID> or temporary:<name>.
+func (s *StoreID) Validate() error {
+	switch {
+	case s.Value == "authorized":
+		return nil
+	case strings.HasPrefix(s.Value, "synchronizer:"):
+		if err := s.SynchronizerID().Validate(); err != nil {
+			return fmt.Errorf("invalid store %q: %w", s.Value, err)
+		}
+		return nil
+	case strings.HasPrefix(s.Value, "temporary:"):
+		if s.TemporaryName() == "" {
+			return fmt.Errorf("invalid store %q: temporary store name is empty", s.Value)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid store %q: expected authorized, synchronizer:<id> or temporary:<name>", s.Value)
+	}
+}
+
+// SynchronizerID returns the synchronizer of a synchronizer store, or "" for other stores.
+func (s *StoreID) SynchronizerID() SynchronizerID {
+	id, ok := strings.CutPrefix(s.Value, "synchronizer:")
+	if !ok {
+		return ""
+	}
+	return SynchronizerID(id)
+}
+
+// TemporaryName returns the name of a temporary store, or "" for other stores.
+func (s *StoreID) TemporaryName() string {
+	name, _ := strings.CutPrefix(s.Value, "temporary:")
+	if name == s.Value {
+		return ""
+	}
+	return name
+}
+
+func splitUniqueIdentifier(kind, s string) (string, Namespace, error) {
+	identifier, namespace, ok := strings.Cut(s, uidDelimiter)
+	if !ok {
+		return "", "", fmt.Errorf("%s %q is not of the form identifier::namespace", kind, s)
+	}
+	if identifier == "" || len(identifier) > maxIdentifierLen {
+		return "", "", fmt.Errorf("%s %q must have an identifier of 1 to %d characters", kind, s, maxIdentifierLen)
+	}
+	for _, r := range identifier {
+		if !isAlphanumeric(r) && r != '-' && r != '_' && r != ':' {
+			return "", "", fmt.Errorf("%s %q contains invalid character %q", kind, s, r)
+		}
+	}
+	if strings.HasSuffix(identifier, ":") {
+		return "", "", fmt.Errorf("%s %q must not end its identifier with ':'", kind, s)
+	}
+	if _, err := ParseNamespace(namespace); err != nil {
+		return "", "", fmt.Errorf("%s %q: %w", kind, s, err)
+	}
+	return identifier, Namespace(namespace), nil
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const testNamespace = "1220b1f2a0e6d1b7c8a9e0f1d2c3b4a5968778695a4b3c2d1e0f1a2b3c4d5e6f7081"
+
+func TestParseIDs(t *testing.T) {
+	party, err := model.ParsePartyID("alice-1::" + testNamespace)
+	require.NoError(t, err)
+	require.Equal(t, "alice-1", party.Hint())
+	require.Equal(t, model.Namespace(testNamespace), party.Namespace())
+
+	built, err := model.NewPartyID("alice-1", model.Namespace(testNamespace))
+	require.NoError(t, err)
+	require.Equal(t, party, built)
+
+	participant, err := model.ParseParticipantUID("PAR::participant1::" + testNamespace)
+	require.NoError(t, err)
+	require.Equal(t, "participant1::"+testNamespace, participant.String())
+	require.Equal(t, "participant1", participant.Identifier())
+
+	sync, err := model.ParseSynchronizerID("global::" + testNamespace)
+	require.NoError(t, err)
+	require.Equal(t, "global", sync.Identifier())
+	require.Equal(t, testNamespace, sync.Namespace().Fingerprint().String())
+}
+
+func TestParseIDs_Invalid(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{"no namespace", parseError(model.ParsePartyID("alice"))},
+		{"empty hint", parseError(model.ParsePartyID("::" + testNamespace))},
+		{"invalid character", parseError(model.ParsePartyID("al ice::" + testNamespace))},
+		{"trailing colon", parseError(model.ParsePartyID("alice:::" + testNamespace))},
+		{"long identifier", parseError(model.ParsePartyID(strings.Repeat("a", 186) + "::" + testNamespace))},
+		{"long fingerprint", parseError(model.ParseFingerprint(strings.Repeat("a", 69)))},
+		{"empty namespace", parseError(model.ParseNamespace(""))},
+		{"participant alias in UID", model.ParticipantUID("PAR::participant1::" + testNamespace).Validate()},
+		{"synchronizer without namespace", parseError(model.ParseSynchronizerID("global"))},
+		{"party list", model.ValidatePartyIDs([]string{"alice::" + testNamespace, "bob"})},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, tt.err)
+		})
+	}
+}
+
+func TestStoreID(t *testing.T) {
+	require.NoError(t, model.AuthorizedStore().Validate())
+	require.NoError(t, model.TemporaryStore("restore").Validate())
+
+	store := model.SynchronizerStore(model.SynchronizerID("global::" + testNamespace))
+	require.NoError(t, store.Validate())
+	require.Equal(t, model.SynchronizerID("global::"+testNamespace), store.SynchronizerID())
+	require.Empty(t, store.TemporaryName())
+
+	require.ErrorContains(t, model.SynchronizerStore("global").Validate(), "invalid store")
+	require.ErrorContains(t, model.TemporaryStore("").Validate(), "temporary store name is empty")
+	require.ErrorContains(t, (&model.StoreID{Value: "global::" + testNamespace}).Validate(), "invalid store")
+}
+
+func parseError[T any](_ T, err error) error {
+	return err
+}
@@ -0,0 +1,192 @@
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+type CryptoKeyFormat int32
+
+const (
+	CryptoKeyFormatUnspecified CryptoKeyFormat = 0
+	CryptoKeyFormatDER         CryptoKeyFormat = 2
+	CryptoKeyFormatRaw         CryptoKeyFormat = 3
+	// CryptoKeyFormatDERX509SubjectPublicKeyInfo is the X.509 SubjectPublicKeyInfo DER encoding.
+	CryptoKeyFormatDERX509SubjectPublicKeyInfo CryptoKeyFormat = 4
+	CryptoKeyFormatSymbolic                    CryptoKeyFormat = 10000
+)
+
+const publicKeyFingerprintPurpose = 12
+
+// NewSigningPublicKey converts an Ed25519 or ECDSA P-256/P-384 key. Raw encoding is only
+// supported for Ed25519; DER and SubjectPublicKeyInfo both store the X.509 encoding.
+// ID is set to the key's Canton fingerprint.
+func NewSigningPublicKey(pub crypto.PublicKey, format CryptoKeyFormat) (PublicKey, error) {
+	key := PublicKey{
+		Format:  int32(format),
+		Purpose: KeyPurposeSigning,
+	}
+
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		key.Scheme = int32(SigningKeySchemeED25519)
+		key.KeySpec = int32(SigningKeySpecCurve25519)
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			key.Scheme = int32(SigningKeySchemeECDSAP256)
+			key.KeySpec = int32(SigningKeySpecP256)
+		case elliptic.P384():
+			key.Scheme = int32(SigningKeySchemeECDSAP384)
+			key.KeySpec = int32(SigningKeySpecP384)
+		default:
+			return PublicKey{}, fmt.Errorf("unsupported ECDSA curve %s", k.Curve.Params().Name)
+		}
+	default:
+		return PublicKey{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	switch format {
+	case CryptoKeyFormatRaw:
+		raw, ok := pub.(ed25519.PublicKey)
+		if !ok {
+			return PublicKey{}, fmt.Errorf("raw key format is only supported for Ed25519 keys")
+		}
+		key.Key = []byte(raw)
+	case CryptoKeyFormatDER, CryptoKeyFormatDERX509SubjectPublicKeyInfo:
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return PublicKey{}, fmt.Errorf("failed to encode public key: %w", err)
+		}
+		key.Key = der
+	default:
+		return PublicKey{}, fmt.Errorf("unsupported key format %d", format)
+	}
+
+	fingerprint, err := key.Fingerprint()
+	if err != nil {
+		return PublicKey{}, err
+	}
+	key.ID = string(fingerprint)
+
+	return key, nil
+}
+
+// ParseSubjectPublicKeyInfo parses a DER encoded X.509 SubjectPublicKeyInfo signing key.
+func ParseSubjectPublicKeyInfo(der []byte) (PublicKey, error) {
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("failed to parse subject public key info: %w", err)
+	}
+	return NewSigningPublicKey(pub, CryptoKeyFormatDERX509SubjectPublicKeyInfo)
+}
+
+// CryptoPublicKey returns the key as ed25519.PublicKey or *ecdsa.PublicKey.
+func (k PublicKey) CryptoPublicKey() (crypto.PublicKey, error) {
+	if k.Purpose != KeyPurposeSigning {
+		return nil, fmt.Errorf("only signing keys can be converted")
+	}
+
+	var pub crypto.PublicKey
+	switch CryptoKeyFormat(k.Format) {
+	case CryptoKeyFormatRaw:
+		if len(k.Key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("raw key of length %d is not an Ed25519 key", len(k.Key))
+		}
+		pub = ed25519.PublicKey(k.Key)
+	case CryptoKeyFormatDER, CryptoKeyFormatDERX509SubjectPublicKeyInfo:
+		parsed, err := x509.ParsePKIXPublicKey(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subject public key info: %w", err)
+		}
+		pub = parsed
+	default:
+		return nil, fmt.Errorf("unsupported key format %d", k.Format)
+	}
+
+	if err := k.checkSpec(pub); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// SubjectPublicKeyInfo returns the DER encoded X.509 SubjectPublicKeyInfo of the key.
+func (k PublicKey) SubjectPublicKeyInfo() ([]byte, error) {
+	pub, err := k.CryptoPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return x509.MarshalPKIXPublicKey(pub)
+}
+
+// Fingerprint computes the Canton fingerprint of the key. Ed25519 keys are hashed in raw
+// form whatever their encoding, ECDSA keys as SubjectPublicKeyInfo, so the same key always
+// yields the same fingerprint.
+func (k PublicKey) Fingerprint() (Fingerprint, error) {
+	pub, err := k.CryptoPublicKey()
+	if err != nil {
+		return "", err
+	}
+
+	if raw, ok := pub.(ed25519.PublicKey); ok {
+		return PublicKeyFingerprint(raw), nil
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return PublicKeyFingerprint(der), nil
+}
+
+// VerifyFingerprint checks that fingerprint, e.g. a key ID reported by a participant, belongs to the key.
+func (k PublicKey) VerifyFingerprint(fingerprint Fingerprint) error {
+	computed, err := k.Fingerprint()
+	if err != nil {
+		return err
+	}
+	if computed != fingerprint {
+		return fmt.Errorf("fingerprint mismatch: key has %s, expected %s", computed, fingerprint)
+	}
+	return nil
+}
+
+// PublicKeyFingerprint hashes encoded key bytes into a fingerprint: the hex SHA-256 multihash
+// of the bytes prefixed with the public key fingerprint hash purpose.
+func PublicKeyFingerprint(key []byte) Fingerprint {
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, uint32(publicKeyFingerprintPurpose))
+	h.Write(key)
+
+	return Fingerprint(hex.EncodeToString(append([]byte{0x12, 0x20}, h.Sum(nil)...)))
+}
+
+func (k PublicKey) checkSpec(pub crypto.PublicKey) error {
+	var spec SigningKeySpec
+	switch p := pub.(type) {
+	case ed25519.PublicKey:
+		spec = SigningKeySpecCurve25519
+	case *ecdsa.PublicKey:
+		switch p.Curve {
+		case elliptic.P256():
+			spec = SigningKeySpecP256
+		case elliptic.P384():
+			spec = SigningKeySpecP384
+		default:
+			return fmt.Errorf("unsupported ECDSA curve %s", p.Curve.Params().Name)
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	if k.KeySpec != int32(SigningKeySpecUnspecified) && SigningKeySpec(k.KeySpec) != spec {
+		return fmt.Errorf("key spec %d does not match the encoded key", k.KeySpec)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package model_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningPublicKey(t *testing.T) {
+	edKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("ed25519 fingerprint ignores encoding", func(t *testing.T) {
+		raw, err := model.NewSigningPublicKey(edKey.Public(), model.CryptoKeyFormatRaw)
+		require.NoError(t, err)
+		spki, err := model.NewSigningPublicKey(edKey.Public(), model.CryptoKeyFormatDERX509SubjectPublicKeyInfo)
+		require.NoError(t, err)
+
+		fingerprint := model.PublicKeyFingerprint(edKey.Public().(ed25519.PublicKey))
+		require.Equal(t, fingerprint.String(), raw.ID)
+		require.Equal(t, raw.ID, spki.ID)
+		require.NoError(t, spki.VerifyFingerprint(fingerprint))
+
+		der, err := raw.SubjectPublicKeyInfo()
+		require.NoError(t, err)
+		require.Equal(t, spki.Key, der)
+	})
+
+	for _, tt := range []struct {
+		name   string
+		key    *ecdsa.PrivateKey
+		scheme model.SigningKeyScheme
+		spec   model.SigningKeySpec
+	}{
+		{"p256", p256, model.SigningKeySchemeECDSAP256, model.SigningKeySpecP256},
+		{"p384", p384, model.SigningKeySchemeECDSAP384, model.SigningKeySpecP384},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := model.NewSigningPublicKey(&tt.key.PublicKey, model.CryptoKeyFormatDERX509SubjectPublicKeyInfo)
+			require.NoError(t, err)
+			require.Equal(t, int32(tt.scheme), key.Scheme)
+			require.Equal(t, int32(tt.spec), key.KeySpec)
+			require.Equal(t, model.PublicKeyFingerprint(key.Key).String(), key.ID)
+
+			parsed, err := model.ParseSubjectPublicKeyInfo(key.Key)
+			require.NoError(t, err)
+			require.Equal(t, key, parsed)
+
+			pub, err := parsed.CryptoPublicKey()
+			require.NoError(t, err)
+			require.True(t, tt.key.PublicKey.Equal(pub))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := model.NewSigningPublicKey(&p256.PublicKey, model.CryptoKeyFormatRaw)
+		require.ErrorContains(t, err, "only supported for Ed25519")
+
+		mismatched, err := model.NewSigningPublicKey(&p256.PublicKey, model.CryptoKeyFormatDER)
+		require.NoError(t, err)
+		mismatched.KeySpec = int32(model.SigningKeySpecP384)
+		_, err = mismatched.CryptoPublicKey()
+		require.ErrorContains(t, err, "does not match")
+
+		other, err := model.NewSigningPublicKey(&p384.PublicKey, model.CryptoKeyFormatDER)
+		require.NoError(t, err)
+		require.ErrorContains(t, other.VerifyFingerprint(model.Fingerprint(mismatched.ID)), "fingerprint mismatch")
+	})
+}
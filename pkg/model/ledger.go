@@ -274,7 +274,7 @@ func (*IncompleteAssignedEntry) isContractEntry() {}
 
 type ActiveContract struct {
 	CreatedEvent        *CreatedEvent
-	SynchronizerID      string
+	SynchronizerID      SynchronizerID
 	ReassignmentCounter uint64
 }
 
@@ -347,7 +347,7 @@ type GetConnectedSynchronizersResponse struct {
 }
 
 type ConnectedSynchronizer struct {
-	SynchronizerID        string
+	SynchronizerID        SynchronizerID
 	ParticipantPermission ParticipantPermission
 }
 
@@ -396,6 +396,7 @@ type Transaction struct {
 	CommandID   string
 	WorkflowID  string
 	EffectiveAt *time.Time
+	RecordTime  *time.Time
 	Events      []*Event
 	Offset      int64
 }
@@ -407,12 +408,14 @@ type Event struct {
 }
 
 type Reassignment struct {
-	UpdateID    string
-	Offset      int64
-	UnassignID  string
-	Source      string
-	Target      string
-	Counter     int64
+	UpdateID   string
+	Offset     int64
+	UnassignID string
+	Source     string
+	Target     string
+	Counter    int64
+	RecordTime *time.Time
+	// Deprecated: SubmittedAt holds the record time; use RecordTime.
 	SubmittedAt *time.Time
 	Unassigned  *time.Time
 	Reassigned  *time.Time
@@ -466,7 +469,7 @@ type PrepareSubmissionRequest struct {
 	ActAs                        []string
 	ReadAs                       []string
 	DisclosedContracts           []*DisclosedContract
-	SynchronizerID               string
+	SynchronizerID               SynchronizerID
 	PackageIDSelectionPreference []string
 	VerboseHashing               bool
 	PrefetchContractKeys         []*PrefetchContractKey
@@ -496,7 +499,7 @@ type DisclosedContract struct {
 	TemplateID       string
 	ContractID       string
 	CreatedEventBlob []byte
-	SynchronizerID   string
+	SynchronizerID   SynchronizerID
 }
 
 type PrefetchContractKey struct {
@@ -569,13 +572,13 @@ type ExecuteSubmissionResponse struct{}
 type GetPreferredPackageVersionRequest struct {
 	Parties        []string
 	PackageName    string
-	SynchronizerID string
+	SynchronizerID SynchronizerID
 	VettingValidAt *time.Time
 }
 
 type GetPreferredPackageVersionResponse struct {
 	PackageReference *PackageReference
-	SynchronizerID   string
+	SynchronizerID   SynchronizerID
 }
 
 type PackageReference struct {
@@ -25,6 +25,10 @@ type AddTransactionsRequest struct {
 
 type AddTransactionsResponse struct{}
 
+// The Filter fields of the List requests are matched by the participant as string prefixes,
+// so they hold partial identifiers and are sent without validation. An empty filter matches
+// everything.
+
 type ListNamespaceDelegationRequest struct {
 	BaseQuery                  *BaseQuery
 	FilterNamespace            string
@@ -67,9 +71,14 @@ type StoreID struct {
 	Value string
 }
 
+// TimeQuery selects which topology state a query reads. At most one field may be set.
 type TimeQuery struct {
 	Serial *int64
 	Range  *TimeRange
+	// Snapshot selects the state valid at the given time.
+	Snapshot *time.Time
+	// HeadState selects the current state of the store.
+	HeadState bool
 }
 
 type TimeRange struct {
@@ -106,9 +115,10 @@ type MultiTransactionSignatures struct {
 }
 
 type TopologyTransactionSignature struct {
-	SignedBy        string
-	Signature       []byte
-	SignatureFormat int32
+	SignedBy             string
+	Signature            []byte
+	SignatureFormat      int32
+	SigningAlgorithmSpec SigningAlgorithmSpec
 }
 
 type TopologyTransactionProposal struct {
@@ -122,15 +132,45 @@ type TopologyMapping interface {
 }
 
 type NamespaceDelegationMapping struct {
-	Namespace        string
+	Namespace        Namespace
 	TargetKey        PublicKey
 	IsRootDelegation bool
+	Restriction      *DelegationRestriction
+}
+
+// DelegationRestriction limits which mappings a delegated key may sign. Exactly one field must be set;
+// leave NamespaceDelegationMapping.Restriction nil for no restriction.
+type DelegationRestriction struct {
+	CanSignAllMappings                bool
+	CanSignAllButNamespaceDelegations bool
+	CanSignSpecificMappings           []TopologyMappingCode
 }
 
+type TopologyMappingCode int32
+
+const (
+	TopologyMappingCodeUnspecified                      TopologyMappingCode = 0
+	TopologyMappingCodeNamespaceDelegation              TopologyMappingCode = 1
+	TopologyMappingCodeDecentralizedNamespaceDefinition TopologyMappingCode = 3
+	TopologyMappingCodeOwnerToKeyMapping                TopologyMappingCode = 4
+	TopologyMappingCodeSynchronizerTrustCertificate     TopologyMappingCode = 5
+	TopologyMappingCodeParticipantPermission            TopologyMappingCode = 6
+	TopologyMappingCodePartyHostingLimits               TopologyMappingCode = 7
+	TopologyMappingCodeVettedPackages                   TopologyMappingCode = 8
+	TopologyMappingCodePartyToParticipant               TopologyMappingCode = 9
+	TopologyMappingCodeSynchronizerParametersState      TopologyMappingCode = 11
+	TopologyMappingCodeMediatorSynchronizerState        TopologyMappingCode = 12
+	TopologyMappingCodeSequencerSynchronizerState       TopologyMappingCode = 13
+	TopologyMappingCodeSequencingDynamicParametersState TopologyMappingCode = 17
+	TopologyMappingCodePartyToKeyMapping                TopologyMappingCode = 18
+	TopologyMappingCodeSynchronizerUpgradeAnnouncement  TopologyMappingCode = 19
+	TopologyMappingCodeSequencerConnectionSuccessor     TopologyMappingCode = 20
+)
+
 func (*NamespaceDelegationMapping) isTopologyMapping() {}
 
 type PartyToKeyMapping struct {
-	Party       string
+	Party       PartyID
 	Threshold   uint32
 	SigningKeys []PublicKey
 }
@@ -174,6 +214,7 @@ type PublicKey struct {
 	Scheme  int32
 	KeySpec int32
 	Usage   []int32
+	Purpose KeyPurpose
 }
 
 type TopologyTransactionResult struct {
@@ -206,16 +247,23 @@ type PartyToParticipantResult struct {
 }
 
 type PartyToParticipantMapping struct {
-	Party        string
-	Threshold    uint32
-	Participants []HostingParticipant
+	Party            PartyID
+	Threshold        uint32
+	Participants     []HostingParticipant
+	PartySigningKeys *SigningKeysWithThreshold
 }
 
 func (*PartyToParticipantMapping) isTopologyMapping() {}
 
 type HostingParticipant struct {
-	ParticipantUID string
+	ParticipantUID ParticipantUID
 	Permission     ParticipantPermission
+	Onboarding     bool
+}
+
+type SigningKeysWithThreshold struct {
+	Keys      []PublicKey
+	Threshold uint32
 }
 
 type BaseResult struct {
@@ -279,6 +327,12 @@ type DropTemporaryTopologyStoreRequest struct {
 
 type DropTemporaryTopologyStoreResponse struct{}
 
+type ExportTopologySnapshotRequest struct {
+	BaseQuery       *BaseQuery
+	ExcludeMappings []string
+	FilterNamespace string
+}
+
 type ImportTopologySnapshotRequest struct {
 	TopologySnapshot      []byte
 	Store                 *StoreID
@@ -286,3 +340,295 @@ type ImportTopologySnapshotRequest struct {
 }
 
 type ImportTopologySnapshotResponse struct{}
+
+type KeyPurpose int32
+
+const (
+	KeyPurposeSigning    KeyPurpose = 0
+	KeyPurposeEncryption KeyPurpose = 1
+)
+
+type ParticipantFeatureFlag int32
+
+const (
+	ParticipantFeatureFlagUnspecified                               ParticipantFeatureFlag = 0
+	ParticipantFeatureFlagPV33ExternalSigningLocalContractInSubview ParticipantFeatureFlag = 1
+)
+
+type OnboardingRestriction int32
+
+const (
+	OnboardingRestrictionUnspecified        OnboardingRestriction = 0
+	OnboardingRestrictionUnrestrictedOpen   OnboardingRestriction = 1
+	OnboardingRestrictionUnrestrictedLocked OnboardingRestriction = 2
+	OnboardingRestrictionRestrictedOpen     OnboardingRestriction = 3
+	OnboardingRestrictionRestrictedLocked   OnboardingRestriction = 4
+)
+
+type OwnerToKeyMapping struct {
+	Member     string
+	PublicKeys []PublicKey
+}
+
+func (*OwnerToKeyMapping) isTopologyMapping() {}
+
+type DecentralizedNamespaceDefinition struct {
+	Namespace Namespace
+	Threshold int32
+	Owners    []string
+}
+
+func (*DecentralizedNamespaceDefinition) isTopologyMapping() {}
+
+type SynchronizerTrustCertificate struct {
+	ParticipantUID ParticipantUID
+	SynchronizerID SynchronizerID
+	FeatureFlags   []ParticipantFeatureFlag
+}
+
+func (*SynchronizerTrustCertificate) isTopologyMapping() {}
+
+type ParticipantSynchronizerPermission struct {
+	SynchronizerID SynchronizerID
+	ParticipantUID ParticipantUID
+	Permission     ParticipantPermission
+	Limits         *ParticipantSynchronizerLimits
+	LoginAfter     *int64
+}
+
+func (*ParticipantSynchronizerPermission) isTopologyMapping() {}
+
+type ParticipantSynchronizerLimits struct {
+	ConfirmationRequestsMaxRate uint32
+}
+
+type SynchronizerParametersState struct {
+	SynchronizerID SynchronizerID
+	Parameters     *DynamicSynchronizerParameters
+}
+
+func (*SynchronizerParametersState) isTopologyMapping() {}
+
+type DynamicSynchronizerParameters struct {
+	ConfirmationResponseTimeout         time.Duration
+	MediatorReactionTimeout             time.Duration
+	AssignmentExclusivityTimeout        time.Duration
+	LedgerTimeRecordTimeTolerance       time.Duration
+	ReconciliationInterval              time.Duration
+	MediatorDeduplicationTimeout        time.Duration
+	MaxRequestSize                      uint32
+	OnboardingRestriction               OnboardingRestriction
+	ParticipantSynchronizerLimits       *ParticipantSynchronizerLimits
+	SequencerAggregateSubmissionTimeout time.Duration
+	TrafficControl                      *TrafficControlParameters
+	AcsCommitmentsCatchup               *AcsCommitmentsCatchUpConfig
+	PreparationTimeRecordTimeTolerance  time.Duration
+}
+
+type TrafficControlParameters struct {
+	MaxBaseTrafficAmount                  uint64
+	MaxBaseTrafficAccumulationDuration    time.Duration
+	ReadVsWriteScalingFactor              uint32
+	SetBalanceRequestSubmissionWindowSize time.Duration
+	EnforceRateLimiting                   bool
+	BaseEventCost                         *uint64
+}
+
+type AcsCommitmentsCatchUpConfig struct {
+	CatchupIntervalSkip         uint32
+	NrIntervalsToTriggerCatchup uint32
+}
+
+type MediatorSynchronizerState struct {
+	SynchronizerID SynchronizerID
+	Group          uint32
+	Threshold      uint32
+	Active         []string
+	Observers      []string
+}
+
+func (*MediatorSynchronizerState) isTopologyMapping() {}
+
+type SequencerSynchronizerState struct {
+	SynchronizerID SynchronizerID
+	Threshold      uint32
+	Active         []string
+	Observers      []string
+}
+
+func (*SequencerSynchronizerState) isTopologyMapping() {}
+
+type VettedPackages struct {
+	ParticipantUID ParticipantUID
+	Packages       []VettedPackage
+}
+
+func (*VettedPackages) isTopologyMapping() {}
+
+type PartyHostingLimits struct {
+	SynchronizerID SynchronizerID
+	Party          PartyID
+}
+
+func (*PartyHostingLimits) isTopologyMapping() {}
+
+type DynamicSequencingParametersState struct {
+	SynchronizerID SynchronizerID
+	Payload        []byte
+}
+
+func (*DynamicSequencingParametersState) isTopologyMapping() {}
+
+type SynchronizerUpgradeAnnouncement struct {
+	SuccessorPhysicalSynchronizerID string
+	UpgradeTime                     *time.Time
+}
+
+func (*SynchronizerUpgradeAnnouncement) isTopologyMapping() {}
+
+type SequencerConnectionSuccessor struct {
+	SequencerID             string
+	SynchronizerID          SynchronizerID
+	Endpoints               []string
+	CustomTrustCertificates []byte
+}
+
+func (*SequencerConnectionSuccessor) isTopologyMapping() {}
+
+type VettedPackage struct {
+	PackageID  string
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+}
+
+type ListOwnerToKeyMappingRequest struct {
+	BaseQuery          *BaseQuery
+	FilterKeyOwnerType string
+	FilterKeyOwnerUID  string
+}
+
+type ListOwnerToKeyMappingResponse struct {
+	Results []*OwnerToKeyMappingResult
+}
+
+type OwnerToKeyMappingResult struct {
+	Context *BaseResult
+	Item    *OwnerToKeyMapping
+}
+
+type ListDecentralizedNamespaceDefinitionRequest struct {
+	BaseQuery       *BaseQuery
+	FilterNamespace string
+}
+
+type ListDecentralizedNamespaceDefinitionResponse struct {
+	Results []*DecentralizedNamespaceDefinitionResult
+}
+
+type DecentralizedNamespaceDefinitionResult struct {
+	Context *BaseResult
+	Item    *DecentralizedNamespaceDefinition
+}
+
+type ListSynchronizerTrustCertificateRequest struct {
+	BaseQuery *BaseQuery
+	FilterUID string
+}
+
+type ListSynchronizerTrustCertificateResponse struct {
+	Results []*SynchronizerTrustCertificateResult
+}
+
+type SynchronizerTrustCertificateResult struct {
+	Context *BaseResult
+	Item    *SynchronizerTrustCertificate
+}
+
+type ListParticipantSynchronizerPermissionRequest struct {
+	BaseQuery *BaseQuery
+	FilterUID string
+}
+
+type ListParticipantSynchronizerPermissionResponse struct {
+	Results []*ParticipantSynchronizerPermissionResult
+}
+
+type ParticipantSynchronizerPermissionResult struct {
+	Context *BaseResult
+	Item    *ParticipantSynchronizerPermission
+}
+
+type ListSynchronizerParametersStateRequest struct {
+	BaseQuery            *BaseQuery
+	FilterSynchronizerID string
+}
+
+type ListSynchronizerParametersStateResponse struct {
+	Results []*SynchronizerParametersStateResult
+}
+
+type SynchronizerParametersStateResult struct {
+	Context *BaseResult
+	Item    *SynchronizerParametersState
+}
+
+type ListMediatorSynchronizerStateRequest struct {
+	BaseQuery            *BaseQuery
+	FilterSynchronizerID string
+}
+
+type ListMediatorSynchronizerStateResponse struct {
+	Results []*MediatorSynchronizerStateResult
+}
+
+type MediatorSynchronizerStateResult struct {
+	Context *BaseResult
+	Item    *MediatorSynchronizerState
+}
+
+type ListSequencerSynchronizerStateRequest struct {
+	BaseQuery            *BaseQuery
+	FilterSynchronizerID string
+}
+
+type ListSequencerSynchronizerStateResponse struct {
+	Results []*SequencerSynchronizerStateResult
+}
+
+type SequencerSynchronizerStateResult struct {
+	Context *BaseResult
+	Item    *SequencerSynchronizerState
+}
+
+type ListVettedPackagesRequest struct {
+	BaseQuery         *BaseQuery
+	FilterParticipant string
+}
+
+type ListVettedPackagesResponse struct {
+	Results []*VettedPackagesResult
+}
+
+type VettedPackagesResult struct {
+	Context *BaseResult
+	Item    *VettedPackages
+}
+
+type ListAllRequest struct {
+	BaseQuery       *BaseQuery
+	ExcludeMappings []string
+	FilterNamespace string
+}
+
+type ListAllResponse struct {
+	Items []*TopologyTransactionItem
+}
+
+// TopologyTransactionItem is a serialized signed topology transaction with its validity window.
+type TopologyTransactionItem struct {
+	Sequenced       *time.Time
+	ValidFrom       *time.Time
+	ValidUntil      *time.Time
+	Transaction     []byte
+	RejectionReason *string
+}
@@ -0,0 +1,350 @@
+package printer
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
+	"github.com/noders-team/go-daml/pkg/lf"
+	"github.com/noders-team/go-daml/pkg/service/ledger"
+	"github.com/noders-team/go-daml/pkg/types"
+)
+
+// Redacted replaces the values a Printer is configured to hide.
+const Redacted = "<redacted>"
+
+// Printer renders DAML values in DAML-like syntax, such as
+// MyPair {left = Some 1, right = None}. With a registry it names unlabelled record fields,
+// records and variants from their data types and prints Numerics at their declared scale.
+type Printer struct {
+	registry      *lf.Registry
+	indent        string
+	redactParties bool
+	redactFields  map[string]bool
+}
+
+type Option func(*Printer)
+
+// WithRegistry supplies the packages whose data types describe the printed values.
+func WithRegistry(registry *lf.Registry) Option {
+	return func(p *Printer) {
+		p.registry = registry
+	}
+}
+
+// WithIndent prints records and nested containers over several lines, indented by indent.
+func WithIndent(indent string) Option {
+	return func(p *Printer) {
+		p.indent = indent
+	}
+}
+
+// WithRedactedParties replaces every party ID with Redacted.
+func WithRedactedParties() Option {
+	return func(p *Printer) {
+		p.redactParties = true
+	}
+}
+
+// WithRedactedFields replaces the values of record fields with the given names with Redacted.
+func WithRedactedFields(names ...string) Option {
+	return func(p *Printer) {
+		for _, name := range names {
+			p.redactFields[name] = true
+		}
+	}
+}
+
+// NewPrinter returns a printer that prints on a single line unless WithIndent is given.
+func NewPrinter(opts ...Option) *Printer {
+	p := &Printer{redactFields: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Sprint formats a *v2.Value, a *v2.Record or any value the ledger converters accept.
+// Records, variants and enums carrying an ID of a registered package are printed by their
+// data types.
+func (p *Printer) Sprint(value interface{}) string {
+	return p.format(toValue(value), nil, 0)
+}
+
+// SprintType formats value as a value of typ.
+func (p *Printer) SprintType(value interface{}, typ *lf.Type) string {
+	return p.format(toValue(value), &lf.TypeRef{Type: typ}, 0)
+}
+
+// SprintCreateArguments formats the create arguments of a template, such as
+// CreatedEvent.CreateArguments. Unknown templates are printed like Sprint.
+func (p *Printer) SprintCreateArguments(templateID string, args interface{}) string {
+	value := recordValue(args)
+	if p.registry == nil {
+		return p.format(value, nil, 0)
+	}
+	template, err := p.registry.Template(templateID)
+	if err != nil {
+		return p.format(value, nil, 0)
+	}
+	return p.format(value, &lf.TypeRef{Type: &lf.Type{Kind: lf.TypeCon, Con: template.ID}}, 0)
+}
+
+// SprintChoiceArgument formats the argument of a choice exercised through templateID.
+// Unknown choices are printed like Sprint.
+func (p *Printer) SprintChoiceArgument(templateID, choice string, arg interface{}) string {
+	if p.registry == nil {
+		return p.Sprint(arg)
+	}
+	def, err := p.registry.Choice(templateID, choice)
+	if err != nil {
+		return p.Sprint(arg)
+	}
+	return p.SprintType(arg, def.ArgType)
+}
+
+// toValue converts value like the ledger converters; values they reject print as unsupported.
+func toValue(value interface{}) *v2.Value {
+	switch v := value.(type) {
+	case *v2.Value:
+		return v
+	case *v2.Record:
+		return &v2.Value{Sum: &v2.Value_Record{Record: v}}
+	}
+	converted, _ := ledger.MapToValue(value)
+	return converted
+}
+
+// recordValue converts create arguments the way the command service does.
+func recordValue(args interface{}) *v2.Value {
+	switch args.(type) {
+	case *v2.Value, *v2.Record:
+		return toValue(args)
+	}
+	if record, err := ledger.ConvertToRecord(args); err == nil && record != nil {
+		return &v2.Value{Sum: &v2.Value_Record{Record: record}}
+	}
+	return toValue(args)
+}
+
+// resolve returns the data type or builtin t stands for, or nil when it is unknown.
+func (p *Printer) resolve(t *lf.TypeRef) (*lf.Type, []lf.TypeRef) {
+	if t == nil || p.registry == nil {
+		return nil, nil
+	}
+	typ, args, ok := p.registry.Resolve(*t)
+	if !ok {
+		return nil, nil
+	}
+	return typ, args
+}
+
+// dataType returns the data type of a record, variant or enum, taken from typ or else
+// from the identifier carried by the value.
+func (p *Printer) dataType(typ *lf.Type, id *v2.Identifier) *lf.DataType {
+	if p.registry == nil {
+		return nil
+	}
+	name := lf.TypeConName{PackageID: id.GetPackageId(), Module: id.GetModuleName(), Name: id.GetEntityName()}
+	if typ != nil && typ.Kind == lf.TypeCon {
+		name = typ.Con
+	}
+	dataType, _ := p.registry.DataType(name)
+	return dataType
+}
+
+func (p *Printer) format(value *v2.Value, t *lf.TypeRef, depth int) string {
+	typ, args := p.resolve(t)
+	arg := func(i int) *lf.TypeRef {
+		if i >= len(args) {
+			return nil
+		}
+		return &args[i]
+	}
+
+	switch v := value.GetSum().(type) {
+	case *v2.Value_Unit:
+		return "()"
+	case *v2.Value_Bool:
+		if v.Bool {
+			return "True"
+		}
+		return "False"
+	case *v2.Value_Int64:
+		return strconv.FormatInt(v.Int64, 10)
+	case *v2.Value_Text:
+		return strconv.Quote(v.Text)
+	case *v2.Value_Numeric:
+		return p.numeric(v.Numeric, typ, args)
+	case *v2.Value_Party:
+		if p.redactParties {
+			return Redacted
+		}
+		return "'" + v.Party + "'"
+	case *v2.Value_ContractId:
+		return v.ContractId
+	case *v2.Value_Date:
+		return time.Unix(int64(v.Date)*24*60*60, 0).UTC().Format(time.DateOnly)
+	case *v2.Value_Timestamp:
+		return time.UnixMicro(v.Timestamp).UTC().Format(time.RFC3339Nano)
+	case *v2.Value_Optional:
+		if v.Optional.GetValue() == nil {
+			return "None"
+		}
+		return "Some " + parens(p.format(v.Optional.Value, arg(0), depth))
+	case *v2.Value_List:
+		items := make([]string, len(v.List.GetElements()))
+		for i, elem := range v.List.GetElements() {
+			items[i] = p.format(elem, arg(0), depth+1)
+		}
+		return p.block("[", "]", items, anyCompound(v.List.GetElements()...), depth)
+	case *v2.Value_TextMap:
+		entries := v.TextMap.GetEntries()
+		items := make([]string, len(entries))
+		values := make([]*v2.Value, len(entries))
+		for i, entry := range entries {
+			items[i] = "(" + strconv.Quote(entry.Key) + ", " + p.format(entry.Value, arg(0), depth+1) + ")"
+			values[i] = entry.Value
+		}
+		return "TextMap.fromList " + p.block("[", "]", items, anyCompound(values...), depth)
+	case *v2.Value_GenMap:
+		entries := v.GenMap.GetEntries()
+		items := make([]string, len(entries))
+		values := make([]*v2.Value, 0, 2*len(entries))
+		for i, entry := range entries {
+			items[i] = "(" + p.format(entry.Key, arg(0), depth+1) + ", " + p.format(entry.Value, arg(1), depth+1) + ")"
+			values = append(values, entry.Key, entry.Value)
+		}
+		return "Map.fromList " + p.block("[", "]", items, anyCompound(values...), depth)
+	case *v2.Value_Record:
+		return p.record(v.Record, p.dataType(typ, v.Record.GetRecordId()), args, depth)
+	case *v2.Value_Variant:
+		return p.variant(v.Variant, p.dataType(typ, v.Variant.GetVariantId()), args, depth)
+	case *v2.Value_Enum:
+		return v.Enum.GetConstructor()
+	default:
+		return "<unsupported value>"
+	}
+}
+
+// numeric prints a Numeric at the scale of its type when known, and as sent otherwise.
+func (p *Printer) numeric(literal string, typ *lf.Type, args []lf.TypeRef) string {
+	n, err := types.ParseNumeric(literal)
+	if err != nil {
+		return literal
+	}
+	if typ.IsBuiltin(lf.BuiltinNumeric) {
+		if scale, ok := p.registry.NumericScale(args); ok {
+			if scaled, err := n.Rescale(int32(scale)); err == nil {
+				return scaled.String()
+			}
+		}
+	}
+	return n.String()
+}
+
+func (p *Printer) record(record *v2.Record, dataType *lf.DataType, args []lf.TypeRef, depth int) string {
+	var vars map[string]lf.TypeRef
+	name := record.GetRecordId().GetEntityName()
+	if dataType != nil && dataType.Kind == lf.DataRecord {
+		vars = lf.BindParams(dataType.Params, args)
+		name = dataType.ID.Name
+	} else {
+		dataType = nil
+	}
+
+	items := make([]string, len(record.GetFields()))
+	for i, field := range record.GetFields() {
+		label := field.Label
+		var fieldType *lf.TypeRef
+		if dataType != nil {
+			def := dataType.Field(label)
+			if label == "" && i < len(dataType.Fields) {
+				def = dataType.Fields[i]
+			}
+			if def != nil {
+				label = def.Name
+				fieldType = &lf.TypeRef{Type: def.Type, Vars: vars}
+			}
+		}
+
+		value := Redacted
+		if !p.redactFields[label] {
+			value = p.format(field.Value, fieldType, depth+1)
+		}
+		if label != "" {
+			value = label + " = " + value
+		}
+		items[i] = value
+	}
+
+	body := p.block("{", "}", items, len(items) > 0, depth)
+	if name == "" {
+		return body
+	}
+	return name + " " + body
+}
+
+func (p *Printer) variant(variant *v2.Variant, dataType *lf.DataType, args []lf.TypeRef, depth int) string {
+	constructor := variant.GetConstructor()
+	if _, ok := variant.GetValue().GetSum().(*v2.Value_Unit); ok || variant.GetValue() == nil {
+		return constructor
+	}
+
+	var payloadType *lf.TypeRef
+	if dataType != nil && dataType.Kind == lf.DataVariant {
+		if field := dataType.Field(constructor); field != nil {
+			payloadType = &lf.TypeRef{Type: field.Type, Vars: lf.BindParams(dataType.Params, args)}
+		}
+	}
+	return constructor + " " + parens(p.format(variant.Value, payloadType, depth))
+}
+
+// block joins items between open and close, one per line when indenting and multiLine
+// is set.
+func (p *Printer) block(open, close string, items []string, multiLine bool, depth int) string {
+	if p.indent == "" || !multiLine || len(items) == 0 {
+		return open + strings.Join(items, ", ") + close
+	}
+	inner := strings.Repeat(p.indent, depth+1)
+	return open + "\n" + inner + strings.Join(items, ",\n"+inner) + "\n" + strings.Repeat(p.indent, depth) + close
+}
+
+// anyCompound reports whether any of values is printed as a record or a non-empty container.
+func anyCompound(values ...*v2.Value) bool {
+	for _, value := range values {
+		if compound(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compound(value *v2.Value) bool {
+	switch v := value.GetSum().(type) {
+	case *v2.Value_Record:
+		return len(v.Record.GetFields()) > 0
+	case *v2.Value_List:
+		return len(v.List.GetElements()) > 0
+	case *v2.Value_TextMap:
+		return len(v.TextMap.GetEntries()) > 0
+	case *v2.Value_GenMap:
+		return len(v.GenMap.GetEntries()) > 0
+	case *v2.Value_Optional:
+		return compound(v.Optional.GetValue())
+	case *v2.Value_Variant:
+		return compound(v.Variant.GetValue())
+	default:
+		return false
+	}
+}
+
+// parens wraps an argument of Some or a variant constructor when it is an application
+// or a negative number.
+func parens(s string) string {
+	if strings.HasPrefix(s, "-") || (strings.Contains(s, " ") && !strings.ContainsAny(s[:1], `[{("'`)) {
+		return "(" + s + ")"
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package printer
+
+import (
+	"os"
+	"testing"
+
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
+	"github.com/noders-team/go-daml/pkg/lf"
+	"github.com/noders-team/go-daml/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	allKindsDar = "../../test-data/all-kinds-of-1.0.0.dar"
+	oneOfAll    = "#all-kinds-of:AllKindsOf:OneOfEverything"
+)
+
+func loadRegistry(t *testing.T) *lf.Registry {
+	dar, err := os.ReadFile(allKindsDar)
+	require.NoError(t, err)
+
+	registry := lf.NewRegistry()
+	_, err = registry.AddDar(dar)
+	require.NoError(t, err)
+	return registry
+}
+
+func int64Value(i int64) *v2.Value {
+	return &v2.Value{Sum: &v2.Value_Int64{Int64: i}}
+}
+
+func tuple(fields ...*v2.Value) *v2.Value {
+	record := &v2.Record{}
+	for _, field := range fields {
+		record.Fields = append(record.Fields, &v2.RecordField{Value: field})
+	}
+	return &v2.Value{Sum: &v2.Value_Record{Record: record}}
+}
+
+func variantValue(constructor string, value *v2.Value) *v2.Value {
+	return &v2.Value{Sum: &v2.Value_Variant{Variant: &v2.Variant{Constructor: constructor, Value: value}}}
+}
+
+// oneOfEverything returns unlabelled create arguments, as in a non-verbose transaction.
+func oneOfEverything() *v2.Record {
+	pair := tuple(int64Value(1), int64Value(2))
+	return tuple(
+		&v2.Value{Sum: &v2.Value_Party{Party: "Alice::1220aa"}},
+		&v2.Value{Sum: &v2.Value_Bool{Bool: true}},
+		int64Value(-190),
+		&v2.Value{Sum: &v2.Value_Numeric{Numeric: "1.5"}},
+		&v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{Value: int64Value(42)}}},
+		&v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{}}},
+		&v2.Value{Sum: &v2.Value_Text{Text: "some \"text\""}},
+		&v2.Value{Sum: &v2.Value_Date{Date: 19844}},
+		&v2.Value{Sum: &v2.Value_Timestamp{Timestamp: 1714564800123456}},
+		&v2.Value{Sum: &v2.Value_List{List: &v2.List{Elements: []*v2.Value{int64Value(1), int64Value(2)}}}},
+		pair,
+		tuple(pair, pair),
+		variantValue("Both", variantValue("Left", tuple(pair, pair))),
+		&v2.Value{Sum: &v2.Value_Numeric{Numeric: "0.0000000001"}},
+		&v2.Value{Sum: &v2.Value_Enum{Enum: &v2.Enum{Constructor: "Green"}}},
+		&v2.Value{Sum: &v2.Value_Unit{Unit: nil}},
+	).GetRecord()
+}
+
+func TestSprint(t *testing.T) {
+	p := NewPrinter()
+	require.Equal(t, "{1, Some (-2), None, [True, False], ()}", p.Sprint(tuple(
+		int64Value(1),
+		&v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{Value: int64Value(-2)}}},
+		&v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{}}},
+		&v2.Value{Sum: &v2.Value_List{List: &v2.List{Elements: []*v2.Value{
+			{Sum: &v2.Value_Bool{Bool: true}},
+			{Sum: &v2.Value_Bool{Bool: false}},
+		}}}},
+		&v2.Value{Sum: &v2.Value_Unit{}},
+	)))
+
+	genMap := &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: []*v2.GenMap_Entry{
+		{Key: variantValue("Left", int64Value(1)), Value: &v2.Value{Sum: &v2.Value_Text{Text: "a"}}},
+		{Key: variantValue("None", nil), Value: &v2.Value{Sum: &v2.Value_Text{Text: "b"}}},
+	}}}}
+	require.Equal(t, `Map.fromList [(Left 1, "a"), (None, "b")]`, p.Sprint(genMap))
+
+	require.Equal(t, `{owner = 'Bob'}`, p.Sprint(map[string]interface{}{"owner": types.PARTY("Bob")}))
+}
+
+func TestSprintCreateArguments(t *testing.T) {
+	p := NewPrinter(WithRegistry(loadRegistry(t)))
+	require.Equal(t,
+		`OneOfEverything {operator = 'Alice::1220aa', someBoolean = True, someInteger = -190, someDecimal = 1.5000000000, `+
+			`someMaybe = Some 42, someMaybeNot = None, someText = "some \"text\"", someDate = 2024-05-01, `+
+			`someDatetime = 2024-05-01T12:00:00.123456Z, someSimpleList = [1, 2], someSimplePair = MyPair {left = 1, right = 2}, `+
+			`someNestedPair = MyPair {left = MyPair {left = 1, right = 2}, right = MyPair {left = 1, right = 2}}, `+
+			`someUglyNesting = Both (Left (MyPair {left = MyPair {left = 1, right = 2}, right = MyPair {left = 1, right = 2}})), `+
+			`someMeasurement = 0.0000000001, someEnum = Green, theUnit = ()}`,
+		p.SprintCreateArguments(oneOfAll, oneOfEverything()))
+
+	require.Equal(t, "{operator = 'Alice::1220aa'}",
+		NewPrinter().SprintCreateArguments(oneOfAll, map[string]interface{}{"operator": types.PARTY("Alice::1220aa")}))
+}
+
+func TestSprintIndented(t *testing.T) {
+	registry := loadRegistry(t)
+	p := NewPrinter(WithRegistry(registry), WithIndent("  "), WithRedactedParties(), WithRedactedFields("someText"))
+	out := p.SprintCreateArguments(oneOfAll, oneOfEverything())
+	require.Contains(t, out, "OneOfEverything {\n  operator = <redacted>,\n  someBoolean = True,\n")
+	require.Contains(t, out, "  someText = <redacted>,\n")
+	require.Contains(t, out, "  someSimpleList = [1, 2],\n  someSimplePair = MyPair {\n    left = 1,\n    right = 2\n  },\n")
+	require.Contains(t, out, "  someUglyNesting = Both (Left (MyPair {\n    left = MyPair {\n      left = 1,\n")
+	require.True(t, len(out) > 0 && out[len(out)-1] == '}')
+
+	arg := p.SprintChoiceArgument(oneOfAll, "Accept", tuple())
+	require.Equal(t, "Accept {}", arg)
+}
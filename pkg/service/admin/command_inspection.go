@@ -5,8 +5,10 @@ import (
 
 	"google.golang.org/grpc"
 
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
 	adminv2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2/admin"
 	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/ledger"
 )
 
 type CommandInspection interface {
@@ -71,7 +73,9 @@ func commandStatusFromProto(pb *adminv2.CommandStatus) *model.CommandStatus {
 	}
 
 	cs := &model.CommandStatus{
-		State: commandStateFromProto(pb.State),
+		State:    commandStateFromProto(pb.State),
+		Commands: commandsFromProto(pb.Commands),
+		Updates:  commandUpdatesFromProto(pb.Updates),
 	}
 
 	if pb.Started != nil {
@@ -84,9 +88,116 @@ func commandStatusFromProto(pb *adminv2.CommandStatus) *model.CommandStatus {
 		cs.Completed = &t
 	}
 
+	if pb.Completion != nil {
+		completion := ledger.CompletionFromProto(pb.Completion)
+		cs.Completion = &completion
+		cs.CommandID = completion.CommandID
+		cs.UpdateID = completion.UpdateID
+		cs.SubmissionID = completion.SubmissionID
+		if statusErr, ok := completion.Status.(model.StatusError); ok {
+			cs.Error = &statusErr
+		}
+	}
+
+	if pb.RequestStatistics != nil {
+		cs.RequestStatistics = &model.RequestStatistics{
+			Envelopes:   pb.RequestStatistics.Envelopes,
+			RequestSize: pb.RequestStatistics.RequestSize,
+			Recipients:  pb.RequestStatistics.Recipients,
+		}
+	}
+
 	return cs
 }
 
+func commandsFromProto(pbs []*v2.Command) []*model.Command {
+	if len(pbs) == 0 {
+		return nil
+	}
+
+	result := make([]*model.Command, 0, len(pbs))
+	for _, pb := range pbs {
+		if cmd := commandFromProto(pb); cmd != nil {
+			result = append(result, cmd)
+		}
+	}
+	return result
+}
+
+func commandFromProto(pb *v2.Command) *model.Command {
+	if pb == nil {
+		return nil
+	}
+
+	switch c := pb.Command.(type) {
+	case *v2.Command_Create:
+		return &model.Command{
+			Command: &model.CreateCommand{
+				TemplateID: ledger.IdentifierToString(c.Create.TemplateId),
+				Arguments:  ledger.RecordToMap(c.Create.CreateArguments),
+			},
+		}
+	case *v2.Command_Exercise:
+		return &model.Command{
+			Command: &model.ExerciseCommand{
+				ContractID: c.Exercise.ContractId,
+				TemplateID: ledger.IdentifierToString(c.Exercise.TemplateId),
+				Choice:     c.Exercise.Choice,
+				Arguments:  valueToArguments(c.Exercise.ChoiceArgument),
+			},
+		}
+	case *v2.Command_ExerciseByKey:
+		return &model.Command{
+			Command: &model.ExerciseByKeyCommand{
+				TemplateID: ledger.IdentifierToString(c.ExerciseByKey.TemplateId),
+				Key:        valueToArguments(c.ExerciseByKey.ContractKey),
+				Choice:     c.ExerciseByKey.Choice,
+				Arguments:  valueToArguments(c.ExerciseByKey.ChoiceArgument),
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+// valueToArguments converts a choice argument or key to the map form used by the command models,
+// wrapping non-record values under "value".
+func valueToArguments(pb *v2.Value) map[string]interface{} {
+	if pb == nil {
+		return nil
+	}
+	if record := pb.GetRecord(); record != nil {
+		return ledger.RecordToMap(record)
+	}
+	return map[string]interface{}{"value": ledger.ValueFromProto(pb)}
+}
+
+func commandUpdatesFromProto(pb *adminv2.CommandUpdates) *model.CommandUpdates {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.CommandUpdates{
+		Created:       commandContractsFromProto(pb.Created),
+		Archived:      commandContractsFromProto(pb.Archived),
+		Exercised:     pb.Exercised,
+		Fetched:       pb.Fetched,
+		LookedUpByKey: pb.LookedUpByKey,
+	}
+}
+
+func commandContractsFromProto(pbs []*adminv2.Contract) []*model.CommandContract {
+	result := make([]*model.CommandContract, len(pbs))
+	for i, pb := range pbs {
+		result[i] = &model.CommandContract{
+			TemplateID:  ledger.IdentifierToString(pb.TemplateId),
+			ContractID:  pb.ContractId,
+			ContractKey: ledger.ValueFromProto(pb.ContractKey),
+		}
+	}
+	return result
+}
+
 func commandStatusFromProtos(pbs []*adminv2.CommandStatus) []*model.CommandStatus {
 	result := make([]*model.CommandStatus, len(pbs))
 	for i, pb := range pbs {
@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+type CommandStatusWatchConfig struct {
+	CommandIDPrefix string
+	// States limits the reported changes to commands that entered one of these states.
+	// A single state is also applied as a server-side filter.
+	States   []model.CommandState
+	Limit    uint32
+	Interval time.Duration
+}
+
+// CommandStatusChange is emitted whenever a watched command is first seen or changes state.
+type CommandStatusChange struct {
+	Previous model.CommandState
+	Status   *model.CommandStatus
+}
+
+// CommandStatusWatcher polls CommandInspection and reports commands whose state changed.
+type CommandStatusWatcher struct {
+	inspection CommandInspection
+	config     CommandStatusWatchConfig
+	known      map[string]model.CommandState
+}
+
+func NewCommandStatusWatcher(inspection CommandInspection, config CommandStatusWatchConfig) *CommandStatusWatcher {
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+
+	return &CommandStatusWatcher{
+		inspection: inspection,
+		config:     config,
+		known:      make(map[string]model.CommandState),
+	}
+}
+
+// Watch polls until ctx is done or a poll fails. The first poll reports every matching command.
+func (w *CommandStatusWatcher) Watch(ctx context.Context) (<-chan *CommandStatusChange, <-chan error) {
+	responseCh := make(chan *CommandStatusChange)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(responseCh)
+		defer close(errCh)
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			changes, err := w.Poll(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			for _, change := range changes {
+				select {
+				case responseCh <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return responseCh, errCh
+}
+
+// Poll fetches the current command statuses once and returns the changes since the previous poll.
+func (w *CommandStatusWatcher) Poll(ctx context.Context) ([]*CommandStatusChange, error) {
+	serverState := model.CommandStateUnspecified
+	if len(w.config.States) == 1 {
+		serverState = w.config.States[0]
+	}
+
+	statuses, err := w.inspection.GetCommandStatus(ctx, w.config.CommandIDPrefix, serverState, w.config.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*CommandStatusChange
+	seen := make(map[string]model.CommandState, len(statuses))
+	for _, status := range statuses {
+		// The command ID comes from the completion; statuses without one cannot be told apart
+		// from each other, so they are not tracked.
+		if status == nil || status.CommandID == "" {
+			continue
+		}
+
+		key := status.CommandID + "/" + status.SubmissionID
+		seen[key] = status.State
+
+		previous, ok := w.known[key]
+		if ok && previous == status.State {
+			continue
+		}
+		if len(w.config.States) > 0 && !slices.Contains(w.config.States, status.State) {
+			continue
+		}
+
+		changes = append(changes, &CommandStatusChange{
+			Previous: previous,
+			Status:   status,
+		})
+	}
+	w.known = seen
+
+	return changes, nil
+}
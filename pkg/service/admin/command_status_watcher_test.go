@@ -0,0 +1,53 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/admin"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCommandInspection struct {
+	polls [][]*model.CommandStatus
+}
+
+func (f *fakeCommandInspection) GetCommandStatus(_ context.Context, _ string, _ model.CommandState, _ uint32) ([]*model.CommandStatus, error) {
+	if len(f.polls) == 0 {
+		return nil, nil
+	}
+	result := f.polls[0]
+	f.polls = f.polls[1:]
+	return result, nil
+}
+
+func TestCommandStatusWatcher_Poll(t *testing.T) {
+	inspection := &fakeCommandInspection{polls: [][]*model.CommandStatus{
+		{
+			{CommandID: "cmd-1", State: model.CommandStatePending},
+			{CommandID: "cmd-2", State: model.CommandStatePending},
+			{State: model.CommandStatePending},
+		},
+		{
+			{CommandID: "cmd-1", State: model.CommandStateSucceeded},
+			{CommandID: "cmd-2", State: model.CommandStateFailed, Error: &model.StatusError{Code: 9, Message: "CONTRACT_NOT_FOUND"}},
+		},
+	}}
+
+	watcher := admin.NewCommandStatusWatcher(inspection, admin.CommandStatusWatchConfig{
+		States: []model.CommandState{model.CommandStatePending, model.CommandStateFailed},
+	})
+
+	changes, err := watcher.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, model.CommandStateUnspecified, changes[0].Previous)
+
+	changes, err = watcher.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "cmd-2", changes[0].Status.CommandID)
+	require.Equal(t, model.CommandStatePending, changes[0].Previous)
+	require.Equal(t, "CONTRACT_NOT_FOUND", changes[0].Status.Error.Message)
+}
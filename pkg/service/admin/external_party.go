@@ -0,0 +1,154 @@
+package admin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+)
+
+// ExternalPartyAllocator onboards parties whose signing key is held by the caller
+// rather than by the participant.
+type ExternalPartyAllocator struct {
+	parties  PartyManagement
+	topology topology.TopologyManagerWrite
+}
+
+func NewExternalPartyAllocator(parties PartyManagement, topologyWrite topology.TopologyManagerWrite) *ExternalPartyAllocator {
+	return &ExternalPartyAllocator{
+		parties:  parties,
+		topology: topologyWrite,
+	}
+}
+
+type ExternalPartyRequest struct {
+	PartyHint    string
+	Synchronizer model.SynchronizerID
+	// ParticipantUID hosts the party with confirmation rights. Defaults to the connected participant.
+	ParticipantUID     model.ParticipantUID
+	IdentityProviderID string
+}
+
+type ExternalParty struct {
+	PartyID     string
+	Fingerprint string
+	PublicKey   ed25519.PublicKey
+	PrivateKey  ed25519.PrivateKey
+}
+
+// Allocate generates an Ed25519 key pair, builds and signs the namespace delegation,
+// party-to-key and party-to-participant transactions, and allocates the party.
+func (a *ExternalPartyAllocator) Allocate(ctx context.Context, req ExternalPartyRequest) (*ExternalParty, error) {
+	if req.PartyHint == "" {
+		return nil, fmt.Errorf("party hint is required")
+	}
+	if req.Synchronizer == "" {
+		return nil, fmt.Errorf("synchronizer is required")
+	}
+	if err := req.Synchronizer.Validate(); err != nil {
+		return nil, err
+	}
+
+	participantUID := req.ParticipantUID
+	if participantUID == "" {
+		id, err := a.parties.GetParticipantID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participant ID: %w", err)
+		}
+		participantUID, err = model.ParseParticipantUID(id)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := participantUID.Validate(); err != nil {
+		return nil, err
+	}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+
+	fingerprint := topology.Fingerprint(publicKey)
+	partyID, err := model.NewPartyID(req.PartyHint, model.Namespace(fingerprint))
+	if err != nil {
+		return nil, fmt.Errorf("invalid party hint: %w", err)
+	}
+
+	signingKey, err := model.NewSigningPublicKey(publicKey, model.CryptoKeyFormatRaw)
+	if err != nil {
+		return nil, err
+	}
+	store := model.AuthorizedStore()
+
+	proposals := []*model.GenerateTransactionProposal{
+		{
+			Operation: model.OperationAddReplace,
+			Serial:    1,
+			Mapping: &model.NamespaceDelegationMapping{
+				Namespace:        model.Namespace(fingerprint),
+				TargetKey:        signingKey,
+				IsRootDelegation: true,
+			},
+			Store: store,
+		},
+		{
+			Operation: model.OperationAddReplace,
+			Serial:    1,
+			Mapping: &model.PartyToKeyMapping{
+				Party:       partyID,
+				Threshold:   1,
+				SigningKeys: []model.PublicKey{signingKey},
+			},
+			Store: store,
+		},
+		{
+			Operation: model.OperationAddReplace,
+			Serial:    1,
+			Mapping: &model.PartyToParticipantMapping{
+				Party:     partyID,
+				Threshold: 1,
+				Participants: []model.HostingParticipant{
+					{ParticipantUID: participantUID, Permission: model.ParticipantPermissionConfirmation},
+				},
+			},
+			Store: store,
+		},
+	}
+
+	generated, err := a.topology.GenerateTransactions(ctx, &model.GenerateTransactionsRequest{Proposals: proposals})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate onboarding transactions: %w", err)
+	}
+	if len(generated.GeneratedTransactions) != len(proposals) {
+		return nil, fmt.Errorf("expected %d onboarding transactions, got %d", len(proposals), len(generated.GeneratedTransactions))
+	}
+
+	transactions := make([]model.SignedTransaction, len(generated.GeneratedTransactions))
+	hashes := make([][]byte, len(generated.GeneratedTransactions))
+	for i, tx := range generated.GeneratedTransactions {
+		transactions[i] = model.SignedTransaction{Transaction: tx.SerializedTransaction}
+		hashes[i] = tx.TransactionHash
+	}
+
+	multiHashSignature := model.Signature{
+		Format:               model.SignatureFormatConcat,
+		Signature:            ed25519.Sign(privateKey, topology.MultiTransactionHash(hashes)),
+		SignedBy:             string(fingerprint),
+		SigningAlgorithmSpec: model.SigningAlgorithmSpecED25519,
+	}
+
+	allocated, err := a.parties.AllocateExternalParty(ctx, req.Synchronizer, transactions, []model.Signature{multiHashSignature}, req.IdentityProviderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate external party %s: %w", partyID, err)
+	}
+
+	return &ExternalParty{
+		PartyID:     allocated,
+		Fingerprint: string(fingerprint),
+		PublicKey:   publicKey,
+		PrivateKey:  privateKey,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package admin_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/admin"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePartyManagement struct {
+	admin.PartyManagement
+	participantID string
+	transactions  []model.SignedTransaction
+	signatures    []model.Signature
+}
+
+func (f *fakePartyManagement) GetParticipantID(context.Context) (string, error) {
+	return f.participantID, nil
+}
+
+func (f *fakePartyManagement) AllocateExternalParty(_ context.Context, _ model.SynchronizerID, txs []model.SignedTransaction, sigs []model.Signature, _ string) (string, error) {
+	f.transactions = txs
+	f.signatures = sigs
+
+	var party string
+	for _, tx := range txs {
+		if strings.HasPrefix(string(tx.Transaction), "party-to-key:") {
+			party = strings.TrimPrefix(string(tx.Transaction), "party-to-key:")
+		}
+	}
+	return party, nil
+}
+
+type fakeTopologyWrite struct {
+	proposals []*model.GenerateTransactionProposal
+}
+
+func (f *fakeTopologyWrite) Authorize(context.Context, *model.AuthorizeRequest) (*model.AuthorizeResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeTopologyWrite) AddTransactions(context.Context, *model.AddTransactionsRequest) (*model.AddTransactionsResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeTopologyWrite) SignTransactions(context.Context, *model.SignTransactionsRequest) (*model.SignTransactionsResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeTopologyWrite) GenerateTransactions(_ context.Context, req *model.GenerateTransactionsRequest) (*model.GenerateTransactionsResponse, error) {
+	f.proposals = req.Proposals
+
+	resp := &model.GenerateTransactionsResponse{}
+	for i, p := range req.Proposals {
+		serialized := "other"
+		if m, ok := p.Mapping.(*model.PartyToKeyMapping); ok {
+			serialized = "party-to-key:" + string(m.Party)
+		}
+		resp.GeneratedTransactions = append(resp.GeneratedTransactions, &model.GeneratedTransaction{
+			SerializedTransaction: []byte(serialized),
+			TransactionHash:       []byte{0x12, 0x20, byte(i)},
+		})
+	}
+	return resp, nil
+}
+
+func (f *fakeTopologyWrite) CreateTemporaryTopologyStore(context.Context, *model.CreateTemporaryTopologyStoreRequest) (*model.CreateTemporaryTopologyStoreResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeTopologyWrite) DropTemporaryTopologyStore(context.Context, *model.DropTemporaryTopologyStoreRequest) (*model.DropTemporaryTopologyStoreResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeTopologyWrite) ImportTopologySnapshot(context.Context, *model.ImportTopologySnapshotRequest, io.Reader) (*model.ImportTopologySnapshotResponse, error) {
+	return nil, nil
+}
+
+func TestExternalPartyAllocator_Allocate(t *testing.T) {
+	parties := &fakePartyManagement{participantID: "PAR::participant1::1220abcd"}
+	topologyWrite := &fakeTopologyWrite{}
+
+	allocator := admin.NewExternalPartyAllocator(parties, topologyWrite)
+	party, err := allocator.Allocate(context.Background(), admin.ExternalPartyRequest{
+		PartyHint:    "alice",
+		Synchronizer: "global::1220ef",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "alice::"+party.Fingerprint, party.PartyID)
+	require.True(t, strings.HasPrefix(party.Fingerprint, "1220"))
+
+	require.Len(t, topologyWrite.proposals, 3)
+	delegation, ok := topologyWrite.proposals[0].Mapping.(*model.NamespaceDelegationMapping)
+	require.True(t, ok)
+	require.Equal(t, model.Namespace(party.Fingerprint), delegation.Namespace)
+	hosting, ok := topologyWrite.proposals[2].Mapping.(*model.PartyToParticipantMapping)
+	require.True(t, ok)
+	require.Equal(t, model.ParticipantUID("participant1::1220abcd"), hosting.Participants[0].ParticipantUID)
+
+	require.Len(t, parties.transactions, 3)
+	require.Len(t, parties.signatures, 1)
+	hash := topology.MultiTransactionHash([][]byte{{0x12, 0x20, 2}, {0x12, 0x20, 0}, {0x12, 0x20, 1}})
+	require.True(t, ed25519.Verify(party.PublicKey, hash, parties.signatures[0].Signature))
+	require.Equal(t, party.Fingerprint, parties.signatures[0].SignedBy)
+}
+
+func TestExternalPartyAllocator_Validation(t *testing.T) {
+	allocator := admin.NewExternalPartyAllocator(&fakePartyManagement{}, &fakeTopologyWrite{})
+
+	_, err := allocator.Allocate(context.Background(), admin.ExternalPartyRequest{Synchronizer: "global::1220ef"})
+	require.ErrorContains(t, err, "party hint")
+
+	_, err = allocator.Allocate(context.Background(), admin.ExternalPartyRequest{PartyHint: "alice"})
+	require.ErrorContains(t, err, "synchronizer")
+}
@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/auth"
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+// IdentityProviderPreflight validates an identity provider configuration before it is
+// registered: the issuer must be unused, the JWKS must be reachable and well formed, and
+// an optional sample token must verify against it.
+type IdentityProviderPreflight struct {
+	idp        IdentityProviderConfig
+	httpClient *http.Client
+}
+
+// NewIdentityProviderPreflight creates a preflight. A nil httpClient uses http.DefaultClient.
+func NewIdentityProviderPreflight(idp IdentityProviderConfig, httpClient *http.Client) *IdentityProviderPreflight {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &IdentityProviderPreflight{
+		idp:        idp,
+		httpClient: httpClient,
+	}
+}
+
+type IdentityProviderPreflightResult struct {
+	JWKS        *auth.JWKS
+	TokenClaims *auth.JWTClaims
+}
+
+// Check runs all validations. sampleToken is optional; when empty the token check is skipped.
+func (p *IdentityProviderPreflight) Check(ctx context.Context, config *model.IdentityProviderConfig, sampleToken string) (*IdentityProviderPreflightResult, error) {
+	if config == nil {
+		return nil, fmt.Errorf("identity provider config is required")
+	}
+	if config.IdentityProviderID == "" {
+		return nil, fmt.Errorf("identity provider ID is required")
+	}
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("issuer is required")
+	}
+
+	jwksURL, err := url.Parse(config.JwksURL)
+	if err != nil || (jwksURL.Scheme != "https" && jwksURL.Scheme != "http") || jwksURL.Host == "" {
+		return nil, fmt.Errorf("invalid JWKS URL %q", config.JwksURL)
+	}
+
+	existing, err := p.idp.ListIdentityProviderConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identity provider configs: %w", err)
+	}
+	for _, other := range existing {
+		if other == nil || other.IdentityProviderID == config.IdentityProviderID {
+			continue
+		}
+		if other.Issuer == config.Issuer {
+			return nil, fmt.Errorf("issuer %q is already used by identity provider %q", config.Issuer, other.IdentityProviderID)
+		}
+	}
+
+	jwks, err := auth.FetchJWKS(ctx, p.httpClient, config.JwksURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IdentityProviderPreflightResult{JWKS: jwks}
+	if sampleToken == "" {
+		return result, nil
+	}
+
+	claims, err := jwks.VerifyJWT(sampleToken)
+	if err != nil {
+		return nil, fmt.Errorf("sample token rejected: %w", err)
+	}
+	if claims.Issuer != config.Issuer {
+		return nil, fmt.Errorf("sample token issuer %q does not match %q", claims.Issuer, config.Issuer)
+	}
+	if config.Audience != "" && !slices.Contains(claims.Audience, config.Audience) {
+		return nil, fmt.Errorf("sample token audience %v does not contain %q", claims.Audience, config.Audience)
+	}
+	now := time.Now()
+	if claims.ExpiresAt != nil && now.After(*claims.ExpiresAt) {
+		return nil, fmt.Errorf("sample token expired at %s", claims.ExpiresAt.Format(time.RFC3339))
+	}
+	if claims.NotBefore != nil && now.Before(*claims.NotBefore) {
+		return nil, fmt.Errorf("sample token not valid before %s", claims.NotBefore.Format(time.RFC3339))
+	}
+	result.TokenClaims = claims
+
+	return result, nil
+}
+
+// CreateIdentityProviderConfig runs Check and registers the config only if it passes.
+func (p *IdentityProviderPreflight) CreateIdentityProviderConfig(ctx context.Context, config *model.IdentityProviderConfig, sampleToken string) (*model.IdentityProviderConfig, error) {
+	if _, err := p.Check(ctx, config, sampleToken); err != nil {
+		return nil, err
+	}
+
+	return p.idp.CreateIdentityProviderConfig(ctx, config)
+}
@@ -0,0 +1,111 @@
+package admin_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/admin"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIdentityProviderConfig struct {
+	existing []*model.IdentityProviderConfig
+	created  []*model.IdentityProviderConfig
+}
+
+func (f *fakeIdentityProviderConfig) CreateIdentityProviderConfig(_ context.Context, config *model.IdentityProviderConfig) (*model.IdentityProviderConfig, error) {
+	f.created = append(f.created, config)
+	return config, nil
+}
+
+func (f *fakeIdentityProviderConfig) GetIdentityProviderConfig(context.Context, string) (*model.IdentityProviderConfig, error) {
+	return nil, nil
+}
+
+func (f *fakeIdentityProviderConfig) UpdateIdentityProviderConfig(context.Context, *model.IdentityProviderConfig, []string) (*model.IdentityProviderConfig, error) {
+	return nil, nil
+}
+
+func (f *fakeIdentityProviderConfig) ListIdentityProviderConfigs(context.Context) ([]*model.IdentityProviderConfig, error) {
+	return f.existing, nil
+}
+
+func (f *fakeIdentityProviderConfig) DeleteIdentityProviderConfig(context.Context, string) error {
+	return nil
+}
+
+func TestIdentityProviderPreflight(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"k1","alg":"EdDSA","x":%q}]}`,
+			base64.RawURLEncoding.EncodeToString(pub))
+	}))
+	defer server.Close()
+
+	sampleToken := func(issuer string) string {
+		header, _ := json.Marshal(map[string]string{"alg": "EdDSA", "kid": "k1"})
+		claims, _ := json.Marshal(map[string]interface{}{
+			"iss": issuer,
+			"aud": "participant",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+		return input + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(priv, []byte(input)))
+	}
+
+	config := &model.IdentityProviderConfig{
+		IdentityProviderID: "idp-new",
+		Issuer:             "https://idp.example.com",
+		JwksURL:            server.URL,
+		Audience:           "participant",
+	}
+
+	tests := []struct {
+		name        string
+		existing    []*model.IdentityProviderConfig
+		token       string
+		expectError string
+	}{
+		{
+			name:  "valid config and token",
+			token: sampleToken("https://idp.example.com"),
+		},
+		{
+			name:        "issuer already registered",
+			existing:    []*model.IdentityProviderConfig{{IdentityProviderID: "idp-old", Issuer: "https://idp.example.com"}},
+			expectError: "already used",
+		},
+		{
+			name:        "token from another issuer",
+			token:       sampleToken("https://other.example.com"),
+			expectError: "does not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := &fakeIdentityProviderConfig{existing: tt.existing}
+			preflight := admin.NewIdentityProviderPreflight(idp, server.Client())
+
+			_, err := preflight.CreateIdentityProviderConfig(context.Background(), config, tt.token)
+			if tt.expectError != "" {
+				require.ErrorContains(t, err, tt.expectError)
+				require.Empty(t, idp.created)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, idp.created, 1)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package admin_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -14,7 +16,9 @@ func TestMain(m *testing.M) {
 	defer cancel()
 
 	err := testutil.Setup(ctx)
-	if err != nil {
+	if errors.Is(err, testutil.ErrDockerUnavailable) {
+		fmt.Fprintf(os.Stderr, "running without the Canton sandbox: %v\n", err)
+	} else if err != nil {
 		panic(err)
 	}
 
@@ -16,7 +16,7 @@ type PartyManagement interface {
 	GetParties(ctx context.Context, parties []string, identityProviderID string) ([]*model.PartyDetails, error)
 	ListKnownParties(ctx context.Context, pageToken string, pageSize int32, identityProviderID string) (*model.ListKnownPartiesResponse, error)
 	AllocateParty(ctx context.Context, partyIDHint string, localMetadata map[string]string, identityProviderID string) (*model.PartyDetails, error)
-	AllocateExternalParty(ctx context.Context, synchronizer string, onboardingTransactions []model.SignedTransaction, multiHashSignatures []model.Signature, identityProviderID string) (string, error)
+	AllocateExternalParty(ctx context.Context, synchronizer model.SynchronizerID, onboardingTransactions []model.SignedTransaction, multiHashSignatures []model.Signature, identityProviderID string) (string, error)
 	UpdatePartyDetails(ctx context.Context, party *model.PartyDetails, updateMask *model.UpdateMask) (*model.PartyDetails, error)
 	UpdatePartyIdentityProviderID(ctx context.Context, party string, sourceIdentityProviderID string, targetIdentityProviderID string) error
 }
@@ -116,7 +116,11 @@ func (c *partyManagement) UpdatePartyDetails(ctx context.Context, party *model.P
 	return partyDetailsFromProto(resp.PartyDetails), nil
 }
 
-func (c *partyManagement) AllocateExternalParty(ctx context.Context, synchronizer string, onboardingTransactions []model.SignedTransaction, multiHashSignatures []model.Signature, identityProviderID string) (string, error) {
+func (c *partyManagement) AllocateExternalParty(ctx context.Context, synchronizer model.SynchronizerID, onboardingTransactions []model.SignedTransaction, multiHashSignatures []model.Signature, identityProviderID string) (string, error) {
+	if err := synchronizer.Validate(); err != nil {
+		return "", err
+	}
+
 	signedTxs := make([]*adminv2.AllocateExternalPartyRequest_SignedTransaction, len(onboardingTransactions))
 	for i, tx := range onboardingTransactions {
 		sigs := make([]*v2.Signature, len(tx.Signatures))
@@ -145,7 +149,7 @@ func (c *partyManagement) AllocateExternalParty(ctx context.Context, synchronize
 	}
 
 	req := &adminv2.AllocateExternalPartyRequest{
-		Synchronizer:           synchronizer,
+		Synchronizer:           string(synchronizer),
 		OnboardingTransactions: signedTxs,
 		MultiHashSignatures:    multiSigs,
 		IdentityProviderId:     identityProviderID,
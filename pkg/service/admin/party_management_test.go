@@ -20,8 +20,7 @@ func TestAllocateExternalParty(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 	defer cancel()
 
-	cl := testutil.GetClient()
-	require.NotNil(t, cl)
+	cl := testutil.RequireClient(t)
 	require.NotNil(t, cl.PartyMng)
 	require.NotNil(t, cl.TopologyManagerWrite)
 
@@ -85,8 +84,7 @@ func TestAllocateExternalParty_ErrorHandling(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 	defer cancel()
 
-	cl := testutil.GetClient()
-	require.NotNil(t, cl)
+	cl := testutil.RequireClient(t)
 	require.NotNil(t, cl.PartyMng)
 
 	syncResp, err := cl.StateService.GetConnectedSynchronizers(ctx, &model.GetConnectedSynchronizersRequest{})
@@ -97,7 +95,7 @@ func TestAllocateExternalParty_ErrorHandling(t *testing.T) {
 
 	tests := []struct {
 		name                   string
-		synchronizer           string
+		synchronizer           model.SynchronizerID
 		onboardingTransactions []model.SignedTransaction
 		multiHashSignatures    []model.Signature
 		identityProviderID     string
@@ -182,7 +180,7 @@ func createValidOnboardingTransactions(
 			Operation: model.OperationAddReplace,
 			Serial:    1,
 			Mapping: &model.NamespaceDelegationMapping{
-				Namespace:        keyFingerprint,
+				Namespace:        model.Namespace(keyFingerprint),
 				TargetKey:        *pubKey,
 				IsRootDelegation: true,
 			},
@@ -192,7 +190,7 @@ func createValidOnboardingTransactions(
 			Operation: model.OperationAddReplace,
 			Serial:    1,
 			Mapping: &model.PartyToKeyMapping{
-				Party:       partyID,
+				Party:       model.PartyID(partyID),
 				Threshold:   1,
 				SigningKeys: []model.PublicKey{*pubKey},
 			},
@@ -202,11 +200,11 @@ func createValidOnboardingTransactions(
 			Operation: model.OperationAddReplace,
 			Serial:    1,
 			Mapping: &model.PartyToParticipantMapping{
-				Party:     partyID,
+				Party:     model.PartyID(partyID),
 				Threshold: 1,
 				Participants: []model.HostingParticipant{
 					{
-						ParticipantUID: participantID,
+						ParticipantUID: model.ParticipantUID(participantID),
 						Permission:     model.ParticipantPermissionConfirmation,
 					},
 				},
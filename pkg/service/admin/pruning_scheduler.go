@@ -0,0 +1,289 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/ledger"
+)
+
+// PruningStage identifies the step a PruningScheduler run has reached.
+type PruningStage int
+
+const (
+	PruningStageStarted PruningStage = iota
+	PruningStageOffsetComputed
+	PruningStageSkipped
+	PruningStagePruned
+	PruningStageFailed
+)
+
+func (s PruningStage) String() string {
+	switch s {
+	case PruningStageStarted:
+		return "started"
+	case PruningStageOffsetComputed:
+		return "offset_computed"
+	case PruningStageSkipped:
+		return "skipped"
+	case PruningStagePruned:
+		return "pruned"
+	case PruningStageFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// PruningProgress is reported to PruningSchedulerConfig.OnProgress at every stage of a run.
+type PruningProgress struct {
+	Stage         PruningStage
+	Cutoff        time.Time
+	LatestPruned  int64
+	LedgerEnd     int64
+	RetentionSafe int64
+	ConsumerSafe  *int64
+	PruneUpTo     int64
+	Err           error
+}
+
+type PruningSchedulerConfig struct {
+	// Retention is how far back in record time the ledger must stay unpruned.
+	Retention time.Duration
+	// Interval is the base delay between two scheduled runs. Defaults to one hour.
+	Interval time.Duration
+	// Jitter is the maximum random delay added to Interval.
+	Jitter time.Duration
+	// UpdateFormat selects the updates scanned for record times. Defaults to all transactions of any party.
+	UpdateFormat *model.EventFormat
+	// PruneAllDivulgedContracts is forwarded to every prune request.
+	PruneAllDivulgedContracts bool
+	// OnProgress, when set, is called synchronously with every progress report.
+	OnProgress func(PruningProgress)
+	// Now overrides the clock, mostly for tests.
+	Now func() time.Time
+}
+
+// PruningScheduler prunes the participant up to the newest offset that is older than
+// the configured retention and not needed by any registered consumer.
+type PruningScheduler struct {
+	pruning ParticipantPruning
+	state   ledger.StateService
+	updates ledger.UpdateService
+	config  PruningSchedulerConfig
+
+	mu        sync.Mutex
+	consumers map[string]int64
+}
+
+func NewPruningScheduler(pruning ParticipantPruning, state ledger.StateService, updates ledger.UpdateService, config PruningSchedulerConfig) *PruningScheduler {
+	if config.UpdateFormat == nil {
+		config.UpdateFormat = &model.EventFormat{
+			FiltersForAnyParty: &model.Filters{},
+		}
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+
+	return &PruningScheduler{
+		pruning:   pruning,
+		state:     state,
+		updates:   updates,
+		config:    config,
+		consumers: make(map[string]int64),
+	}
+}
+
+// SetConsumerOffset records that the named consumer has processed everything up to
+// and including offset, so nothing after it may be pruned.
+func (s *PruningScheduler) SetConsumerOffset(name string, offset int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consumers[name] = offset
+}
+
+func (s *PruningScheduler) RemoveConsumer(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.consumers, name)
+}
+
+func (s *PruningScheduler) minConsumerOffset() *int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result *int64
+	for _, offset := range s.consumers {
+		if result == nil || offset < *result {
+			o := offset
+			result = &o
+		}
+	}
+	return result
+}
+
+// Run executes PruneOnce every Interval plus a random Jitter until ctx is done.
+// Errors are reported through OnProgress and do not stop the loop.
+func (s *PruningScheduler) Run(ctx context.Context) error {
+	for {
+		timer := time.NewTimer(s.nextDelay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if _, err := s.PruneOnce(ctx); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *PruningScheduler) nextDelay() time.Duration {
+	delay := s.config.Interval
+	if s.config.Jitter > 0 {
+		delay += rand.N(s.config.Jitter)
+	}
+	return delay
+}
+
+// PruneOnce computes the safe offset and prunes up to it. It returns the report of the
+// final stage, which is PruningStageSkipped when there is nothing new to prune.
+func (s *PruningScheduler) PruneOnce(ctx context.Context) (*PruningProgress, error) {
+	progress := PruningProgress{
+		Stage:  PruningStageStarted,
+		Cutoff: s.config.Now().Add(-s.config.Retention),
+	}
+	s.report(progress)
+
+	pruneUpTo, err := s.computeSafeOffset(ctx, &progress)
+	if err != nil {
+		return s.fail(progress, err)
+	}
+	progress.PruneUpTo = pruneUpTo
+	progress.Stage = PruningStageOffsetComputed
+	s.report(progress)
+
+	if pruneUpTo <= progress.LatestPruned {
+		progress.Stage = PruningStageSkipped
+		s.report(progress)
+		return &progress, nil
+	}
+
+	err = s.pruning.Prune(ctx, &model.PruneRequest{
+		PruneUpTo:                 pruneUpTo,
+		SubmissionID:              fmt.Sprintf("prune-%d", pruneUpTo),
+		PruneAllDivulgedContracts: s.config.PruneAllDivulgedContracts,
+	})
+	if err != nil {
+		return s.fail(progress, fmt.Errorf("failed to prune up to offset %d: %w", pruneUpTo, err))
+	}
+
+	progress.Stage = PruningStagePruned
+	s.report(progress)
+	return &progress, nil
+}
+
+func (s *PruningScheduler) computeSafeOffset(ctx context.Context, progress *PruningProgress) (int64, error) {
+	pruned, err := s.state.GetLatestPrunedOffsets(ctx, &model.GetLatestPrunedOffsetsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest pruned offsets: %w", err)
+	}
+	progress.LatestPruned = pruned.ParticipantPrunedUpToInclusive
+
+	end, err := s.state.GetLedgerEnd(ctx, &model.GetLedgerEndRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ledger end: %w", err)
+	}
+	progress.LedgerEnd = end.Offset
+
+	retentionSafe, err := s.lastOffsetBefore(ctx, progress.LatestPruned, progress.LedgerEnd, progress.Cutoff)
+	if err != nil {
+		return 0, err
+	}
+	progress.RetentionSafe = retentionSafe
+
+	safe := retentionSafe
+	if consumerSafe := s.minConsumerOffset(); consumerSafe != nil {
+		progress.ConsumerSafe = consumerSafe
+		if *consumerSafe < safe {
+			safe = *consumerSafe
+		}
+	}
+
+	return safe, nil
+}
+
+// lastOffsetBefore scans the updates in (begin, end] and returns the highest offset
+// whose record time is not after cutoff. The scan stops at the first newer update.
+func (s *PruningScheduler) lastOffsetBefore(ctx context.Context, begin, end int64, cutoff time.Time) (int64, error) {
+	if end <= begin {
+		return begin, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	endInclusive := end
+	responseCh, errCh := s.updates.GetUpdates(ctx, &model.GetUpdatesRequest{
+		BeginExclusive: begin,
+		EndInclusive:   &endInclusive,
+		UpdateFormat:   s.config.UpdateFormat,
+	})
+	if responseCh == nil {
+		return 0, fmt.Errorf("failed to read updates: %w", <-errCh)
+	}
+
+	safe := begin
+	for resp := range responseCh {
+		offset, recordTime, ok := updateRecordTime(resp.Update)
+		if !ok {
+			continue
+		}
+		if recordTime.After(cutoff) {
+			return safe, nil
+		}
+		safe = offset
+	}
+
+	if err := <-errCh; err != nil {
+		return 0, fmt.Errorf("failed to read updates: %w", err)
+	}
+
+	return safe, nil
+}
+
+func updateRecordTime(update *model.Update) (int64, time.Time, bool) {
+	if update == nil {
+		return 0, time.Time{}, false
+	}
+	if tx := update.Transaction; tx != nil && tx.RecordTime != nil {
+		return tx.Offset, *tx.RecordTime, true
+	}
+	if r := update.Reassignment; r != nil && r.RecordTime != nil {
+		return r.Offset, *r.RecordTime, true
+	}
+	return 0, time.Time{}, false
+}
+
+func (s *PruningScheduler) fail(progress PruningProgress, err error) (*PruningProgress, error) {
+	progress.Stage = PruningStageFailed
+	progress.Err = err
+	s.report(progress)
+	return &progress, err
+}
+
+func (s *PruningScheduler) report(progress PruningProgress) {
+	if s.config.OnProgress != nil {
+		s.config.OnProgress(progress)
+	}
+}
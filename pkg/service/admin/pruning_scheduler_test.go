@@ -0,0 +1,179 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/admin"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePruning struct {
+	requests []*model.PruneRequest
+}
+
+func (f *fakePruning) Prune(_ context.Context, req *model.PruneRequest) error {
+	f.requests = append(f.requests, req)
+	return nil
+}
+
+type fakeLedgerState struct {
+	latestPruned int64
+	ledgerEnd    int64
+}
+
+func (f *fakeLedgerState) GetActiveContracts(context.Context, *model.GetActiveContractsRequest) (<-chan *model.GetActiveContractsResponse, <-chan error) {
+	return nil, nil
+}
+
+func (f *fakeLedgerState) GetConnectedSynchronizers(context.Context, *model.GetConnectedSynchronizersRequest) (*model.GetConnectedSynchronizersResponse, error) {
+	return &model.GetConnectedSynchronizersResponse{}, nil
+}
+
+func (f *fakeLedgerState) GetLedgerEnd(context.Context, *model.GetLedgerEndRequest) (*model.GetLedgerEndResponse, error) {
+	return &model.GetLedgerEndResponse{Offset: f.ledgerEnd}, nil
+}
+
+func (f *fakeLedgerState) GetLatestPrunedOffsets(context.Context, *model.GetLatestPrunedOffsetsRequest) (*model.GetLatestPrunedOffsetsResponse, error) {
+	return &model.GetLatestPrunedOffsetsResponse{ParticipantPrunedUpToInclusive: f.latestPruned}, nil
+}
+
+type fakeUpdates struct {
+	recordTimes   map[int64]time.Time
+	reassignments map[int64]bool
+}
+
+func (f *fakeUpdates) GetUpdates(ctx context.Context, req *model.GetUpdatesRequest) (<-chan *model.GetUpdatesResponse, <-chan error) {
+	responseCh := make(chan *model.GetUpdatesResponse)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(responseCh)
+		defer close(errCh)
+
+		for offset := req.BeginExclusive + 1; offset <= *req.EndInclusive; offset++ {
+			recordTime, ok := f.recordTimes[offset]
+			if !ok {
+				continue
+			}
+			resp := &model.GetUpdatesResponse{
+				Update: &model.Update{
+					Transaction: &model.Transaction{Offset: offset, RecordTime: &recordTime},
+				},
+			}
+			if f.reassignments[offset] {
+				resp.Update = &model.Update{
+					Reassignment: &model.Reassignment{Offset: offset, RecordTime: &recordTime},
+				}
+			}
+			select {
+			case responseCh <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return responseCh, errCh
+}
+
+func (f *fakeUpdates) GetUpdateById(context.Context, *model.GetUpdateByIDRequest) (*model.GetUpdateResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeUpdates) GetTransactionByID(context.Context, *model.GetTransactionByIDRequest) (*model.GetTransactionResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeUpdates) GetTransactionByOffset(context.Context, *model.GetTransactionByOffsetRequest) (*model.GetTransactionResponse, error) {
+	return nil, nil
+}
+
+func TestPruningScheduler_PruneOnce(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	updates := &fakeUpdates{recordTimes: map[int64]time.Time{
+		11: now.Add(-72 * time.Hour),
+		12: now.Add(-50 * time.Hour),
+		14: now.Add(-30 * time.Hour),
+		15: now.Add(-1 * time.Hour),
+	}, reassignments: map[int64]bool{12: true}}
+
+	tests := []struct {
+		name          string
+		latestPruned  int64
+		consumers     map[string]int64
+		expectedStage admin.PruningStage
+		expectedUpTo  int64
+	}{
+		{
+			name:          "prunes up to last update older than retention",
+			latestPruned:  10,
+			expectedStage: admin.PruningStagePruned,
+			expectedUpTo:  12,
+		},
+		{
+			name:          "registered consumer holds pruning back",
+			latestPruned:  10,
+			consumers:     map[string]int64{"indexer": 11, "audit": 14},
+			expectedStage: admin.PruningStagePruned,
+			expectedUpTo:  11,
+		},
+		{
+			name:          "nothing new to prune",
+			latestPruned:  12,
+			expectedStage: admin.PruningStageSkipped,
+			expectedUpTo:  12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pruning := &fakePruning{}
+			state := &fakeLedgerState{latestPruned: tt.latestPruned, ledgerEnd: 15}
+
+			var stages []admin.PruningStage
+			scheduler := admin.NewPruningScheduler(pruning, state, updates, admin.PruningSchedulerConfig{
+				Retention: 48 * time.Hour,
+				Now:       func() time.Time { return now },
+				OnProgress: func(p admin.PruningProgress) {
+					stages = append(stages, p.Stage)
+				},
+			})
+			for name, offset := range tt.consumers {
+				scheduler.SetConsumerOffset(name, offset)
+			}
+
+			progress, err := scheduler.PruneOnce(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStage, progress.Stage)
+			require.Equal(t, tt.expectedUpTo, progress.PruneUpTo)
+			require.Equal(t, tt.expectedStage, stages[len(stages)-1])
+
+			if tt.expectedStage == admin.PruningStagePruned {
+				require.Len(t, pruning.requests, 1)
+				require.Equal(t, tt.expectedUpTo, pruning.requests[0].PruneUpTo)
+			} else {
+				require.Empty(t, pruning.requests)
+			}
+		})
+	}
+}
+
+func TestPruningScheduler_RunDefaultsInterval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	pruning := &fakePruning{}
+	var runs int
+	scheduler := admin.NewPruningScheduler(pruning, &fakeLedgerState{}, &fakeUpdates{}, admin.PruningSchedulerConfig{
+		Retention: time.Hour,
+		OnProgress: func(admin.PruningProgress) {
+			runs++
+		},
+	})
+
+	require.ErrorIs(t, scheduler.Run(ctx), context.DeadlineExceeded)
+	require.Zero(t, runs)
+}
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"fmt"
 
 	"google.golang.org/grpc"
 
@@ -16,6 +17,7 @@ type UserManagement interface {
 	GrantUserRights(ctx context.Context, userID, identityProviderID string, rights []*model.Right) ([]*model.Right, error)
 	RevokeUserRights(ctx context.Context, userID string, rights []*model.Right) ([]*model.Right, error)
 	ListUserRights(ctx context.Context, userID string) ([]*model.Right, error)
+	SetUserRights(ctx context.Context, userID string, desired []*model.Right) ([]*model.Right, error)
 	ListUsers(ctx context.Context) ([]*model.User, error)
 }
 
@@ -31,9 +33,14 @@ func NewUserManagementClient(conn *grpc.ClientConn) *userManagement {
 }
 
 func (c *userManagement) CreateUser(ctx context.Context, user *model.User, rights []*model.Right) (*model.User, error) {
+	pbRights, err := rightsToProto(rights)
+	if err != nil {
+		return nil, err
+	}
+
 	request := &adminv2.CreateUserRequest{
 		User:   userToProto(user),
-		Rights: rightsToProto(rights),
+		Rights: pbRights,
 	}
 
 	resp, err := c.client.CreateUser(ctx, request)
@@ -82,10 +89,15 @@ func (c *userManagement) DeleteUser(ctx context.Context, userID string) error {
 }
 
 func (c *userManagement) GrantUserRights(ctx context.Context, userID, identityProviderID string, rights []*model.Right) ([]*model.Right, error) {
+	pbRights, err := rightsToProto(rights)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &adminv2.GrantUserRightsRequest{
 		UserId:             userID,
 		IdentityProviderId: identityProviderID,
-		Rights:             rightsToProto(rights),
+		Rights:             pbRights,
 	}
 
 	resp, err := c.client.GrantUserRights(ctx, req)
@@ -97,9 +109,14 @@ func (c *userManagement) GrantUserRights(ctx context.Context, userID, identityPr
 }
 
 func (c *userManagement) RevokeUserRights(ctx context.Context, userID string, rights []*model.Right) ([]*model.Right, error) {
+	pbRights, err := rightsToProto(rights)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &adminv2.RevokeUserRightsRequest{
 		UserId: userID,
-		Rights: rightsToProto(rights),
+		Rights: pbRights,
 	}
 
 	resp, err := c.client.RevokeUserRights(ctx, req)
@@ -123,6 +140,78 @@ func (c *userManagement) ListUserRights(ctx context.Context, userID string) ([]*
 	return rightsFromProto(resp.Rights), nil
 }
 
+// SetUserRights grants and revokes only what is needed to make the user's rights equal to
+// desired, and returns the rights the user holds afterwards.
+func (c *userManagement) SetUserRights(ctx context.Context, userID string, desired []*model.Right) ([]*model.Right, error) {
+	if _, err := rightsToProto(desired); err != nil {
+		return nil, err
+	}
+
+	user, err := c.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := c.ListUserRights(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	toGrant, toRevoke := diffRights(current, desired)
+
+	if len(toGrant) > 0 {
+		if _, err := c.GrantUserRights(ctx, userID, user.IdentityProviderID, toGrant); err != nil {
+			return nil, fmt.Errorf("failed to grant rights to user %s: %w", userID, err)
+		}
+	}
+
+	if len(toRevoke) > 0 {
+		pbRights, err := rightsToProto(toRevoke)
+		if err != nil {
+			return nil, err
+		}
+		req := &adminv2.RevokeUserRightsRequest{
+			UserId:             userID,
+			IdentityProviderId: user.IdentityProviderID,
+			Rights:             pbRights,
+		}
+		if _, err := c.client.RevokeUserRights(ctx, req); err != nil {
+			return nil, fmt.Errorf("failed to revoke rights from user %s: %w", userID, err)
+		}
+	}
+
+	return c.ListUserRights(ctx, userID)
+}
+
+// diffRights returns the rights in desired but not in current, and those in current but not in desired.
+func diffRights(current, desired []*model.Right) (toGrant, toRevoke []*model.Right) {
+	have := make(map[model.RightType]bool, len(current))
+	for _, r := range current {
+		if r != nil && r.Type != nil {
+			have[r.Type] = true
+		}
+	}
+
+	want := make(map[model.RightType]bool, len(desired))
+	for _, r := range desired {
+		if r == nil || r.Type == nil || want[r.Type] {
+			continue
+		}
+		want[r.Type] = true
+		if !have[r.Type] {
+			toGrant = append(toGrant, r)
+		}
+	}
+
+	for _, r := range current {
+		if r != nil && r.Type != nil && !want[r.Type] {
+			toRevoke = append(toRevoke, r)
+		}
+	}
+
+	return toGrant, toRevoke
+}
+
 func userFromProto(pb *adminv2.User) *model.User {
 	if pb == nil {
 		return nil
@@ -173,21 +262,33 @@ func rightFromProto(pb *adminv2.Right) *model.Right {
 		r.Type = model.ParticipantAdmin{}
 	case *adminv2.Right_IdentityProviderAdmin_:
 		r.Type = model.IdentityProviderAdmin{}
+	case *adminv2.Right_CanReadAsAnyParty_:
+		r.Type = model.CanReadAsAnyParty{}
+	case *adminv2.Right_CanExecuteAs_:
+		r.Type = model.CanExecuteAs{Party: rt.CanExecuteAs.Party}
+	case *adminv2.Right_CanExecuteAsAnyParty_:
+		r.Type = model.CanExecuteAsAnyParty{}
 	}
 	return r
 }
 
-func rightToProto(r *model.Right) *adminv2.Right {
+func rightToProto(r *model.Right) (*adminv2.Right, error) {
 	if r == nil {
-		return nil
+		return nil, nil
 	}
 	pb := &adminv2.Right{}
 	switch rt := r.Type.(type) {
 	case model.CanActAs:
+		if err := model.PartyID(rt.Party).Validate(); err != nil {
+			return nil, fmt.Errorf("invalid act-as right: %w", err)
+		}
 		pb.Kind = &adminv2.Right_CanActAs_{
 			CanActAs: &adminv2.Right_CanActAs{Party: rt.Party},
 		}
 	case model.CanReadAs:
+		if err := model.PartyID(rt.Party).Validate(); err != nil {
+			return nil, fmt.Errorf("invalid read-as right: %w", err)
+		}
 		pb.Kind = &adminv2.Right_CanReadAs_{
 			CanReadAs: &adminv2.Right_CanReadAs{Party: rt.Party},
 		}
@@ -199,8 +300,23 @@ func rightToProto(r *model.Right) *adminv2.Right {
 		pb.Kind = &adminv2.Right_IdentityProviderAdmin_{
 			IdentityProviderAdmin: &adminv2.Right_IdentityProviderAdmin{},
 		}
+	case model.CanReadAsAnyParty:
+		pb.Kind = &adminv2.Right_CanReadAsAnyParty_{
+			CanReadAsAnyParty: &adminv2.Right_CanReadAsAnyParty{},
+		}
+	case model.CanExecuteAs:
+		if err := model.PartyID(rt.Party).Validate(); err != nil {
+			return nil, fmt.Errorf("invalid execute-as right: %w", err)
+		}
+		pb.Kind = &adminv2.Right_CanExecuteAs_{
+			CanExecuteAs: &adminv2.Right_CanExecuteAs{Party: rt.Party},
+		}
+	case model.CanExecuteAsAnyParty:
+		pb.Kind = &adminv2.Right_CanExecuteAsAnyParty_{
+			CanExecuteAsAnyParty: &adminv2.Right_CanExecuteAsAnyParty{},
+		}
 	}
-	return pb
+	return pb, nil
 }
 
 func rightsFromProto(pbs []*adminv2.Right) []*model.Right {
@@ -211,12 +327,16 @@ func rightsFromProto(pbs []*adminv2.Right) []*model.Right {
 	return rights
 }
 
-func rightsToProto(rights []*model.Right) []*adminv2.Right {
+func rightsToProto(rights []*model.Right) ([]*adminv2.Right, error) {
 	pbs := make([]*adminv2.Right, len(rights))
 	for i, r := range rights {
-		pbs[i] = rightToProto(r)
+		pb, err := rightToProto(r)
+		if err != nil {
+			return nil, err
+		}
+		pbs[i] = pb
 	}
-	return pbs
+	return pbs, nil
 }
 
 func usersFromProto(pbs []*adminv2.User) []*model.User {
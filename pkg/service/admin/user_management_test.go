@@ -0,0 +1,131 @@
+package admin_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	adminv2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2/admin"
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/admin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeUserManagementServer struct {
+	adminv2.UnimplementedUserManagementServiceServer
+	rights  []*adminv2.Right
+	granted []*adminv2.Right
+	revoked []*adminv2.Right
+}
+
+func (s *fakeUserManagementServer) GetUser(_ context.Context, req *adminv2.GetUserRequest) (*adminv2.GetUserResponse, error) {
+	return &adminv2.GetUserResponse{User: &adminv2.User{Id: req.UserId, IdentityProviderId: "idp-1"}}, nil
+}
+
+func (s *fakeUserManagementServer) ListUserRights(context.Context, *adminv2.ListUserRightsRequest) (*adminv2.ListUserRightsResponse, error) {
+	return &adminv2.ListUserRightsResponse{Rights: s.rights}, nil
+}
+
+func (s *fakeUserManagementServer) GrantUserRights(_ context.Context, req *adminv2.GrantUserRightsRequest) (*adminv2.GrantUserRightsResponse, error) {
+	s.granted = append(s.granted, req.Rights...)
+	s.rights = append(s.rights, req.Rights...)
+	return &adminv2.GrantUserRightsResponse{NewlyGrantedRights: req.Rights}, nil
+}
+
+func (s *fakeUserManagementServer) RevokeUserRights(_ context.Context, req *adminv2.RevokeUserRightsRequest) (*adminv2.RevokeUserRightsResponse, error) {
+	s.revoked = append(s.revoked, req.Rights...)
+	var kept []*adminv2.Right
+	for _, r := range s.rights {
+		revoke := false
+		for _, rr := range req.Rights {
+			if proto.Equal(r, rr) {
+				revoke = true
+			}
+		}
+		if !revoke {
+			kept = append(kept, r)
+		}
+	}
+	s.rights = kept
+	return &adminv2.RevokeUserRightsResponse{NewlyRevokedRights: req.Rights}, nil
+}
+
+func TestUserManagement_SetUserRights(t *testing.T) {
+	actAs := func(party string) *adminv2.Right {
+		return &adminv2.Right{Kind: &adminv2.Right_CanActAs_{CanActAs: &adminv2.Right_CanActAs{Party: party}}}
+	}
+	readAsAny := &adminv2.Right{Kind: &adminv2.Right_CanReadAsAnyParty_{CanReadAsAnyParty: &adminv2.Right_CanReadAsAnyParty{}}}
+
+	tests := []struct {
+		name          string
+		current       []*adminv2.Right
+		desired       []*model.Right
+		expectGranted int
+		expectRevoked int
+		expectErr     string
+	}{
+		{
+			name:    "already in sync",
+			current: []*adminv2.Right{actAs("alice::1220aa")},
+			desired: []*model.Right{{Type: model.CanActAs{Party: "alice::1220aa"}}},
+		},
+		{
+			name:    "grant and revoke",
+			current: []*adminv2.Right{actAs("alice::1220aa"), readAsAny},
+			desired: []*model.Right{
+				{Type: model.CanActAs{Party: "alice::1220aa"}},
+				{Type: model.CanExecuteAs{Party: "bob::1220aa"}},
+				{Type: model.CanExecuteAsAnyParty{}},
+			},
+			expectGranted: 2,
+			expectRevoked: 1,
+		},
+		{
+			name:          "revoke everything",
+			current:       []*adminv2.Right{actAs("alice::1220aa"), readAsAny},
+			expectRevoked: 2,
+		},
+		{
+			name:      "invalid party",
+			current:   []*adminv2.Right{readAsAny},
+			desired:   []*model.Right{{Type: model.CanReadAs{Party: "alice"}}},
+			expectErr: "invalid read-as right",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeUserManagementServer{rights: tt.current}
+			listener := bufconn.Listen(1024 * 1024)
+			grpcServer := grpc.NewServer()
+			adminv2.RegisterUserManagementServiceServer(grpcServer, server)
+			go func() { _ = grpcServer.Serve(listener) }()
+			defer grpcServer.Stop()
+
+			conn, err := grpc.NewClient("passthrough:///bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return listener.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			effective, err := admin.NewUserManagementClient(conn).SetUserRights(context.Background(), "user-1", tt.desired)
+			if tt.expectErr != "" {
+				require.ErrorContains(t, err, tt.expectErr)
+				require.Empty(t, server.granted)
+				require.Empty(t, server.revoked)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, server.granted, tt.expectGranted)
+			require.Len(t, server.revoked, tt.expectRevoked)
+			require.ElementsMatch(t, tt.desired, effective)
+		})
+	}
+}
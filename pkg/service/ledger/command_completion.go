@@ -28,6 +28,13 @@ func NewCommandCompletionClient(conn *grpc.ClientConn) *commandCompletion {
 }
 
 func (c *commandCompletion) CompletionStream(ctx context.Context, req *model.CompletionStreamRequest) (<-chan *model.CompletionStreamResponse, <-chan error) {
+	if err := model.ValidatePartyIDs(req.Parties); err != nil {
+		errCh := make(chan error, 1)
+		errCh <- err
+		close(errCh)
+		return nil, errCh
+	}
+
 	streamReq := &v2.CompletionStreamRequest{
 		UserId:         req.UserID,
 		Parties:        req.Parties,
@@ -94,6 +101,11 @@ func completionStreamResponseFromProto(pb *v2.CompletionStreamResponse) *model.C
 	return resp
 }
 
+// CompletionFromProto converts a Ledger API completion, as also returned by command inspection.
+func CompletionFromProto(pb *v2.Completion) model.Completion {
+	return completionFromProto(pb)
+}
+
 func completionFromProto(pb *v2.Completion) model.Completion {
 	comp := model.Completion{
 		CommandID:    pb.CommandId,
@@ -102,13 +114,18 @@ func completionFromProto(pb *v2.Completion) model.Completion {
 		Offset:       pb.Offset,
 	}
 
-	// Note: proto Completion doesn't have CompletedAt or TransactionID fields
+	// Note: proto Completion doesn't have a TransactionID field
 	// TransactionID would come from a separate transaction response
 
 	if pb.Status != nil {
 		comp.Status = statusFromProto(pb.Status)
 	}
 
+	if pb.SynchronizerTime != nil && pb.SynchronizerTime.RecordTime != nil {
+		t := pb.SynchronizerTime.RecordTime.AsTime()
+		comp.CompletedAt = &t
+	}
+
 	return comp
 }
 
@@ -27,8 +27,12 @@ func NewCommandServiceClient(conn *grpc.ClientConn) *commandService {
 }
 
 func (c *commandService) SubmitAndWait(ctx context.Context, req *model.SubmitAndWaitRequest) (*model.SubmitAndWaitResponse, error) {
+	commands, err := commandsToProto(req.Commands)
+	if err != nil {
+		return nil, err
+	}
 	protoReq := &v2.SubmitAndWaitRequest{
-		Commands: commandsToProto(req.Commands),
+		Commands: commands,
 	}
 
 	resp, err := c.client.SubmitAndWait(ctx, protoReq)
@@ -45,8 +49,12 @@ func (c *commandService) SubmitAndWait(ctx context.Context, req *model.SubmitAnd
 // SubmitAndWaitForTransaction implementation
 func (c *commandService) SubmitAndWaitForTransaction(ctx context.Context, req *model.SubmitAndWaitRequest) (*model.SubmitAndWaitForTransactionResponse, error) {
 	// The request structure for both Wait and WaitForTransaction is identical in terms of commands
+	commands, err := commandsToProto(req.Commands)
+	if err != nil {
+		return nil, err
+	}
 	protoReq := &v2.SubmitAndWaitForTransactionRequest{
-		Commands: commandsToProto(req.Commands),
+		Commands: commands,
 	}
 
 	resp, err := c.client.SubmitAndWaitForTransaction(ctx, protoReq)
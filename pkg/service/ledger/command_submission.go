@@ -25,11 +25,15 @@ func NewCommandSubmissionClient(conn *grpc.ClientConn) *commandSubmission {
 }
 
 func (c *commandSubmission) Submit(ctx context.Context, req *model.SubmitRequest) (*model.SubmitResponse, error) {
+	commands, err := commandsToProto(req.Commands)
+	if err != nil {
+		return nil, err
+	}
 	protoReq := &v2.SubmitRequest{
-		Commands: commandsToProto(req.Commands),
+		Commands: commands,
 	}
 
-	_, err := c.client.Submit(ctx, protoReq)
+	_, err = c.client.Submit(ctx, protoReq)
 	if err != nil {
 		return nil, err
 	}
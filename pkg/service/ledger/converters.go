@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,31 +23,44 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-var defaultJsonCodec = codec.NewJsonCodec()
+var (
+	defaultJsonCodec  = codec.NewJsonCodec()
+	defaultProtoCodec = codec.NewProtoCodec()
+)
 
-func ConvertToRecord(data any) *v2.Record {
+// ConvertToRecord converts create arguments the way the command service does.
+func ConvertToRecord(data any) (*v2.Record, error) {
 	return convertToRecord(data)
 }
 
 // MapToValue exposes the internal any->Value conversion (useful for choice args later).
-func MapToValue(v interface{}) *v2.Value {
+func MapToValue(v interface{}) (*v2.Value, error) {
 	return mapToValue(v)
 }
 
+// ValueFromProto exposes the internal Value->any conversion.
+func ValueFromProto(v *v2.Value) interface{} {
+	return valueFromProto(v)
+}
+
+// RecordToMap exposes the internal Record->map conversion.
+func RecordToMap(r *v2.Record) map[string]interface{} {
+	return valueFromRecord(r)
+}
+
+// IdentifierToString formats a template or interface identifier as package:module:entity.
+func IdentifierToString(id *v2.Identifier) string {
+	return identifierToString(id)
+}
+
+var anyTupleType = reflect.TypeOf((*types.AnyTuple)(nil)).Elem()
+
 func isTuple2(v reflect.Value) bool {
-	if v.Kind() == reflect.Struct && v.NumField() == 2 {
-		t := v.Type()
-		return t.Field(0).Name == "First" && t.Field(1).Name == "Second"
-	}
-	return false
+	return v.Kind() == reflect.Struct && v.NumField() == 2 && v.Type().Implements(anyTupleType)
 }
 
 func isTuple3(v reflect.Value) bool {
-	if v.Kind() == reflect.Struct && v.NumField() == 3 {
-		t := v.Type()
-		return t.Field(0).Name == "First" && t.Field(1).Name == "Second" && t.Field(2).Name == "Third"
-	}
-	return false
+	return v.Kind() == reflect.Struct && v.NumField() == 3 && v.Type().Implements(anyTupleType)
 }
 
 func parseTemplateID(templateID string) (packageID, moduleName, entityName string) {
@@ -79,12 +93,24 @@ func isPackageHash(s string) bool {
 	return true
 }
 
-func commandsToProto(cmd *model.Commands) *v2.Commands {
+func commandsToProto(cmd *model.Commands) (*v2.Commands, error) {
+	if err := model.ValidatePartyIDs(cmd.ActAs); err != nil {
+		return nil, fmt.Errorf("invalid act-as party: %w", err)
+	}
+	if err := model.ValidatePartyIDs(cmd.ReadAs); err != nil {
+		return nil, fmt.Errorf("invalid read-as party: %w", err)
+	}
+
+	commands, err := commandsArrayToProto(cmd.Commands)
+	if err != nil {
+		return nil, err
+	}
+
 	pbCmd := &v2.Commands{
 		WorkflowId:         cmd.WorkflowID,
 		UserId:             cmd.UserID,
 		CommandId:          cmd.CommandID,
-		Commands:           commandsArrayToProto(cmd.Commands),
+		Commands:           commands,
 		ActAs:              cmd.ActAs,
 		ReadAs:             cmd.ReadAs,
 		SubmissionId:       cmd.SubmissionID,
@@ -110,23 +136,31 @@ func commandsToProto(cmd *model.Commands) *v2.Commands {
 		}
 	}
 
-	return pbCmd
+	return pbCmd, nil
 }
 
-func commandsArrayToProto(cmds []*model.Command) []*v2.Command {
+func commandsArrayToProto(cmds []*model.Command) ([]*v2.Command, error) {
 	result := make([]*v2.Command, len(cmds))
 	for i, cmd := range cmds {
-		result[i] = commandToProto(cmd)
+		pbCmd, err := commandToProto(cmd)
+		if err != nil {
+			return nil, fmt.Errorf("command %d: %w", i, err)
+		}
+		result[i] = pbCmd
 	}
-	return result
+	return result, nil
 }
 
-func commandToProto(cmd *model.Command) *v2.Command {
+func commandToProto(cmd *model.Command) (*v2.Command, error) {
 	pbCmd := &v2.Command{}
 
 	switch c := cmd.Command.(type) {
 	case *model.CreateCommand:
 		packageID, moduleName, entityName := parseTemplateID(c.TemplateID)
+		args, err := convertToRecord(c.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert create arguments of %s: %w", c.TemplateID, err)
+		}
 		pbCmd.Command = &v2.Command_Create{
 			Create: &v2.CreateCommand{
 				TemplateId: &v2.Identifier{
@@ -134,11 +168,15 @@ func commandToProto(cmd *model.Command) *v2.Command {
 					ModuleName: moduleName,
 					EntityName: entityName,
 				},
-				CreateArguments: convertToRecord(c.Arguments),
+				CreateArguments: args,
 			},
 		}
 	case *model.ExerciseCommand:
 		packageID, moduleName, entityName := parseTemplateID(c.TemplateID)
+		arg, err := mapToValue(c.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert argument of choice %s: %w", c.Choice, err)
+		}
 		pbCmd.Command = &v2.Command_Exercise{
 			Exercise: &v2.ExerciseCommand{
 				ContractId: c.ContractID,
@@ -148,11 +186,19 @@ func commandToProto(cmd *model.Command) *v2.Command {
 					EntityName: entityName,
 				},
 				Choice:         c.Choice,
-				ChoiceArgument: mapToValue(c.Arguments),
+				ChoiceArgument: arg,
 			},
 		}
 	case *model.ExerciseByKeyCommand:
 		packageID, moduleName, entityName := parseTemplateID(c.TemplateID)
+		key, err := mapToValue(c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert contract key of %s: %w", c.TemplateID, err)
+		}
+		arg, err := mapToValue(c.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert argument of choice %s: %w", c.Choice, err)
+		}
 		pbCmd.Command = &v2.Command_ExerciseByKey{
 			ExerciseByKey: &v2.ExerciseByKeyCommand{
 				TemplateId: &v2.Identifier{
@@ -160,14 +206,14 @@ func commandToProto(cmd *model.Command) *v2.Command {
 					ModuleName: moduleName,
 					EntityName: entityName,
 				},
-				ContractKey:    mapToValue(c.Key),
+				ContractKey:    key,
 				Choice:         c.Choice,
-				ChoiceArgument: mapToValue(c.Arguments),
+				ChoiceArgument: arg,
 			},
 		}
 	}
 
-	return pbCmd
+	return pbCmd, nil
 }
 
 func filtersToProto(filters *model.Filters) *v2.Filters {
@@ -351,11 +397,6 @@ func archivedEventFromProto(pb *v2.ArchivedEvent) *model.ArchivedEvent {
 	return event
 }
 
-func convertBigIntToNumeric(i *big.Int, scale int) *big.Rat {
-	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
-	return new(big.Rat).SetFrac(i, den)
-}
-
 func valueFromProto(pb *v2.Value) interface{} {
 	if pb == nil {
 		return nil
@@ -371,15 +412,27 @@ func valueFromProto(pb *v2.Value) interface{} {
 	case *v2.Value_Text:
 		return v.Text
 	case *v2.Value_Numeric:
-		return v.Numeric
+		n, err := types.ParseNumeric(v.Numeric)
+		if err != nil {
+			return v.Numeric
+		}
+		return n
 	case *v2.Value_Party:
 		return v.Party
 	case *v2.Value_ContractId:
 		return v.ContractId
 	case *v2.Value_Date:
-		return v.Date
+		d, err := types.DateFromDays(v.Date)
+		if err != nil {
+			return v.Date
+		}
+		return d
 	case *v2.Value_Timestamp:
-		return v.Timestamp
+		t, err := types.TimestampFromMicros(v.Timestamp)
+		if err != nil {
+			return v.Timestamp
+		}
+		return t
 	case *v2.Value_Optional:
 		if v.Optional.Value != nil {
 			return valueFromProto(v.Optional.Value)
@@ -423,12 +476,10 @@ func valueFromProto(pb *v2.Value) interface{} {
 		}
 		return nil
 	case *v2.Value_GenMap:
-		// GenMap as map for DAML codec
-		result := make(map[string]interface{})
-		for _, entry := range v.GenMap.Entries {
-			// Convert key to string for map key
-			keyStr := fmt.Sprintf("%v", valueFromProto(entry.Key))
-			result[keyStr] = valueFromProto(entry.Value)
+		// keys may be records or variants, so entries stay a list in ledger order
+		result := make([]types.AnyEntry, len(v.GenMap.Entries))
+		for i, entry := range v.GenMap.Entries {
+			result[i] = types.AnyEntry{Key: valueFromProto(entry.Key), Value: valueFromProto(entry.Value)}
 		}
 		return result
 	default:
@@ -436,113 +487,54 @@ func valueFromProto(pb *v2.Value) interface{} {
 	}
 }
 
-func normalizeLedgerNumericLiteral(s string) (string, bool) {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return "", false
-	}
-	// allow printers that emit "123."
-	if strings.HasSuffix(s, ".") {
-		s = strings.TrimSuffix(s, ".")
-		if s == "" || s == "-" {
-			return "", false
-		}
-	}
-
-	dot := false
-	for i, ch := range s {
-		if ch == '-' {
-			if i != 0 {
-				return "", false
-			}
-			continue
-		}
-		if ch == '.' {
-			if dot {
-				return "", false
-			}
-			dot = true
-			continue
-		}
-		if ch < '0' || ch > '9' {
-			return "", false
-		}
+func numericToValue(n types.Numeric) (*v2.Value, error) {
+	if n.IsZero() {
+		return nil, fmt.Errorf("numeric value is not set")
 	}
-
-	// Clean: strip ".0000..." => integer
-	if dot {
-		parts := strings.SplitN(s, ".", 2)
-		frac := strings.TrimRight(parts[1], "0")
-		if frac == "" {
-			return parts[0], true
-		}
-		return parts[0] + "." + frac, true
+	if err := n.Validate(); err != nil {
+		return nil, err
 	}
-
-	return s, true
+	return &v2.Value{Sum: &v2.Value_Numeric{Numeric: n.String()}}, nil
 }
 
-func mapToValue(data interface{}) *v2.Value {
+// mapToValue converts a Go value to a ledger value. It returns nil without an error for types
+// it does not support, and an error for values that cannot be encoded.
+func mapToValue(data interface{}) (*v2.Value, error) {
 	if data == nil {
-		return nil
+		return nil, nil
+	}
+
+	// generated types convert themselves
+	if encoder, ok := data.(codec.ValueEncoder); ok {
+		return encoder.ToValue()
 	}
 
 	// handle custom pointer types first before dereferencing
 	switch v := data.(type) {
 	case decimal.Decimal:
-		scaled := types.NewNumericFromDecimal(v)
-		s, ok := normalizeLedgerNumericLiteral(string(scaled))
-		if !ok {
-			log.Warn().Msgf("invalid NumericLiteral from decimal %q", string(scaled))
-			return nil
+		n, err := types.NewNumericFromDecimal(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Numeric from decimal %s: %w", v, err)
 		}
-		return &v2.Value{Sum: &v2.Value_Numeric{Numeric: s}}
-	case types.NUMERIC:
-		s, ok := normalizeLedgerNumericLiteral(string(v))
-		if !ok {
-			log.Warn().Msgf("invalid NumericLiteral %q", string(v))
-			return nil
-		}
-		return &v2.Value{Sum: &v2.Value_Numeric{Numeric: s}}
-	case types.DECIMAL:
-		return &v2.Value{Sum: &v2.Value_Numeric{Numeric: convertBigIntToNumeric((*big.Int)(v), 10).FloatString(10)}}
+		return numericToValue(n)
+	case types.Numeric:
+		return numericToValue(v)
 	case *big.Int:
-		return &v2.Value{Sum: &v2.Value_Numeric{Numeric: convertBigIntToNumeric(v, 10).FloatString(10)}}
+		// a bare *big.Int is the unscaled value of a legacy Decimal
+		n, err := types.NewNumeric(v, types.DecimalScale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Decimal %s: %w", v, err)
+		}
+		return numericToValue(n)
 	case types.RELTIME:
 		microseconds := int64(time.Duration(v) / time.Microsecond)
-		return &v2.Value{Sum: &v2.Value_Int64{Int64: microseconds}}
-	case types.SET:
-		elements := make([]*v2.Value, len(v))
-		for i, elem := range v {
-			elements[i] = mapToValue(elem)
-		}
-		return &v2.Value{
-			Sum: &v2.Value_List{
-				List: &v2.List{Elements: elements},
-			},
-		}
-	case []types.INT64, []types.TEXT, []types.BOOL, []int64, []string:
-		rv := reflect.ValueOf(v)
-		elements := make([]*v2.Value, rv.Len())
-		for i := 0; i < rv.Len(); i++ {
-			elements[i] = mapToValue(rv.Index(i).Interface())
-		}
-		return &v2.Value{
-			Sum: &v2.Value_List{
-				List: &v2.List{Elements: elements},
-			},
-		}
-	case types.LIST:
-		return &v2.Value{Sum: &v2.Value_List{List: &v2.List{Elements: mapValues(v)}}}
+		return &v2.Value{Sum: &v2.Value_Int64{Int64: microseconds}}, nil
+	case types.SET, []types.INT64, []types.TEXT, []types.BOOL, []int64, []string, types.LIST:
+		return listToValue(reflect.ValueOf(v))
+	case []types.AnyEntry:
+		return getMapConvert(v)
 	case types.VARIANT:
-		return &v2.Value{
-			Sum: &v2.Value_Variant{
-				Variant: &v2.Variant{
-					Constructor: v.GetVariantTag(),
-					Value:       mapToValue(v.GetVariantValue()),
-				},
-			},
-		}
+		return variantToValue(v)
 	case types.ENUM:
 		return &v2.Value{
 			Sum: &v2.Value_Enum{
@@ -550,88 +542,89 @@ func mapToValue(data interface{}) *v2.Value {
 					Constructor: v.GetEnumConstructor(),
 				},
 			},
-		}
+		}, nil
 	}
 
 	// handle pointers by dereferencing them
 	if reflect.TypeOf(data).Kind() == reflect.Ptr {
 		val := reflect.ValueOf(data)
 		if val.IsNil() {
-			return nil
+			return nil, nil
 		}
 		return mapToValue(val.Elem().Interface())
 	}
 
-	rv := reflect.ValueOf(data)
-	if isTuple2(rv) {
-		first := rv.Field(0).Interface()
-		second := rv.Field(1).Interface()
-		fields := []*v2.RecordField{
-			{Label: "_1", Value: mapToValue(first)},
-			{Label: "_2", Value: mapToValue(second)},
+	switch v := data.(type) {
+	case types.AnyOptional:
+		value, some := v.AnyValue()
+		if !some {
+			return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{}}}, nil
+		}
+		return optionalToValue(value)
+	case types.AnyGenMap:
+		return getMapConvert(v.AnyEntries())
+	case types.AnyTextMap:
+		return getTextMapConvert(v.AnyTextEntries())
+	case types.AnySet:
+		// DA.Set.Set is a record whose map field is a GenMap from the items to unit
+		entries := make([]*v2.GenMap_Entry, 0, len(v.AnyItems()))
+		for _, item := range v.AnyItems() {
+			key, err := mapToValue(item)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, &v2.GenMap_Entry{
+				Key:   key,
+				Value: &v2.Value{Sum: &v2.Value_Unit{Unit: &emptypb.Empty{}}},
+			})
 		}
-		return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: fields}}}
+		genMap := &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: entries}}}
+		return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: []*v2.RecordField{{Label: "map", Value: genMap}}}}}, nil
 	}
-	if isTuple3(rv) {
-		first := rv.Field(0).Interface()
-		second := rv.Field(1).Interface()
-		third := rv.Field(2).Interface()
-		fields := []*v2.RecordField{
-			{Label: "_1", Value: mapToValue(first)},
-			{Label: "_2", Value: mapToValue(second)},
-			{Label: "_3", Value: mapToValue(third)},
+
+	rv := reflect.ValueOf(data)
+	if isTuple2(rv) || isTuple3(rv) {
+		fields := make([]*v2.RecordField, rv.NumField())
+		for i := range fields {
+			value, err := mapToValue(rv.Field(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("field _%d: %w", i+1, err)
+			}
+			fields[i] = &v2.RecordField{Label: fmt.Sprintf("_%d", i+1), Value: value}
 		}
-		return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: fields}}}
+		return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: fields}}}, nil
 	}
 
 	// Handle custom types before other type checking
 	switch v := data.(type) {
 	case types.TEXTMAP:
-		entries := make([]*v2.TextMap_Entry, 0, len(v))
-		for k, val := range v {
-			entries = append(entries, &v2.TextMap_Entry{
-				Key:   k,
-				Value: mapToValue(val),
-			})
-		}
-		return &v2.Value{
-			Sum: &v2.Value_TextMap{
-				TextMap: &v2.TextMap{Entries: entries},
-			},
-		}
-
+		return getTextMapConvert(v)
 	case types.INT64:
-		return &v2.Value{Sum: &v2.Value_Int64{Int64: int64(v)}}
+		return &v2.Value{Sum: &v2.Value_Int64{Int64: int64(v)}}, nil
 	case types.TEXT:
-		return &v2.Value{Sum: &v2.Value_Text{Text: string(v)}}
+		return &v2.Value{Sum: &v2.Value_Text{Text: string(v)}}, nil
 	case types.BOOL:
-		return &v2.Value{Sum: &v2.Value_Bool{Bool: bool(v)}}
+		return &v2.Value{Sum: &v2.Value_Bool{Bool: bool(v)}}, nil
 	case types.PARTY:
-		return &v2.Value{Sum: &v2.Value_Party{Party: string(v)}}
+		return &v2.Value{Sum: &v2.Value_Party{Party: string(v)}}, nil
 	case types.CONTRACT_ID:
-		return &v2.Value{Sum: &v2.Value_ContractId{ContractId: string(v)}}
+		return &v2.Value{Sum: &v2.Value_ContractId{ContractId: string(v)}}, nil
 	case types.DATE:
-		return &v2.Value{Sum: &v2.Value_Date{Date: int32((time.Time)(v).Unix() / 86400)}}
+		return codec.DateValue(v)
+	case types.Date:
+		return codec.CivilDateValue(v)
 	case types.TIMESTAMP:
-		return &v2.Value{Sum: &v2.Value_Timestamp{Timestamp: int64((time.Time)(v).Unix())}}
+		return codec.TimestampValue(v)
 	case bool:
-		return &v2.Value{Sum: &v2.Value_Bool{Bool: v}}
+		return &v2.Value{Sum: &v2.Value_Bool{Bool: v}}, nil
 	case int64:
-		return &v2.Value{Sum: &v2.Value_Int64{Int64: v}}
+		return &v2.Value{Sum: &v2.Value_Int64{Int64: v}}, nil
 	case int:
-		return &v2.Value{Sum: &v2.Value_Int64{Int64: int64(v)}}
+		return &v2.Value{Sum: &v2.Value_Int64{Int64: int64(v)}}, nil
 	case string:
-		return &v2.Value{Sum: &v2.Value_Text{Text: v}}
+		return &v2.Value{Sum: &v2.Value_Text{Text: v}}, nil
 	case []interface{}:
-		elements := make([]*v2.Value, len(v))
-		for i, elem := range v {
-			elements[i] = mapToValue(elem)
-		}
-		return &v2.Value{
-			Sum: &v2.Value_List{
-				List: &v2.List{Elements: elements},
-			},
-		}
+		return listToValue(reflect.ValueOf(v))
 	case map[string]interface{}:
 		if typeVal, hasType := v["_type"]; hasType && typeVal == "optional" {
 			val, ok := v["value"]
@@ -642,33 +635,29 @@ func mapToValue(data interface{}) *v2.Value {
 							Value: nil,
 						},
 					},
-				}
-			}
-
-			return &v2.Value{
-				Sum: &v2.Value_Optional{
-					Optional: &v2.Optional{
-						Value: mapToValue(val),
-					},
-				},
+				}, nil
 			}
+			return optionalToValue(val)
 		}
 
 		if typeStr, ok := v["_type"].(string); ok && typeStr == "unit" {
-			return &v2.Value{Sum: &v2.Value_Unit{Unit: &emptypb.Empty{}}}
+			return &v2.Value{Sum: &v2.Value_Unit{Unit: &emptypb.Empty{}}}, nil
 		}
 
 		if typeStr, ok := v["_type"].(string); ok && typeStr == "party" {
 			if partyValue, ok := v["value"].(string); ok {
-				return &v2.Value{Sum: &v2.Value_Party{Party: partyValue}}
+				return &v2.Value{Sum: &v2.Value_Party{Party: partyValue}}, nil
 			}
 		}
 
 		if typeStr, ok := v["_type"].(string); ok && typeStr == "genmap" {
-			if mapValue, ok := v["value"].(map[string]interface{}); ok {
-				return getMapConvert(mapValue)
-			} else if genMapValue, ok := v["value"].(types.GENMAP); ok {
-				return getMapConvert(genMapValue)
+			switch mv := v["value"].(type) {
+			case []types.AnyEntry:
+				return getMapConvert(mv)
+			case map[string]interface{}:
+				return getMapConvert(textKeyEntries(mv))
+			case types.GENMAP:
+				return getMapConvert(textKeyEntries(mv))
 			}
 		}
 
@@ -684,21 +673,20 @@ func mapToValue(data interface{}) *v2.Value {
 				}
 				return getTextMapConvert(tmp)
 			case types.TEXTMAP:
-				// TEXTMAP is a map-like alias; convert it
-				tmp := make(map[string]interface{}, len(mv))
-				for k, val := range mv {
-					tmp[k] = val
-				}
-				return getTextMapConvert(tmp)
+				return getTextMapConvert(mv)
 			}
 		}
 
 		fields := make([]*v2.RecordField, 0, len(v))
 		for key, val := range v {
 			if key != "_type" && key != "value" {
+				value, err := mapToValue(val)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", key, err)
+				}
 				fields = append(fields, &v2.RecordField{
 					Label: key,
-					Value: mapToValue(val),
+					Value: value,
 				})
 			}
 		}
@@ -706,34 +694,26 @@ func mapToValue(data interface{}) *v2.Value {
 			Sum: &v2.Value_Record{
 				Record: &v2.Record{Fields: fields},
 			},
-		}
+		}, nil
 	case time.Time:
-		return &v2.Value{Sum: &v2.Value_Date{Date: int32(v.Unix() / 86400)}}
+		// a plain time.Time is a Timestamp; wrap it in types.DATE to send a Date
+		return codec.TimestampValue(types.TIMESTAMP(v))
 	case interface{}:
 		// Check if it implements VARIANT interface
 		if variant, ok := v.(types.VARIANT); ok {
-			return &v2.Value{
-				Sum: &v2.Value_Variant{
-					Variant: &v2.Variant{
-						Constructor: variant.GetVariantTag(),
-						Value:       mapToValue(variant.GetVariantValue()),
-					},
-				},
-			}
+			return variantToValue(variant)
 		}
 
 		// Handle generic slices
 		rv := reflect.ValueOf(v)
 		if rv.Kind() == reflect.Slice {
-			elements := make([]*v2.Value, rv.Len())
-			for i := 0; i < rv.Len(); i++ {
-				elements[i] = mapToValue(rv.Index(i).Interface())
-			}
-			return &v2.Value{
-				Sum: &v2.Value_List{
-					List: &v2.List{Elements: elements},
-				},
-			}
+			return listToValue(rv)
+		}
+
+		// Statically typed structs go through the codec. Structs holding interface values keep the
+		// map conversion, which understands the "_type" maps.
+		if rv.Kind() == reflect.Struct && !defaultProtoCodec.IsDynamic(rv.Type()) {
+			return defaultProtoCodec.ToValue(v)
 		}
 
 		// Check if the value has a ToMap() method
@@ -748,17 +728,65 @@ func mapToValue(data interface{}) *v2.Value {
 
 		return mapToValue(structToMap(v))
 	default:
-		return nil
+		return nil, nil
 	}
 }
 
-func getMapConvert(genMapValue map[string]interface{}) *v2.Value {
-	entries := make([]*v2.GenMap_Entry, 0, len(genMapValue))
-	for key, val := range genMapValue {
-		entries = append(entries, &v2.GenMap_Entry{
-			Key:   &v2.Value{Sum: &v2.Value_Text{Text: key}},
-			Value: mapToValue(val),
-		})
+func optionalToValue(data interface{}) (*v2.Value, error) {
+	value, err := mapToValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return &v2.Value{Sum: &v2.Value_Optional{Optional: &v2.Optional{Value: value}}}, nil
+}
+
+func variantToValue(variant types.VARIANT) (*v2.Value, error) {
+	value, err := mapToValue(variant.GetVariantValue())
+	if err != nil {
+		return nil, fmt.Errorf("constructor %s: %w", variant.GetVariantTag(), err)
+	}
+	return &v2.Value{
+		Sum: &v2.Value_Variant{
+			Variant: &v2.Variant{
+				Constructor: variant.GetVariantTag(),
+				Value:       value,
+			},
+		},
+	}, nil
+}
+
+func listToValue(rv reflect.Value) (*v2.Value, error) {
+	elements := make([]*v2.Value, rv.Len())
+	for i := range elements {
+		value, err := mapToValue(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		elements[i] = value
+	}
+	return &v2.Value{
+		Sum: &v2.Value_List{
+			List: &v2.List{Elements: elements},
+		},
+	}, nil
+}
+
+// getMapConvert converts GenMap entries, keeping their order.
+func getMapConvert(genMapEntries []types.AnyEntry) (*v2.Value, error) {
+	entries := make([]*v2.GenMap_Entry, len(genMapEntries))
+	for i, entry := range genMapEntries {
+		key, err := mapToValue(entry.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		value, err := mapToValue(entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		entries[i] = &v2.GenMap_Entry{
+			Key:   key,
+			Value: value,
+		}
 	}
 	return &v2.Value{
 		Sum: &v2.Value_GenMap{
@@ -766,15 +794,34 @@ func getMapConvert(genMapValue map[string]interface{}) *v2.Value {
 				Entries: entries,
 			},
 		},
+	}, nil
+}
+
+// textKeyEntries turns a map keyed by text into GenMap entries sorted by key.
+func textKeyEntries(m map[string]interface{}) []types.AnyEntry {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]types.AnyEntry, len(keys))
+	for i, k := range keys {
+		entries[i] = types.AnyEntry{Key: k, Value: m[k]}
 	}
+	return entries
 }
 
-func getTextMapConvert(values map[string]interface{}) *v2.Value {
+func getTextMapConvert(values map[string]interface{}) (*v2.Value, error) {
 	entries := make([]*v2.TextMap_Entry, 0, len(values))
 	for key, val := range values {
+		value, err := mapToValue(val)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key, err)
+		}
 		entries = append(entries, &v2.TextMap_Entry{
 			Key:   key,
-			Value: mapToValue(val),
+			Value: value,
 		})
 	}
 	return &v2.Value{
@@ -783,7 +830,7 @@ func getTextMapConvert(values map[string]interface{}) *v2.Value {
 				Entries: entries,
 			},
 		},
-	}
+	}, nil
 }
 
 func structToMap(v interface{}) map[string]interface{} {
@@ -863,17 +910,9 @@ func structToMap(v interface{}) map[string]interface{} {
 	return result
 }
 
-func mapValues(values []string) []*v2.Value {
-	result := make([]*v2.Value, len(values))
-	for i, v := range values {
-		result[i] = mapToValue(v)
-	}
-	return result
-}
-
-func convertToRecord(data any) *v2.Record {
+func convertToRecord(data any) (*v2.Record, error) {
 	if data == nil {
-		return nil
+		return nil, nil
 	}
 
 	// If it's already a map, keep existing behavior
@@ -885,41 +924,55 @@ func convertToRecord(data any) *v2.Record {
 	rv := reflect.ValueOf(data)
 	if rv.Kind() == reflect.Ptr {
 		if rv.IsNil() {
-			return nil
+			return nil, nil
 		}
 		data = rv.Elem().Interface()
 		rv = reflect.ValueOf(data)
 	}
 
-	// If it's a struct, convert it to map using json tags (existing helper)
-	if rv.Kind() == reflect.Struct {
-		return mapToRecord(structToMap(data))
+	if encoder, ok := data.(codec.ValueEncoder); ok {
+		value, err := encoder.ToValue()
+		if err != nil {
+			return nil, err
+		}
+		if record := value.GetRecord(); record != nil {
+			return record, nil
+		}
+	}
+
+	// Convert statically typed structs directly. Structs holding interface values keep the map
+	// conversion, which understands the "_type" maps and treats their pointers as plain values.
+	if rv.Kind() == reflect.Struct && !defaultProtoCodec.IsDynamic(rv.Type()) {
+		return defaultProtoCodec.ToRecord(data)
 	}
 
-	// If it's some other type but can still be mapped by structToMap fallback
-	// (structToMap already does json marshal/unmarshal for non-struct)
+	// Anything else, including dynamic structs, goes through structToMap, which also
+	// json marshals and unmarshals non-struct values
 	return mapToRecord(structToMap(data))
 }
 
-func mapToRecord(data map[string]interface{}) *v2.Record {
+func mapToRecord(data map[string]interface{}) (*v2.Record, error) {
 	if data == nil {
-		return nil
+		return nil, nil
 	}
 
 	fields := make([]*v2.RecordField, 0, len(data))
 	for key, val := range data {
-		val := mapToValue(val)
-		if val == nil {
-			log.Warn().Msgf("unsupported type %s for field %s, ignoring", reflect.TypeOf(val), key)
+		value, err := mapToValue(val)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", key, err)
+		}
+		if value == nil {
+			log.Warn().Msgf("unsupported type %T for field %s, ignoring", val, key)
 			continue
 		}
 		fields = append(fields, &v2.RecordField{
 			Label: key,
-			Value: val,
+			Value: value,
 		})
 	}
 
-	return &v2.Record{Fields: fields}
+	return &v2.Record{Fields: fields}, nil
 }
 
 func valueFromRecord(record *v2.Record) map[string]interface{} {
@@ -952,6 +1005,15 @@ func recordToStruct(record *v2.Record, target interface{}) error {
 		return fmt.Errorf("target pointer cannot be nil")
 	}
 
+	if decoder, ok := target.(codec.ValueDecoder); ok {
+		return decoder.FromValue(&v2.Value{Sum: &v2.Value_Record{Record: record}})
+	}
+	// Statically typed targets go through the codec; targets holding interface values are
+	// filled from the JSON form of the record
+	if !defaultProtoCodec.IsDynamic(rv.Elem().Type()) {
+		return defaultProtoCodec.FromRecord(record, target)
+	}
+
 	recordMap := valueFromRecord(record)
 
 	jsonData, err := json.Marshal(recordMap)
@@ -1009,18 +1071,34 @@ func MapToStruct(data map[string]interface{}, target interface{}) error {
 	return nil
 }
 
-func prepareSubmissionRequestToProto(req *model.PrepareSubmissionRequest) *interactive.PrepareSubmissionRequest {
+func prepareSubmissionRequestToProto(req *model.PrepareSubmissionRequest) (*interactive.PrepareSubmissionRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
+	}
+	if req.SynchronizerID != "" {
+		if err := req.SynchronizerID.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := model.ValidatePartyIDs(req.ActAs); err != nil {
+		return nil, fmt.Errorf("invalid act-as party: %w", err)
+	}
+	if err := model.ValidatePartyIDs(req.ReadAs); err != nil {
+		return nil, fmt.Errorf("invalid read-as party: %w", err)
+	}
+
+	commands, err := commandsArrayToProto(req.Commands)
+	if err != nil {
+		return nil, err
 	}
 
 	pbReq := &interactive.PrepareSubmissionRequest{
 		UserId:                       req.UserID,
 		CommandId:                    req.CommandID,
-		Commands:                     commandsArrayToProto(req.Commands),
+		Commands:                     commands,
 		ActAs:                        req.ActAs,
 		ReadAs:                       req.ReadAs,
-		SynchronizerId:               req.SynchronizerID,
+		SynchronizerId:               string(req.SynchronizerID),
 		PackageIdSelectionPreference: req.PackageIDSelectionPreference,
 		VerboseHashing:               req.VerboseHashing,
 	}
@@ -1034,10 +1112,12 @@ func prepareSubmissionRequestToProto(req *model.PrepareSubmissionRequest) *inter
 	}
 
 	if req.PrefetchContractKeys != nil {
-		pbReq.PrefetchContractKeys = prefetchContractKeysToProto(req.PrefetchContractKeys)
+		if pbReq.PrefetchContractKeys, err = prefetchContractKeysToProto(req.PrefetchContractKeys); err != nil {
+			return nil, err
+		}
 	}
 
-	return pbReq
+	return pbReq, nil
 }
 
 func minLedgerTimeToProto(mlt *model.MinLedgerTime) *interactive.MinLedgerTime {
@@ -1086,31 +1166,35 @@ func disclosedContractToProto(contract *model.DisclosedContract) *v2.DisclosedCo
 			EntityName: entityName,
 		},
 		ContractId:       contract.ContractID,
-		SynchronizerId:   contract.SynchronizerID,
+		SynchronizerId:   string(contract.SynchronizerID),
 		CreatedEventBlob: contract.CreatedEventBlob,
 	}
 
 	return pbContract
 }
 
-func prefetchContractKeysToProto(keys []*model.PrefetchContractKey) []*v2.PrefetchContractKey {
+func prefetchContractKeysToProto(keys []*model.PrefetchContractKey) ([]*v2.PrefetchContractKey, error) {
 	if keys == nil {
-		return nil
+		return nil, nil
 	}
 
 	result := make([]*v2.PrefetchContractKey, len(keys))
 	for i, key := range keys {
 		packageID, moduleName, entityName := parseTemplateID(key.TemplateID)
+		contractKey, err := mapToValue(key.ContractKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert contract key of %s: %w", key.TemplateID, err)
+		}
 		result[i] = &v2.PrefetchContractKey{
 			TemplateId: &v2.Identifier{
 				PackageId:  packageID,
 				ModuleName: moduleName,
 				EntityName: entityName,
 			},
-			ContractKey: mapToValue(key.ContractKey),
+			ContractKey: contractKey,
 		}
 	}
-	return result
+	return result, nil
 }
 
 func prepareSubmissionResponseFromProto(pb *interactive.PrepareSubmissionResponse) *model.PrepareSubmissionResponse {
@@ -1315,6 +1399,7 @@ func transactionToModel(pb *v2.Transaction) *model.Transaction {
 		WorkflowID:  pb.WorkflowId,
 		CommandID:   pb.CommandId,
 		EffectiveAt: protoTimeToPointer(pb.EffectiveAt),
+		RecordTime:  protoTimeToPointer(pb.RecordTime),
 		Events:      eventsFromProto(pb.Events),
 	}
 }
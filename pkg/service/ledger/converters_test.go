@@ -122,11 +122,12 @@ var (
 
 func TestConvertToRecordBasic(t *testing.T) {
 	t.Run("Numeric", func(t *testing.T) {
-		decimalValue := types.NUMERIC("0.0000000200")
+		decimalValue := types.MustParseNumeric("0.0000000200")
 		data := make(map[string]interface{})
 		data["someNumeric"] = decimalValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
 		require.Equal(t, "someNumeric", record.Fields[0].Label)
@@ -138,11 +139,13 @@ func TestConvertToRecordBasic(t *testing.T) {
 	})
 
 	t.Run("Decimal", func(t *testing.T) {
-		decimalValue := types.DECIMAL(big.NewInt(200))
+		decimalValue, err := types.NewNumeric(big.NewInt(200), types.DecimalScale)
+		require.NoError(t, err)
 		data := make(map[string]interface{})
 		data["someDecimal"] = decimalValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
 		require.Equal(t, "someDecimal", record.Fields[0].Label)
@@ -158,7 +161,8 @@ func TestConvertToRecordBasic(t *testing.T) {
 		data := make(map[string]interface{})
 		data["someBigInt"] = decimalValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
 		require.Equal(t, "someBigInt", record.Fields[0].Label)
@@ -183,7 +187,8 @@ func TestConvertToRecordBasic(t *testing.T) {
 		data := make(map[string]interface{})
 		data["myPair"] = pair
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -231,7 +236,8 @@ func TestConvertToRecordBasic(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -261,7 +267,8 @@ func TestConvertToRecordContractID(t *testing.T) {
 		data := make(map[string]interface{})
 		data["contractId"] = contractID
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -288,7 +295,8 @@ func TestConvertToRecordContractID(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -318,7 +326,8 @@ func TestConvertToRecordVariant(t *testing.T) {
 		data := make(map[string]interface{})
 		data["variant"] = variant
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -339,7 +348,8 @@ func TestConvertToRecordVariant(t *testing.T) {
 		data := make(map[string]interface{})
 		data["variant"] = variant
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -365,7 +375,8 @@ func TestConvertToRecordVariant(t *testing.T) {
 		data := make(map[string]interface{})
 		data["variant"] = variant
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -403,7 +414,8 @@ func TestConvertToRecordVariant(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -444,7 +456,8 @@ func TestConvertToRecordVariant(t *testing.T) {
 		data := make(map[string]interface{})
 		data["myPair"] = pair
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -497,7 +510,8 @@ func TestConvertToRecordVariant(t *testing.T) {
 		data := make(map[string]interface{})
 		data["myPair"] = pair
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -541,7 +555,8 @@ func TestConvertToRecordEnum(t *testing.T) {
 		data := make(map[string]interface{})
 		data["color"] = enumValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -559,7 +574,8 @@ func TestConvertToRecordEnum(t *testing.T) {
 		data := make(map[string]interface{})
 		data["color"] = enumValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -577,7 +593,8 @@ func TestConvertToRecordEnum(t *testing.T) {
 		data := make(map[string]interface{})
 		data["color"] = enumValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -607,7 +624,8 @@ func TestConvertToRecordEnum(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -647,7 +665,8 @@ func TestConvertToRecordEnum(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -729,10 +748,10 @@ func TestConvertToRecordIntegration(t *testing.T) {
 			Operator:        types.PARTY("test-party"),
 			SomeBoolean:     true,
 			SomeInteger:     190,
-			SomeDecimal:     types.NUMERIC("0.0000000200"),
-			SomeMeasurement: types.NUMERIC("0.0000000300"),
+			SomeDecimal:     types.MustParseNumeric("0.0000000200"),
+			SomeMeasurement: types.MustParseNumeric("0.0000000300"),
 			SomeDate:        types.DATE(time.Now().UTC()),
-			SomeDatetime:    types.TIMESTAMP(time.Now().UTC()),
+			SomeDatetime:    types.TIMESTAMP(time.Now().UTC().Truncate(time.Microsecond)),
 			SomeSimpleList:  someListInt,
 			SomeSimplePair:  MyPairIntegration{Left: types.INT64(100), Right: types.INT64(200)},
 			SomeNestedPair:  MyPairIntegration{Left: MyPairIntegration{Left: types.INT64(10), Right: types.INT64(20)}, Right: types.INT64(30)},
@@ -744,7 +763,8 @@ func TestConvertToRecordIntegration(t *testing.T) {
 		require.NotNil(t, createCmd)
 		require.NotNil(t, createCmd.Arguments)
 
-		record := convertToRecord(createCmd.Arguments)
+		record, err := convertToRecord(createCmd.Arguments)
+		require.NoError(t, err)
 		require.NotNil(t, record)
 
 		fieldMap := make(map[string]*v2.RecordField)
@@ -773,7 +793,8 @@ func TestConvertToRecordOptional(t *testing.T) {
 		data := make(map[string]interface{})
 		data["optionalField"] = optionalData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -793,7 +814,8 @@ func TestConvertToRecordOptional(t *testing.T) {
 		data := make(map[string]interface{})
 		data["optionalField"] = optionalData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -826,7 +848,8 @@ func TestConvertToRecordOptional(t *testing.T) {
 			"_type": "optional",
 		}
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 4)
@@ -861,7 +884,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["intList"] = sliceData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -884,7 +908,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["textList"] = sliceData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -906,7 +931,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["boolList"] = sliceData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -929,7 +955,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["regularIntList"] = sliceData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -952,7 +979,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["stringList"] = sliceData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -974,7 +1002,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["interfaceList"] = sliceData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -997,7 +1026,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["damlList"] = listData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1037,7 +1067,8 @@ func TestConvertToRecordSlices(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1116,7 +1147,8 @@ func TestConvertToRecordRELTIME(t *testing.T) {
 		data := make(map[string]interface{})
 		data["duration"] = reltimeValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1132,7 +1164,8 @@ func TestConvertToRecordRELTIME(t *testing.T) {
 		data := make(map[string]interface{})
 		data["duration"] = reltimeValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1148,7 +1181,8 @@ func TestConvertToRecordRELTIME(t *testing.T) {
 		data := make(map[string]interface{})
 		data["duration"] = reltimeValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1176,7 +1210,8 @@ func TestConvertToRecordRELTIME(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1197,6 +1232,62 @@ func TestConvertToRecordRELTIME(t *testing.T) {
 	})
 }
 
+func TestConvertToRecordDateAndTimestamp(t *testing.T) {
+	t.Run("dates before the epoch", func(t *testing.T) {
+		record, err := convertToRecord(map[string]interface{}{
+			"day":  types.Date{Year: 1969, Month: time.December, Day: 31},
+			"then": types.DATE(time.Date(1969, 12, 31, 18, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, record)
+		for _, field := range record.Fields {
+			require.Equal(t, int32(-1), field.Value.GetDate(), field.Label)
+		}
+	})
+
+	t.Run("timestamps keep microseconds", func(t *testing.T) {
+		at := time.Date(2024, 5, 1, 12, 0, 0, 123456000, time.FixedZone("CEST", 2*60*60))
+		record, err := convertToRecord(map[string]interface{}{"at": types.TIMESTAMP(at), "raw": at})
+		require.NoError(t, err)
+
+		require.NotNil(t, record)
+		for _, field := range record.Fields {
+			require.Equal(t, at.UnixMicro(), field.Value.GetTimestamp(), field.Label)
+		}
+
+		back := valueFromProto(record.Fields[0].Value)
+		require.True(t, at.Equal(back.(time.Time)))
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		for _, invalid := range []interface{}{
+			types.TIMESTAMP(time.Date(2024, 5, 1, 0, 0, 0, 1, time.UTC)),
+			time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+			types.Date{Year: 10000, Month: time.January, Day: 1},
+			types.Date{Year: 2023, Month: time.February, Day: 29},
+		} {
+			value, err := mapToValue(invalid)
+			require.Error(t, err, "%v", invalid)
+			require.Nil(t, value)
+		}
+
+		_, err := convertToRecord(map[string]interface{}{"day": types.Date{Year: 0, Month: time.December, Day: 31}})
+		require.ErrorContains(t, err, "field day")
+	})
+
+	t.Run("time.Time is a Timestamp, types.DATE a Date", func(t *testing.T) {
+		at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		value, err := mapToValue(at)
+		require.NoError(t, err)
+		require.Equal(t, at.UnixMicro(), value.GetTimestamp())
+
+		value, err = mapToValue(types.DATE(at))
+		require.NoError(t, err)
+		require.Equal(t, int32(19844), value.GetDate())
+	})
+}
+
 func TestConvertToRecordSET(t *testing.T) {
 	t.Run("SET type conversion - strings", func(t *testing.T) {
 		setValue := types.SET{"item1", "item2", "item3"}
@@ -1204,7 +1295,8 @@ func TestConvertToRecordSET(t *testing.T) {
 		data := make(map[string]interface{})
 		data["set"] = setValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1224,7 +1316,8 @@ func TestConvertToRecordSET(t *testing.T) {
 		data := make(map[string]interface{})
 		data["set"] = setValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1244,7 +1337,8 @@ func TestConvertToRecordSET(t *testing.T) {
 		data := make(map[string]interface{})
 		data["set"] = setValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1264,7 +1358,8 @@ func TestConvertToRecordSET(t *testing.T) {
 		data := make(map[string]interface{})
 		data["set"] = setValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1293,7 +1388,8 @@ func TestConvertToRecordSET(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1335,7 +1431,8 @@ func TestConvertToRecordSET(t *testing.T) {
 		data := make(map[string]interface{})
 		data["parties"] = setValue
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1373,7 +1470,8 @@ func TestConvertToRecordRELTIMEAndSETIntegration(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1755,7 +1853,8 @@ func TestConvertToRecordGENMAP(t *testing.T) {
 		data := make(map[string]interface{})
 		data["genmap"] = genMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1789,7 +1888,8 @@ func TestConvertToRecordGENMAP(t *testing.T) {
 		data := make(map[string]interface{})
 		data["genmap"] = genMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1818,14 +1918,15 @@ func TestConvertToRecordGENMAP(t *testing.T) {
 				"int":     types.INT64(100),
 				"text":    types.TEXT("test"),
 				"bool":    types.BOOL(false),
-				"numeric": types.NUMERIC("0.0000000500"),
+				"numeric": types.MustParseNumeric("0.0000000500"),
 			},
 		}
 
 		data := make(map[string]interface{})
 		data["genmap"] = genMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1857,7 +1958,8 @@ func TestConvertToRecordGENMAP(t *testing.T) {
 		data := make(map[string]interface{})
 		data["emptyMap"] = genMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1898,7 +2000,8 @@ func TestConvertToRecordGENMAP(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1939,7 +2042,8 @@ func TestConvertToRecordGENMAP(t *testing.T) {
 		data := make(map[string]interface{})
 		data["genmap"] = genMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -1977,7 +2081,8 @@ func TestConvertToRecordOptionalWithMaps(t *testing.T) {
 		data := make(map[string]interface{})
 		data["optionalMap"] = optionalData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -2018,7 +2123,8 @@ func TestConvertToRecordOptionalWithMaps(t *testing.T) {
 		data := make(map[string]interface{})
 		data["optionalTextMap"] = optionalData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -2049,7 +2155,8 @@ func TestConvertToRecordOptionalWithMaps(t *testing.T) {
 		data := make(map[string]interface{})
 		data["optionalEmptyMap"] = optionalData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -2098,7 +2205,8 @@ func TestConvertToRecordOptionalWithMaps(t *testing.T) {
 		data := make(map[string]interface{})
 		data["testStruct"] = testData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -2159,7 +2267,8 @@ func TestConvertToRecordNestedMaps(t *testing.T) {
 		data := make(map[string]interface{})
 		data["nestedMap"] = nestedMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -2202,7 +2311,8 @@ func TestConvertToRecordNestedMaps(t *testing.T) {
 		data := make(map[string]interface{})
 		data["complexMap"] = complexMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -2251,7 +2361,8 @@ func TestConvertToRecordNestedMaps(t *testing.T) {
 		data := make(map[string]interface{})
 		data["complexMap"] = complexMapData
 
-		record := convertToRecord(data)
+		record, err := convertToRecord(data)
+		require.NoError(t, err)
 
 		require.NotNil(t, record)
 		require.Len(t, record.Fields, 1)
@@ -2333,7 +2444,7 @@ func TestValueFromProto(t *testing.T) {
 
 		result := valueFromProto(pb)
 
-		require.Equal(t, "123.456", result)
+		require.Equal(t, types.MustParseNumeric("123.456"), result)
 	})
 
 	t.Run("Party value", func(t *testing.T) {
@@ -2363,17 +2474,23 @@ func TestValueFromProto(t *testing.T) {
 
 		result := valueFromProto(pb)
 
-		require.Equal(t, int32(19000), result)
+		require.Equal(t, types.Date{Year: 2022, Month: time.January, Day: 8}, result)
 	})
 
 	t.Run("Timestamp value", func(t *testing.T) {
 		pb := &v2.Value{
-			Sum: &v2.Value_Timestamp{Timestamp: 1609459200},
+			Sum: &v2.Value_Timestamp{Timestamp: 1609459200123456},
 		}
 
 		result := valueFromProto(pb)
 
-		require.Equal(t, int64(1609459200), result)
+		require.Equal(t, time.Date(2021, time.January, 1, 0, 0, 0, 123456000, time.UTC), result)
+	})
+
+	t.Run("Date and Timestamp out of range", func(t *testing.T) {
+		require.Equal(t, int32(2932897), valueFromProto(&v2.Value{Sum: &v2.Value_Date{Date: 2932897}}))
+		require.Equal(t, int64(253402300800000000),
+			valueFromProto(&v2.Value{Sum: &v2.Value_Timestamp{Timestamp: 253402300800000000}}))
 	})
 
 	t.Run("Optional with value", func(t *testing.T) {
@@ -2533,10 +2650,10 @@ func TestValueFromProto(t *testing.T) {
 
 		result := valueFromProto(pb)
 
-		resultMap, ok := result.(map[string]interface{})
-		require.True(t, ok)
-		require.Equal(t, "value1", resultMap["key1"])
-		require.Equal(t, int64(100), resultMap["key2"])
+		require.Equal(t, []types.AnyEntry{
+			{Key: "key1", Value: "value1"},
+			{Key: "key2", Value: int64(100)},
+		}, result)
 	})
 
 	t.Run("GenMap value with non-string keys", func(t *testing.T) {
@@ -2559,10 +2676,34 @@ func TestValueFromProto(t *testing.T) {
 
 		result := valueFromProto(pb)
 
-		resultMap, ok := result.(map[string]interface{})
-		require.True(t, ok)
-		require.Equal(t, "first", resultMap["1"])
-		require.Equal(t, "second", resultMap["2"])
+		require.Equal(t, []types.AnyEntry{
+			{Key: int64(1), Value: "first"},
+			{Key: int64(2), Value: "second"},
+		}, result)
+	})
+
+	t.Run("GenMap value with record keys", func(t *testing.T) {
+		key := func(owner string) *v2.Value {
+			return &v2.Value{Sum: &v2.Value_Record{Record: &v2.Record{Fields: []*v2.RecordField{
+				{Label: "owner", Value: &v2.Value{Sum: &v2.Value_Party{Party: owner}}},
+			}}}}
+		}
+		pb := &v2.Value{Sum: &v2.Value_GenMap{GenMap: &v2.GenMap{Entries: []*v2.GenMap_Entry{
+			{Key: key("Bob"), Value: &v2.Value{Sum: &v2.Value_Int64{Int64: 2}}},
+			{Key: key("Alice"), Value: &v2.Value{Sum: &v2.Value_Int64{Int64: 1}}},
+		}}}}
+
+		result := valueFromProto(pb)
+		require.Equal(t, []types.AnyEntry{
+			{Key: map[string]interface{}{"owner": "Bob"}, Value: int64(2)},
+			{Key: map[string]interface{}{"owner": "Alice"}, Value: int64(1)},
+		}, result)
+
+		back, err := mapToValue(result)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), back.GetGenMap().Entries[0].Value.GetInt64())
+		require.Equal(t, "Bob", back.GetGenMap().Entries[0].Key.GetRecord().Fields[0].Value.GetText())
+		require.Equal(t, "Alice", back.GetGenMap().Entries[1].Key.GetRecord().Fields[0].Value.GetText())
 	})
 
 	t.Run("Nil value", func(t *testing.T) {
@@ -2625,3 +2766,158 @@ func TestValueFromProto(t *testing.T) {
 		require.Equal(t, int64(85), scoresList[1])
 	})
 }
+
+type genericFieldsTest struct {
+	Owner    types.PARTY                                       `json:"owner"`
+	Note     types.Optional[types.TEXT]                        `json:"note"`
+	Limit    types.Optional[types.INT64]                       `json:"limit"`
+	Balances types.GenMap[types.TEXT, types.INT64]             `json:"balances"`
+	Labels   types.TextMap[types.TEXT]                         `json:"labels"`
+	Admins   types.Set[types.PARTY]                            `json:"admins"`
+	Pair     types.Tuple2[types.PARTY, types.INT64]            `json:"pair"`
+	Triple   types.Tuple3[types.TEXT, types.BOOL, types.INT64] `json:"triple"`
+}
+
+func TestConvertToRecordGenericTypes(t *testing.T) {
+	value := genericFieldsTest{
+		Owner: "Alice::1220aa",
+		Note:  types.Some(types.TEXT("hello")),
+		Limit: types.None[types.INT64](),
+		Balances: types.NewGenMap(
+			types.MapEntry[types.TEXT, types.INT64]{Key: "b", Value: 2},
+			types.MapEntry[types.TEXT, types.INT64]{Key: "a", Value: 1},
+		),
+		Labels: types.TextMap[types.TEXT]{"env": "test"},
+		Admins: types.NewSet[types.PARTY]("Bob::1220bb"),
+		Pair:   types.Tuple2[types.PARTY, types.INT64]{First: "Carol::1220cc", Second: 3},
+		Triple: types.Tuple3[types.TEXT, types.BOOL, types.INT64]{First: "x", Second: true, Third: 7},
+	}
+
+	record, err := convertToRecord(value)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	fields := make(map[string]*v2.Value)
+	for _, f := range record.Fields {
+		fields[f.Label] = f.Value
+	}
+	require.Len(t, fields, 8)
+
+	require.Equal(t, "hello", fields["note"].GetOptional().GetValue().GetText())
+	require.NotNil(t, fields["limit"].GetOptional())
+	require.Nil(t, fields["limit"].GetOptional().GetValue())
+
+	entries := fields["balances"].GetGenMap().GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "b", entries[0].Key.GetText())
+	require.Equal(t, int64(2), entries[0].Value.GetInt64())
+	require.Equal(t, "a", entries[1].Key.GetText())
+
+	require.Equal(t, "test", fields["labels"].GetTextMap().GetEntries()[0].Value.GetText())
+
+	setFields := fields["admins"].GetRecord().GetFields()
+	require.Len(t, setFields, 1)
+	require.Equal(t, "map", setFields[0].Label)
+	require.Equal(t, "Bob::1220bb", setFields[0].Value.GetGenMap().GetEntries()[0].Key.GetParty())
+	require.NotNil(t, setFields[0].Value.GetGenMap().GetEntries()[0].Value.GetUnit())
+
+	pair := fields["pair"].GetRecord().GetFields()
+	require.Equal(t, "_1", pair[0].Label)
+	require.Equal(t, "Carol::1220cc", pair[0].Value.GetParty())
+	require.Equal(t, int64(3), pair[1].Value.GetInt64())
+	require.Len(t, fields["triple"].GetRecord().GetFields(), 3)
+
+	t.Run("GenMap with non-text keys", func(t *testing.T) {
+		m := types.NewGenMap(types.MapEntry[types.INT64, types.BOOL]{Key: 10, Value: true})
+		value, err := mapToValue(m)
+		require.NoError(t, err)
+		entries := value.GetGenMap().GetEntries()
+		require.Len(t, entries, 1)
+		require.Equal(t, int64(10), entries[0].Key.GetInt64())
+		require.True(t, entries[0].Value.GetBool())
+	})
+
+	t.Run("nested optional", func(t *testing.T) {
+		v, err := mapToValue(types.Some(types.None[types.INT64]()))
+		require.NoError(t, err)
+		inner := v.GetOptional().GetValue()
+		require.NotNil(t, inner.GetOptional())
+		require.Nil(t, inner.GetOptional().GetValue())
+	})
+
+	t.Run("round trip through RecordToStruct", func(t *testing.T) {
+		var decoded genericFieldsTest
+		require.NoError(t, RecordToStruct(record, &decoded))
+
+		// the ledger returns map entries keyed by text, so insertion order is not preserved
+		require.ElementsMatch(t, value.Balances.Entries(), decoded.Balances.Entries())
+		decoded.Balances = value.Balances
+		require.Equal(t, value, decoded)
+	})
+}
+
+func TestConvertToRecordStaticStruct(t *testing.T) {
+	type holding struct {
+		Owner   types.PARTY     `json:"owner"`
+		Limit   *types.INT64    `json:"limit"`
+		Created types.TIMESTAMP `json:"created"`
+	}
+	limit := types.INT64(5)
+	value := holding{
+		Owner:   "Alice::1220aa",
+		Limit:   &limit,
+		Created: types.TIMESTAMP(time.Date(2021, 1, 1, 0, 0, 0, 1000, time.UTC)),
+	}
+
+	record, err := convertToRecord(&value)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.Len(t, record.Fields, 3)
+	require.Equal(t, "owner", record.Fields[0].Label)
+	require.Equal(t, int64(5), record.Fields[1].Value.GetOptional().GetValue().GetInt64())
+	require.Equal(t, int64(1609459200000001), record.Fields[2].Value.GetTimestamp())
+
+	var decoded holding
+	require.NoError(t, RecordToStruct(record, &decoded))
+	require.Equal(t, value, decoded)
+
+	record.Fields[0].Value = &v2.Value{Sum: &v2.Value_Int64{Int64: 1}}
+	require.ErrorContains(t, RecordToStruct(record, &decoded), "field owner")
+
+	type payment struct {
+		Amount types.NUMERIC `json:"amount"`
+	}
+	_, err = convertToRecord(payment{})
+	require.ErrorContains(t, err, "field amount")
+
+	_, err = commandsToProto(&model.Commands{Commands: []*model.Command{{
+		Command: &model.CreateCommand{TemplateID: "#pkg:Module:Payment", Arguments: map[string]interface{}{"amount": types.NUMERIC{}}},
+	}}})
+	require.ErrorContains(t, err, "command 0: failed to convert create arguments of #pkg:Module:Payment")
+}
+
+func TestPrepareSubmissionRequestSynchronizerID(t *testing.T) {
+	pb, err := prepareSubmissionRequestToProto(&model.PrepareSubmissionRequest{SynchronizerID: "global::1220aa"})
+	require.NoError(t, err)
+	require.Equal(t, "global::1220aa", pb.SynchronizerId)
+
+	pb, err = prepareSubmissionRequestToProto(&model.PrepareSubmissionRequest{})
+	require.NoError(t, err)
+	require.Empty(t, pb.SynchronizerId)
+
+	_, err = prepareSubmissionRequestToProto(&model.PrepareSubmissionRequest{SynchronizerID: "global"})
+	require.ErrorContains(t, err, "synchronizer ID")
+}
+
+func TestCommandsPartyIDs(t *testing.T) {
+	pb, err := commandsToProto(&model.Commands{ActAs: []string{"alice::1220aa"}, ReadAs: []string{"bob::1220aa"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice::1220aa"}, pb.ActAs)
+
+	_, err = commandsToProto(&model.Commands{ActAs: []string{"alice"}})
+	require.ErrorContains(t, err, "invalid act-as party")
+	_, err = commandsToProto(&model.Commands{ActAs: []string{"alice::1220aa"}, ReadAs: []string{""}})
+	require.ErrorContains(t, err, "invalid read-as party")
+
+	_, err = prepareSubmissionRequestToProto(&model.PrepareSubmissionRequest{ActAs: []string{"alice::"}})
+	require.ErrorContains(t, err, "invalid act-as party")
+}
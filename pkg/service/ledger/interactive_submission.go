@@ -28,7 +28,16 @@ func NewInteractiveSubmissionServiceClient(conn *grpc.ClientConn) InteractiveSub
 }
 
 func (c *interactiveSubmissionService) PrepareSubmission(ctx context.Context, req *model.PrepareSubmissionRequest) (*model.PrepareSubmissionResponse, error) {
-	pbReq := prepareSubmissionRequestToProto(req)
+	if req != nil && req.SynchronizerID != "" {
+		if err := req.SynchronizerID.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	pbReq, err := prepareSubmissionRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 	pbResp, err := c.client.PrepareSubmission(ctx, pbReq)
 	if err != nil {
 		return nil, err
@@ -46,10 +55,19 @@ func (c *interactiveSubmissionService) ExecuteSubmission(ctx context.Context, re
 }
 
 func (c *interactiveSubmissionService) GetPreferredPackageVersion(ctx context.Context, req *model.GetPreferredPackageVersionRequest) (*model.GetPreferredPackageVersionResponse, error) {
+	if req.SynchronizerID != "" {
+		if err := req.SynchronizerID.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := model.ValidatePartyIDs(req.Parties); err != nil {
+		return nil, err
+	}
+
 	pbReq := &interactive.GetPreferredPackageVersionRequest{
 		Parties:        req.Parties,
 		PackageName:    req.PackageName,
-		SynchronizerId: req.SynchronizerID,
+		SynchronizerId: string(req.SynchronizerID),
 	}
 
 	if req.VettingValidAt != nil {
@@ -63,7 +81,7 @@ func (c *interactiveSubmissionService) GetPreferredPackageVersion(ctx context.Co
 
 	resp := &model.GetPreferredPackageVersionResponse{}
 	if pbResp.PackagePreference != nil {
-		resp.SynchronizerID = pbResp.PackagePreference.SynchronizerId
+		resp.SynchronizerID = model.SynchronizerID(pbResp.PackagePreference.SynchronizerId)
 		if pbResp.PackagePreference.PackageReference != nil {
 			resp.PackageReference = &model.PackageReference{
 				PackageID:      pbResp.PackagePreference.PackageReference.PackageId,
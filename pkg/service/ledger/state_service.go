@@ -119,7 +119,7 @@ func activeContractFromProto(pb *v2.ActiveContract) *model.ActiveContract {
 
 	return &model.ActiveContract{
 		CreatedEvent:        createdEventFromProto(pb.CreatedEvent),
-		SynchronizerID:      pb.SynchronizerId,
+		SynchronizerID:      model.SynchronizerID(pb.SynchronizerId),
 		ReassignmentCounter: pb.ReassignmentCounter,
 	}
 }
@@ -183,7 +183,7 @@ func connectedSynchronizerFromProto(pb *v2.GetConnectedSynchronizersResponse_Con
 	}
 
 	return &model.ConnectedSynchronizer{
-		SynchronizerID:        pb.SynchronizerId,
+		SynchronizerID:        model.SynchronizerID(pb.SynchronizerId),
 		ParticipantPermission: participantPermissionFromProto(pb.Permission),
 	}
 }
@@ -187,6 +187,11 @@ func transactionFromProto(pb *v2.Transaction) *model.Transaction {
 		tx.EffectiveAt = &t
 	}
 
+	if pb.RecordTime != nil {
+		t := pb.RecordTime.AsTime()
+		tx.RecordTime = &t
+	}
+
 	for _, event := range pb.Events {
 		tx.Events = append(tx.Events, eventFromProto(event))
 	}
@@ -225,6 +230,7 @@ func reassignmentFromProto(pb *v2.Reassignment) *model.Reassignment {
 
 	if pb.RecordTime != nil {
 		t := pb.RecordTime.AsTime()
+		r.RecordTime = &t
 		r.SubmittedAt = &t
 	}
 
@@ -255,7 +261,7 @@ func reassignmentFromProto(pb *v2.Reassignment) *model.Reassignment {
 				if r.Counter == 0 {
 					r.Counter = int64(e.Assigned.ReassignmentCounter)
 				}
-				r.Reassigned = r.SubmittedAt
+				r.Reassigned = r.RecordTime
 			}
 		}
 	}
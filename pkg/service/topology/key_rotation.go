@@ -0,0 +1,353 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+type KeyRotationStepKind int
+
+const (
+	KeyRotationAddDelegation KeyRotationStepKind = iota
+	KeyRotationReissueMapping
+	KeyRotationRemoveDelegation
+)
+
+func (k KeyRotationStepKind) String() string {
+	switch k {
+	case KeyRotationAddDelegation:
+		return "add delegation"
+	case KeyRotationReissueMapping:
+		return "reissue mapping"
+	case KeyRotationRemoveDelegation:
+		return "remove delegation"
+	default:
+		return "unknown"
+	}
+}
+
+// KeyRotationRequest describes the rotation of a namespace or intermediate key. The new
+// key must already be registered on the participant running the rotation.
+type KeyRotationRequest struct {
+	// Store defaults to the authorized store.
+	Store     *model.StoreID
+	Namespace model.Namespace
+	// OldKey is the fingerprint of the key being rotated out.
+	OldKey            model.Fingerprint
+	NewKey            model.PublicKey
+	NewKeyFingerprint model.Fingerprint
+}
+
+type KeyRotationStep struct {
+	Kind     KeyRotationStepKind
+	Proposal *model.TopologyTransactionProposal
+	SignedBy []string
+	Done     bool
+}
+
+// KeyRotationPlan lists the steps of a rotation in the order they must be issued: the new
+// delegation, the mappings currently signed by the old key, then removal of the old delegation.
+type KeyRotationPlan struct {
+	Store *model.StoreID
+	Steps []*KeyRotationStep
+}
+
+// Transactions builds the topology transactions the plan would issue without contacting
+// the participant, for review before running it.
+func (p *KeyRotationPlan) Transactions() ([]*model.GeneratedTransaction, error) {
+	txs := make([]*model.GeneratedTransaction, len(p.Steps))
+	for i, step := range p.Steps {
+		tx, err := BuildTopologyTransaction(step.Proposal.Operation, step.Proposal.Serial, step.Proposal.Mapping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s transaction: %w", step.Kind, err)
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
+type KeyRotator struct {
+	read  TopologyManagerRead
+	write TopologyManagerWrite
+}
+
+func NewKeyRotator(read TopologyManagerRead, write TopologyManagerWrite) *KeyRotator {
+	return &KeyRotator{
+		read:  read,
+		write: write,
+	}
+}
+
+// Plan derives the remaining steps from the current state of the store, so planning again
+// after a partial failure yields only what is left to do.
+func (r *KeyRotator) Plan(ctx context.Context, req KeyRotationRequest) (*KeyRotationPlan, error) {
+	if err := req.Namespace.Validate(); err != nil {
+		return nil, err
+	}
+	if err := req.OldKey.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid old key: %w", err)
+	}
+	if err := req.NewKeyFingerprint.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid new key: %w", err)
+	}
+	if len(req.NewKey.Key) == 0 {
+		return nil, fmt.Errorf("new key is required")
+	}
+	if req.OldKey == req.NewKeyFingerprint {
+		return nil, fmt.Errorf("old and new key are the same")
+	}
+	if req.OldKey == req.Namespace.Fingerprint() {
+		return nil, fmt.Errorf("the root certificate of namespace %s cannot be rotated; delegate to a new key instead", req.Namespace)
+	}
+
+	store := req.Store
+	if store == nil {
+		store = model.AuthorizedStore()
+	}
+	plan := &KeyRotationPlan{Store: store}
+
+	oldDelegation, err := r.delegation(ctx, store, req.Namespace, req.OldKey)
+	if err != nil {
+		return nil, err
+	}
+	newDelegation, err := r.delegation(ctx, store, req.Namespace, req.NewKeyFingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if oldDelegation == nil && newDelegation == nil {
+		return nil, fmt.Errorf("no delegation for key %s in namespace %s", req.OldKey, req.Namespace)
+	}
+
+	if newDelegation == nil {
+		mapping := &model.NamespaceDelegationMapping{
+			Namespace:        req.Namespace,
+			TargetKey:        req.NewKey,
+			IsRootDelegation: oldDelegation.Item.IsRootDelegation,
+			Restriction:      oldDelegation.Item.Restriction,
+		}
+		serial, err := r.latestSerial(ctx, store, mapping)
+		if err != nil {
+			return nil, err
+		}
+		plan.Steps = append(plan.Steps, &KeyRotationStep{
+			Kind:     KeyRotationAddDelegation,
+			Proposal: &model.TopologyTransactionProposal{Operation: model.OperationAddReplace, Mapping: mapping, Serial: serial + 1},
+		})
+	}
+
+	dependents, err := r.signedBy(ctx, store, req.Namespace, req.OldKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range dependents {
+		if d, ok := dep.mapping.(*model.NamespaceDelegationMapping); ok {
+			if bytes.Equal(d.TargetKey.Key, req.NewKey.Key) ||
+				(oldDelegation != nil && bytes.Equal(d.TargetKey.Key, oldDelegation.Item.TargetKey.Key)) {
+				continue
+			}
+		}
+		plan.Steps = append(plan.Steps, &KeyRotationStep{
+			Kind:     KeyRotationReissueMapping,
+			Proposal: &model.TopologyTransactionProposal{Operation: model.OperationAddReplace, Mapping: dep.mapping, Serial: dep.serial + 1},
+			SignedBy: []string{string(req.NewKeyFingerprint)},
+		})
+	}
+
+	if oldDelegation != nil {
+		plan.Steps = append(plan.Steps, &KeyRotationStep{
+			Kind:     KeyRotationRemoveDelegation,
+			Proposal: &model.TopologyTransactionProposal{Operation: model.OperationRemove, Mapping: oldDelegation.Item, Serial: uint32(oldDelegation.Context.Serial) + 1},
+		})
+	}
+
+	return plan, nil
+}
+
+// Execute issues the plan's steps in order. Steps whose serial has already been reached
+// in the store are marked done and skipped, so a failed run can be repeated with the same plan.
+func (r *KeyRotator) Execute(ctx context.Context, plan *KeyRotationPlan) error {
+	for _, step := range plan.Steps {
+		if step.Done {
+			continue
+		}
+
+		serial, err := r.latestSerial(ctx, plan.Store, step.Proposal.Mapping)
+		if err != nil {
+			return err
+		}
+		if serial >= step.Proposal.Serial {
+			step.Done = true
+			continue
+		}
+
+		_, err = r.write.Authorize(ctx, &model.AuthorizeRequest{
+			Proposal:           step.Proposal,
+			MustFullyAuthorize: true,
+			SignedBy:           step.SignedBy,
+			Store:              plan.Store,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to %s with serial %d: %w", step.Kind, step.Proposal.Serial, err)
+		}
+		step.Done = true
+	}
+
+	return nil
+}
+
+// Rotate plans and executes a rotation. Calling it again after a failure resumes it.
+func (r *KeyRotator) Rotate(ctx context.Context, req KeyRotationRequest) (*KeyRotationPlan, error) {
+	plan, err := r.Plan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return plan, r.Execute(ctx, plan)
+}
+
+func (r *KeyRotator) delegation(ctx context.Context, store *model.StoreID, namespace model.Namespace, key model.Fingerprint) (*model.NamespaceDelegationResult, error) {
+	resp, err := r.read.ListNamespaceDelegation(ctx, &model.ListNamespaceDelegationRequest{
+		BaseQuery:                  headStateQuery(store, ""),
+		FilterNamespace:            string(namespace),
+		FilterTargetKeyFingerprint: string(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespace delegations: %w", err)
+	}
+
+	for _, res := range resp.Results {
+		if res != nil && res.Item != nil && res.Context != nil &&
+			res.Item.Namespace == namespace && res.Context.Operation != model.OperationRemove {
+			return res, nil
+		}
+	}
+	return nil, nil
+}
+
+type signedMapping struct {
+	mapping model.TopologyMapping
+	serial  uint32
+}
+
+// signedBy returns the mappings of namespace whose current transaction carries a signature of key.
+func (r *KeyRotator) signedBy(ctx context.Context, store *model.StoreID, namespace model.Namespace, key model.Fingerprint) ([]signedMapping, error) {
+	query := headStateQuery(store, string(key))
+	inNamespace := func(id string) bool { return strings.HasSuffix(id, "::"+string(namespace)) }
+	var found []signedMapping
+
+	delegations, err := r.read.ListNamespaceDelegation(ctx, &model.ListNamespaceDelegationRequest{BaseQuery: query, FilterNamespace: string(namespace)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespace delegations: %w", err)
+	}
+	for _, res := range delegations.Results {
+		if res != nil && res.Item != nil && res.Context != nil && res.Item.Namespace == namespace && res.Context.Operation != model.OperationRemove {
+			found = append(found, signedMapping{res.Item, uint32(res.Context.Serial)})
+		}
+	}
+
+	hosting, err := r.read.ListPartyToParticipant(ctx, &model.ListPartyToParticipantRequest{BaseQuery: query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list party to participant mappings: %w", err)
+	}
+	for _, res := range hosting.Results {
+		if res != nil && res.Item != nil && res.Context != nil && res.Item.Party.Namespace() == namespace && res.Context.Operation != model.OperationRemove {
+			found = append(found, signedMapping{res.Item, uint32(res.Context.Serial)})
+		}
+	}
+
+	partyKeys, err := r.read.ListPartyToKeyMapping(ctx, &model.ListPartyToKeyMappingRequest{BaseQuery: query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list party to key mappings: %w", err)
+	}
+	for _, res := range partyKeys.Results {
+		if res != nil && res.Item != nil && res.Context != nil && res.Item.Party.Namespace() == namespace && res.Context.Operation != model.OperationRemove {
+			found = append(found, signedMapping{res.Item, uint32(res.Context.Serial)})
+		}
+	}
+
+	ownerKeys, err := r.read.ListOwnerToKeyMapping(ctx, &model.ListOwnerToKeyMappingRequest{BaseQuery: query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owner to key mappings: %w", err)
+	}
+	for _, res := range ownerKeys.Results {
+		if res != nil && res.Item != nil && res.Context != nil && inNamespace(res.Item.Member) && res.Context.Operation != model.OperationRemove {
+			found = append(found, signedMapping{res.Item, uint32(res.Context.Serial)})
+		}
+	}
+
+	return found, nil
+}
+
+// latestSerial returns the highest serial in the full history of mapping's unique key, including removals.
+func (r *KeyRotator) latestSerial(ctx context.Context, store *model.StoreID, mapping model.TopologyMapping) (uint32, error) {
+	query := &model.BaseQuery{
+		Store:     store,
+		TimeQuery: &model.TimeQuery{Range: &model.TimeRange{}},
+	}
+
+	var contexts []*model.BaseResult
+	switch m := mapping.(type) {
+	case *model.NamespaceDelegationMapping:
+		resp, err := r.read.ListNamespaceDelegation(ctx, &model.ListNamespaceDelegationRequest{BaseQuery: query, FilterNamespace: string(m.Namespace)})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list namespace delegations: %w", err)
+		}
+		for _, res := range resp.Results {
+			if res != nil && res.Item != nil && res.Item.Namespace == m.Namespace && bytes.Equal(res.Item.TargetKey.Key, m.TargetKey.Key) {
+				contexts = append(contexts, res.Context)
+			}
+		}
+	case *model.PartyToParticipantMapping:
+		resp, err := r.read.ListPartyToParticipant(ctx, &model.ListPartyToParticipantRequest{BaseQuery: query, FilterParty: string(m.Party)})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list party to participant mappings: %w", err)
+		}
+		for _, res := range resp.Results {
+			if res != nil && res.Item != nil && res.Item.Party == m.Party {
+				contexts = append(contexts, res.Context)
+			}
+		}
+	case *model.PartyToKeyMapping:
+		resp, err := r.read.ListPartyToKeyMapping(ctx, &model.ListPartyToKeyMappingRequest{BaseQuery: query, FilterParty: string(m.Party)})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list party to key mappings: %w", err)
+		}
+		for _, res := range resp.Results {
+			if res != nil && res.Item != nil && res.Item.Party == m.Party {
+				contexts = append(contexts, res.Context)
+			}
+		}
+	case *model.OwnerToKeyMapping:
+		resp, err := r.read.ListOwnerToKeyMapping(ctx, &model.ListOwnerToKeyMappingRequest{BaseQuery: query})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list owner to key mappings: %w", err)
+		}
+		for _, res := range resp.Results {
+			if res != nil && res.Item != nil && res.Item.Member == m.Member {
+				contexts = append(contexts, res.Context)
+			}
+		}
+	default:
+		return 0, fmt.Errorf("key rotation does not support mapping %T", mapping)
+	}
+
+	var serial uint32
+	for _, c := range contexts {
+		if c != nil && uint32(c.Serial) > serial {
+			serial = uint32(c.Serial)
+		}
+	}
+
+	return serial, nil
+}
+
+func headStateQuery(store *model.StoreID, signedKey string) *model.BaseQuery {
+	return &model.BaseQuery{
+		Store:           store,
+		TimeQuery:       &model.TimeQuery{HeadState: true},
+		FilterSignedKey: signedKey,
+	}
+}
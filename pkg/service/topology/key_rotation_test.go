@@ -0,0 +1,195 @@
+package topology_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStoredMapping struct {
+	mapping  model.TopologyMapping
+	serial   int32
+	op       model.Operation
+	signedBy []string
+}
+
+// fakeKeyStore keeps the full history of a store. Target keys are identified by PublicKey.ID.
+type fakeKeyStore struct {
+	topology.TopologyManagerRead
+	topology.TopologyManagerWrite
+	history    []*fakeStoredMapping
+	authorized []*model.AuthorizeRequest
+	failAfter  int
+}
+
+func mappingKey(m model.TopologyMapping) string {
+	switch m := m.(type) {
+	case *model.NamespaceDelegationMapping:
+		return "nd/" + string(m.Namespace) + "/" + m.TargetKey.ID
+	case *model.PartyToParticipantMapping:
+		return "ptp/" + string(m.Party)
+	case *model.PartyToKeyMapping:
+		return "ptk/" + string(m.Party)
+	case *model.OwnerToKeyMapping:
+		return "otk/" + m.Member
+	}
+	return fmt.Sprintf("%T", m)
+}
+
+func (f *fakeKeyStore) query(q *model.BaseQuery) []*fakeStoredMapping {
+	if !q.TimeQuery.HeadState {
+		return f.history
+	}
+
+	latest := make(map[string]*fakeStoredMapping)
+	for _, e := range f.history {
+		latest[mappingKey(e.mapping)] = e
+	}
+	var result []*fakeStoredMapping
+	for _, e := range f.history {
+		if latest[mappingKey(e.mapping)] == e && e.op == model.OperationAddReplace &&
+			(q.FilterSignedKey == "" || slices.Contains(e.signedBy, q.FilterSignedKey)) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func (f *fakeKeyStore) ListNamespaceDelegation(_ context.Context, req *model.ListNamespaceDelegationRequest) (*model.ListNamespaceDelegationResponse, error) {
+	resp := &model.ListNamespaceDelegationResponse{}
+	for _, e := range f.query(req.BaseQuery) {
+		if m, ok := e.mapping.(*model.NamespaceDelegationMapping); ok &&
+			(req.FilterTargetKeyFingerprint == "" || m.TargetKey.ID == req.FilterTargetKeyFingerprint) {
+			resp.Results = append(resp.Results, &model.NamespaceDelegationResult{Context: &model.BaseResult{Serial: e.serial, Operation: e.op}, Item: m})
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeKeyStore) ListPartyToParticipant(_ context.Context, req *model.ListPartyToParticipantRequest) (*model.ListPartyToParticipantResponse, error) {
+	resp := &model.ListPartyToParticipantResponse{}
+	for _, e := range f.query(req.BaseQuery) {
+		if m, ok := e.mapping.(*model.PartyToParticipantMapping); ok {
+			resp.Results = append(resp.Results, &model.PartyToParticipantResult{Context: &model.BaseResult{Serial: e.serial, Operation: e.op}, Item: m})
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeKeyStore) ListPartyToKeyMapping(context.Context, *model.ListPartyToKeyMappingRequest) (*model.ListPartyToKeyMappingResponse, error) {
+	return &model.ListPartyToKeyMappingResponse{}, nil
+}
+
+func (f *fakeKeyStore) ListOwnerToKeyMapping(context.Context, *model.ListOwnerToKeyMappingRequest) (*model.ListOwnerToKeyMappingResponse, error) {
+	return &model.ListOwnerToKeyMappingResponse{}, nil
+}
+
+func (f *fakeKeyStore) Authorize(_ context.Context, req *model.AuthorizeRequest) (*model.AuthorizeResponse, error) {
+	if f.failAfter > 0 && len(f.authorized) == f.failAfter {
+		f.failAfter = 0
+		return nil, errors.New("connection reset")
+	}
+	f.authorized = append(f.authorized, req)
+
+	signedBy := req.SignedBy
+	if len(signedBy) == 0 {
+		signedBy = []string{"1220root"}
+	}
+	f.history = append(f.history, &fakeStoredMapping{
+		mapping:  req.Proposal.Mapping,
+		serial:   int32(req.Proposal.Serial),
+		op:       req.Proposal.Operation,
+		signedBy: signedBy,
+	})
+	return &model.AuthorizeResponse{}, nil
+}
+
+func newFakeKeyStore() *fakeKeyStore {
+	return &fakeKeyStore{history: []*fakeStoredMapping{
+		{
+			mapping:  &model.NamespaceDelegationMapping{Namespace: "1220root", TargetKey: model.PublicKey{ID: "1220root", Key: []byte("root")}, IsRootDelegation: true},
+			serial:   1,
+			op:       model.OperationAddReplace,
+			signedBy: []string{"1220root"},
+		},
+		{
+			mapping:  &model.NamespaceDelegationMapping{Namespace: "1220root", TargetKey: model.PublicKey{ID: "1220old", Key: []byte("old")}},
+			serial:   1,
+			op:       model.OperationAddReplace,
+			signedBy: []string{"1220root"},
+		},
+		{
+			mapping:  &model.PartyToParticipantMapping{Party: "alice::1220root", Threshold: 1},
+			serial:   3,
+			op:       model.OperationAddReplace,
+			signedBy: []string{"1220old"},
+		},
+	}}
+}
+
+var testKeyRotation = topology.KeyRotationRequest{
+	Namespace:         "1220root",
+	OldKey:            "1220old",
+	NewKey:            model.PublicKey{ID: "1220new", Key: []byte("new")},
+	NewKeyFingerprint: "1220new",
+}
+
+func TestKeyRotator_Plan(t *testing.T) {
+	rotator := topology.NewKeyRotator(newFakeKeyStore(), nil)
+
+	plan, err := rotator.Plan(context.Background(), testKeyRotation)
+	require.NoError(t, err)
+	require.Equal(t, "authorized", plan.Store.Value)
+	require.Len(t, plan.Steps, 3)
+
+	require.Equal(t, topology.KeyRotationAddDelegation, plan.Steps[0].Kind)
+	require.Equal(t, uint32(1), plan.Steps[0].Proposal.Serial)
+	require.Equal(t, topology.KeyRotationReissueMapping, plan.Steps[1].Kind)
+	require.Equal(t, uint32(4), plan.Steps[1].Proposal.Serial)
+	require.Equal(t, []string{"1220new"}, plan.Steps[1].SignedBy)
+	require.Equal(t, topology.KeyRotationRemoveDelegation, plan.Steps[2].Kind)
+	require.Equal(t, model.OperationRemove, plan.Steps[2].Proposal.Operation)
+	require.Equal(t, uint32(2), plan.Steps[2].Proposal.Serial)
+
+	txs, err := plan.Transactions()
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	for _, tx := range txs {
+		require.NotEmpty(t, tx.SerializedTransaction)
+		require.Len(t, tx.TransactionHash, 34)
+	}
+}
+
+func TestKeyRotator_ResumeAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeKeyStore()
+	store.failAfter = 2
+	rotator := topology.NewKeyRotator(store, store)
+
+	plan, err := rotator.Plan(ctx, testKeyRotation)
+	require.NoError(t, err)
+	require.ErrorContains(t, rotator.Execute(ctx, plan), "remove delegation")
+	require.Len(t, store.authorized, 2)
+
+	require.NoError(t, rotator.Execute(ctx, plan))
+	require.Len(t, store.authorized, 3)
+
+	resumed, err := rotator.Rotate(ctx, testKeyRotation)
+	require.NoError(t, err)
+	require.Empty(t, resumed.Steps)
+	require.Len(t, store.authorized, 3)
+}
+
+func TestKeyRotator_RootCertificate(t *testing.T) {
+	req := testKeyRotation
+	req.OldKey = req.Namespace.Fingerprint()
+
+	_, err := topology.NewKeyRotator(newFakeKeyStore(), nil).Plan(context.Background(), req)
+	require.ErrorContains(t, err, "root certificate")
+}
@@ -0,0 +1,176 @@
+package topology
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	protov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/protocol/v30"
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+const (
+	hashPurposeTopologyTransactionSignature = 11
+	hashPurposeMultiTopologyTransaction     = 55
+
+	// topologyTransactionProtoVersion is the proto version Canton uses for topology
+	// transactions since protocol version 33.
+	topologyTransactionProtoVersion = 30
+)
+
+// BuildTopologyTransaction serializes a topology transaction and computes its hash the
+// way the participant does, so transactions can be prepared without a connection.
+func BuildTopologyTransaction(op model.Operation, serial uint32, mapping model.TopologyMapping) (*model.GeneratedTransaction, error) {
+	if op == model.OperationUnspecified {
+		return nil, fmt.Errorf("operation is required")
+	}
+	if serial == 0 {
+		return nil, fmt.Errorf("serial must be positive")
+	}
+
+	pbMapping, err := topologyMappingToProto(mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := proto.MarshalOptions{Deterministic: true}
+	tx, err := opts.Marshal(&protov30.TopologyTransaction{
+		Operation: operationToProto(op),
+		Serial:    serial,
+		Mapping:   pbMapping,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize topology transaction: %w", err)
+	}
+
+	// The UntypedVersionedMessage wrapper is encoded by hand: the Go runtime writes the
+	// data oneof after the version, while Canton writes fields in field number order.
+	var serialized []byte
+	serialized = protowire.AppendTag(serialized, 1, protowire.BytesType)
+	serialized = protowire.AppendBytes(serialized, tx)
+	serialized = protowire.AppendTag(serialized, 2, protowire.VarintType)
+	serialized = protowire.AppendVarint(serialized, topologyTransactionProtoVersion)
+
+	return &model.GeneratedTransaction{
+		SerializedTransaction: serialized,
+		TransactionHash:       TopologyTransactionHash(serialized),
+	}, nil
+}
+
+// TopologyTransactionHash returns the hash that signatures over a serialized topology transaction cover.
+func TopologyTransactionHash(serialized []byte) []byte {
+	return cantonHash(hashPurposeTopologyTransactionSignature, serialized)
+}
+
+// MultiTransactionHash computes the hash that a single signature over several topology
+// transactions must sign: the sorted transaction hashes, each length-prefixed.
+func MultiTransactionHash(hashes [][]byte) []byte {
+	sorted := slices.Clone(hashes)
+	slices.SortFunc(sorted, bytes.Compare)
+
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(sorted)))
+	for _, h := range sorted {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(h)))
+		buf.Write(h)
+	}
+
+	return cantonHash(hashPurposeMultiTopologyTransaction, buf.Bytes())
+}
+
+// Fingerprint returns the Canton fingerprint of a raw public key.
+func Fingerprint(publicKey []byte) model.Fingerprint {
+	return model.PublicKeyFingerprint(publicKey)
+}
+
+// cantonHash returns the SHA-256 multihash of data prefixed with its hash purpose.
+func cantonHash(purpose uint32, data []byte) []byte {
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, purpose)
+	h.Write(data)
+
+	return append([]byte{0x12, 0x20}, h.Sum(nil)...)
+}
+
+// TransactionSigner signs topology transaction hashes with a key held by the caller.
+type TransactionSigner interface {
+	Fingerprint() model.Fingerprint
+	Sign(hash []byte) (model.TopologyTransactionSignature, error)
+}
+
+type ed25519Signer struct {
+	key         ed25519.PrivateKey
+	fingerprint model.Fingerprint
+}
+
+func NewEd25519Signer(key ed25519.PrivateKey) TransactionSigner {
+	return &ed25519Signer{
+		key:         key,
+		fingerprint: Fingerprint(key.Public().(ed25519.PublicKey)),
+	}
+}
+
+func (s *ed25519Signer) Fingerprint() model.Fingerprint {
+	return s.fingerprint
+}
+
+func (s *ed25519Signer) Sign(hash []byte) (model.TopologyTransactionSignature, error) {
+	return model.TopologyTransactionSignature{
+		SignedBy:             string(s.fingerprint),
+		Signature:            ed25519.Sign(s.key, hash),
+		SignatureFormat:      int32(model.SignatureFormatConcat),
+		SigningAlgorithmSpec: model.SigningAlgorithmSpecED25519,
+	}, nil
+}
+
+// SignTopologyTransactions has every signer sign every transaction individually.
+func SignTopologyTransactions(txs []*model.GeneratedTransaction, signers ...TransactionSigner) ([]*model.SignedTopologyTransaction, error) {
+	signed := make([]*model.SignedTopologyTransaction, len(txs))
+	for i, tx := range txs {
+		signed[i] = &model.SignedTopologyTransaction{Transaction: tx.SerializedTransaction}
+		for _, signer := range signers {
+			sig, err := signer.Sign(tx.TransactionHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sign topology transaction with %s: %w", signer.Fingerprint(), err)
+			}
+			signed[i].Signatures = append(signed[i].Signatures, sig)
+		}
+	}
+
+	return signed, nil
+}
+
+// SignTopologyTransactionsMulti has every signer produce one signature covering all
+// transactions, attached to each of them as multi-transaction signatures.
+func SignTopologyTransactionsMulti(txs []*model.GeneratedTransaction, signers ...TransactionSigner) ([]*model.SignedTopologyTransaction, error) {
+	hashes := make([][]byte, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.TransactionHash
+	}
+
+	multi := &model.MultiTransactionSignatures{TransactionHashes: hashes}
+	multiHash := MultiTransactionHash(hashes)
+	for _, signer := range signers {
+		sig, err := signer.Sign(multiHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign topology transactions with %s: %w", signer.Fingerprint(), err)
+		}
+		multi.Signatures = append(multi.Signatures, sig)
+	}
+
+	signed := make([]*model.SignedTopologyTransaction, len(txs))
+	for i, tx := range txs {
+		signed[i] = &model.SignedTopologyTransaction{
+			Transaction:                tx.SerializedTransaction,
+			MultiTransactionSignatures: []*model.MultiTransactionSignatures{multi},
+		}
+	}
+
+	return signed, nil
+}
@@ -0,0 +1,270 @@
+package topology_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
+	adminv2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2/admin"
+	protov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/protocol/v30"
+	versionv1 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/version/v1"
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/noders-team/go-daml/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type topologyGolden struct {
+	Source                 string                       `json:"source"`
+	Transactions           []topologyTransactionVector  `json:"transactions"`
+	MultiTransactionHashes []multiTransactionHashVector `json:"multi_transaction_hashes"`
+}
+
+type topologyTransactionVector struct {
+	Name        string `json:"name"`
+	Transaction string `json:"transaction"`
+	Hash        string `json:"hash"`
+	Signature   string `json:"signature"`
+}
+
+type multiTransactionHashVector struct {
+	Name         string   `json:"name"`
+	Transactions []string `json:"transactions"`
+	Hash         string   `json:"hash"`
+}
+
+func offlineTestKey() ed25519.PrivateKey {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	return ed25519.NewKeyFromSeed(seed)
+}
+
+func offlineTestMappings(key ed25519.PrivateKey) map[string]struct {
+	op      model.Operation
+	serial  uint32
+	mapping model.TopologyMapping
+} {
+	namespace := model.Namespace(topology.Fingerprint(key.Public().(ed25519.PublicKey)))
+	signingKey := model.PublicKey{
+		Format:  3,
+		Key:     key.Public().(ed25519.PublicKey),
+		Scheme:  int32(model.SigningKeySchemeED25519),
+		KeySpec: int32(model.SigningKeySpecCurve25519),
+	}
+	party := model.PartyID("alice::" + namespace)
+
+	return map[string]struct {
+		op      model.Operation
+		serial  uint32
+		mapping model.TopologyMapping
+	}{
+		"root_namespace_delegation": {model.OperationAddReplace, 1, &model.NamespaceDelegationMapping{
+			Namespace:        namespace,
+			TargetKey:        signingKey,
+			IsRootDelegation: true,
+		}},
+		"party_to_key": {model.OperationAddReplace, 1, &model.PartyToKeyMapping{
+			Party:       party,
+			Threshold:   1,
+			SigningKeys: []model.PublicKey{signingKey},
+		}},
+		"party_to_participant_remove": {model.OperationRemove, 2, &model.PartyToParticipantMapping{
+			Party:     party,
+			Threshold: 1,
+			Participants: []model.HostingParticipant{
+				{ParticipantUID: "participant1::1220abcd", Permission: model.ParticipantPermissionConfirmation},
+			},
+		}},
+	}
+}
+
+const topologyGoldenPath = "../../../test-data/topology_transactions.golden.json"
+
+// TestBuildTopologyTransaction_GoldenVectors checks the offline encoding and hashes against the
+// vectors in the golden file; its source field records where they come from.
+func TestBuildTopologyTransaction_GoldenVectors(t *testing.T) {
+	raw, err := os.ReadFile(topologyGoldenPath)
+	require.NoError(t, err)
+	var golden topologyGolden
+	require.NoError(t, json.Unmarshal(raw, &golden))
+	require.NotEmpty(t, golden.Source)
+
+	key := offlineTestKey()
+	signer := topology.NewEd25519Signer(key)
+	inputs := offlineTestMappings(key)
+	require.Len(t, golden.Transactions, len(inputs))
+
+	for _, v := range golden.Transactions {
+		t.Run(v.Name, func(t *testing.T) {
+			in, ok := inputs[v.Name]
+			require.True(t, ok)
+
+			tx, err := topology.BuildTopologyTransaction(in.op, in.serial, in.mapping)
+			require.NoError(t, err)
+			require.Equal(t, v.Transaction, hex.EncodeToString(tx.SerializedTransaction))
+			require.Equal(t, v.Hash, hex.EncodeToString(tx.TransactionHash))
+
+			signed, err := topology.SignTopologyTransactions([]*model.GeneratedTransaction{tx}, signer)
+			require.NoError(t, err)
+			require.Equal(t, v.Signature, hex.EncodeToString(signed[0].Signatures[0].Signature))
+			require.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), tx.TransactionHash, signed[0].Signatures[0].Signature))
+
+			var versioned versionv1.UntypedVersionedMessage
+			require.NoError(t, proto.Unmarshal(tx.SerializedTransaction, &versioned))
+			require.Equal(t, int32(30), versioned.Version)
+			var pbTx protov30.TopologyTransaction
+			require.NoError(t, proto.Unmarshal(versioned.GetData(), &pbTx))
+			require.Equal(t, in.serial, pbTx.Serial)
+		})
+	}
+
+	require.NotEmpty(t, golden.MultiTransactionHashes)
+	for _, v := range golden.MultiTransactionHashes {
+		t.Run(v.Name, func(t *testing.T) {
+			require.Greater(t, len(v.Transactions), 1)
+			require.Equal(t, v.Hash, hex.EncodeToString(topology.MultiTransactionHash(transactionHashes(t, v.Transactions))))
+		})
+	}
+}
+
+// TestBuildTopologyTransaction_MatchesParticipant compares the offline encoding with the
+// transactions the participant generates for the same proposals, and MultiTransactionHash
+// with the multi hash the participant returns for an external party's onboarding
+// transactions. Set GO_DAML_UPDATE_TOPOLOGY_GOLDEN=1 to rewrite the golden vectors from the
+// participant's output.
+func TestBuildTopologyTransaction_MatchesParticipant(t *testing.T) {
+	cl := testutil.RequireClient(t)
+	ctx := context.Background()
+	key := offlineTestKey()
+	signer := topology.NewEd25519Signer(key)
+	inputs := offlineTestMappings(key)
+
+	names := slices.Sorted(maps.Keys(inputs))
+	proposals := make([]*model.GenerateTransactionProposal, len(names))
+	for i, name := range names {
+		in := inputs[name]
+		proposals[i] = &model.GenerateTransactionProposal{
+			Operation: in.op,
+			Serial:    in.serial,
+			Mapping:   in.mapping,
+			Store:     model.AuthorizedStore(),
+		}
+	}
+
+	resp, err := cl.TopologyManagerWrite.GenerateTransactions(ctx, &model.GenerateTransactionsRequest{Proposals: proposals})
+	require.NoError(t, err)
+	require.Len(t, resp.GeneratedTransactions, len(names))
+
+	golden := topologyGolden{
+		Source:       "participant GenerateTransactions and GenerateExternalPartyTopology, recorded by TestBuildTopologyTransaction_MatchesParticipant",
+		Transactions: make([]topologyTransactionVector, len(names)),
+	}
+	for i, name := range names {
+		generated := resp.GeneratedTransactions[i]
+		in := inputs[name]
+		tx, err := topology.BuildTopologyTransaction(in.op, in.serial, in.mapping)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(generated.SerializedTransaction), hex.EncodeToString(tx.SerializedTransaction), name)
+		require.Equal(t, hex.EncodeToString(generated.TransactionHash), hex.EncodeToString(tx.TransactionHash), name)
+
+		signed, err := topology.SignTopologyTransactions([]*model.GeneratedTransaction{generated}, signer)
+		require.NoError(t, err)
+		golden.Transactions[i] = topologyTransactionVector{
+			Name:        name,
+			Transaction: hex.EncodeToString(generated.SerializedTransaction),
+			Hash:        hex.EncodeToString(generated.TransactionHash),
+			Signature:   hex.EncodeToString(signed[0].Signatures[0].Signature),
+		}
+	}
+
+	synchronizers, err := cl.StateService.GetConnectedSynchronizers(ctx, &model.GetConnectedSynchronizersRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, synchronizers.ConnectedSynchronizers)
+
+	conn, err := grpc.NewClient(testutil.GetGrpcAddr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	onboarding, err := adminv2.NewPartyManagementServiceClient(conn).GenerateExternalPartyTopology(ctx, &adminv2.GenerateExternalPartyTopologyRequest{
+		Synchronizer: string(synchronizers.ConnectedSynchronizers[0].SynchronizerID),
+		PartyHint:    "golden",
+		PublicKey: &v2.SigningPublicKey{
+			Format:  v2.CryptoKeyFormat_CRYPTO_KEY_FORMAT_RAW,
+			KeyData: key.Public().(ed25519.PublicKey),
+			KeySpec: v2.SigningKeySpec_SIGNING_KEY_SPEC_EC_CURVE25519,
+		},
+	})
+	require.NoError(t, err)
+	require.Greater(t, len(onboarding.TopologyTransactions), 1)
+
+	onboardingTxs := make([]string, len(onboarding.TopologyTransactions))
+	for i, tx := range onboarding.TopologyTransactions {
+		onboardingTxs[i] = hex.EncodeToString(tx)
+	}
+	multiHash := topology.MultiTransactionHash(transactionHashes(t, onboardingTxs))
+	require.Equal(t, hex.EncodeToString(onboarding.MultiHash), hex.EncodeToString(multiHash))
+	golden.MultiTransactionHashes = []multiTransactionHashVector{
+		{Name: "external_party_onboarding", Transactions: onboardingTxs, Hash: hex.EncodeToString(onboarding.MultiHash)},
+	}
+
+	if os.Getenv("GO_DAML_UPDATE_TOPOLOGY_GOLDEN") != "" {
+		data, err := json.MarshalIndent(golden, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(topologyGoldenPath, append(data, '\n'), 0o644))
+	}
+}
+
+func transactionHashes(t *testing.T, transactions []string) [][]byte {
+	t.Helper()
+	hashes := make([][]byte, len(transactions))
+	for i, tx := range transactions {
+		serialized, err := hex.DecodeString(tx)
+		require.NoError(t, err)
+		hashes[i] = topology.TopologyTransactionHash(serialized)
+	}
+	return hashes
+}
+
+func TestSignTopologyTransactionsMulti(t *testing.T) {
+	key := offlineTestKey()
+	signer := topology.NewEd25519Signer(key)
+
+	var txs []*model.GeneratedTransaction
+	for _, in := range offlineTestMappings(key) {
+		tx, err := topology.BuildTopologyTransaction(in.op, in.serial, in.mapping)
+		require.NoError(t, err)
+		txs = append(txs, tx)
+	}
+
+	signed, err := topology.SignTopologyTransactionsMulti(txs, signer)
+	require.NoError(t, err)
+	require.Len(t, signed, len(txs))
+
+	multi := signed[0].MultiTransactionSignatures[0]
+	require.Len(t, multi.TransactionHashes, len(txs))
+	require.Equal(t, signer.Fingerprint().String(), multi.Signatures[0].SignedBy)
+	require.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), topology.MultiTransactionHash(multi.TransactionHashes), multi.Signatures[0].Signature))
+}
+
+func TestBuildTopologyTransaction_Validation(t *testing.T) {
+	_, err := topology.BuildTopologyTransaction(model.OperationUnspecified, 1, &model.PartyToKeyMapping{})
+	require.ErrorContains(t, err, "operation")
+
+	_, err = topology.BuildTopologyTransaction(model.OperationAddReplace, 0, &model.PartyToKeyMapping{})
+	require.ErrorContains(t, err, "serial")
+
+	_, err = topology.BuildTopologyTransaction(model.OperationAddReplace, 1, nil)
+	require.Error(t, err)
+}
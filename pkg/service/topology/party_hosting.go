@@ -0,0 +1,196 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+// HostingNode is the admin connection to one participant taking part in a hosting change.
+type HostingNode struct {
+	ParticipantUID model.ParticipantUID
+	Read           TopologyManagerRead
+	Write          TopologyManagerWrite
+}
+
+type PartyHostingRequest struct {
+	Party        model.PartyID
+	Synchronizer model.SynchronizerID
+	Participants []model.HostingParticipant
+	// Threshold is the number of confirming participants that must approve a transaction.
+	Threshold uint32
+	// PollInterval is how often the synchronizer view is checked; defaults to 1s.
+	PollInterval time.Duration
+}
+
+// PartyHostingHelper changes the set of participants hosting a party. Every node proposes
+// the same PartyToParticipant mapping, so the party owner and each added participant authorize it.
+type PartyHostingHelper struct {
+	nodes []HostingNode
+}
+
+func NewPartyHostingHelper(nodes ...HostingNode) *PartyHostingHelper {
+	return &PartyHostingHelper{
+		nodes: nodes,
+	}
+}
+
+// UpdateHosting proposes the desired hosting on every node and waits until all of them
+// see it in the synchronizer store. It returns immediately if the hosting already matches.
+func (h *PartyHostingHelper) UpdateHosting(ctx context.Context, req PartyHostingRequest) (*model.PartyToParticipantMapping, error) {
+	if err := validatePartyHostingRequest(req); err != nil {
+		return nil, err
+	}
+	if len(h.nodes) == 0 {
+		return nil, fmt.Errorf("at least one participant node is required")
+	}
+
+	store := model.SynchronizerStore(req.Synchronizer)
+
+	current, serial, err := currentPartyHosting(ctx, h.nodes[0].Read, store, req.Party)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := &model.PartyToParticipantMapping{
+		Party:        req.Party,
+		Threshold:    req.Threshold,
+		Participants: slices.Clone(req.Participants),
+	}
+	if current != nil {
+		desired.PartySigningKeys = current.PartySigningKeys
+		if samePartyHosting(current, desired) {
+			return current, nil
+		}
+	}
+
+	proposal := &model.TopologyTransactionProposal{
+		Operation: model.OperationAddReplace,
+		Mapping:   desired,
+		Serial:    serial + 1,
+	}
+	for _, node := range h.nodes {
+		_, err := node.Write.Authorize(ctx, &model.AuthorizeRequest{
+			Proposal: proposal,
+			Store:    store,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to authorize hosting of %s on %s: %w", req.Party, node.ParticipantUID, err)
+		}
+	}
+
+	interval := req.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for _, node := range h.nodes {
+		if err := waitForPartyHosting(ctx, node, store, desired, proposal.Serial, interval); err != nil {
+			return nil, err
+		}
+	}
+
+	return desired, nil
+}
+
+func validatePartyHostingRequest(req PartyHostingRequest) error {
+	if err := req.Party.Validate(); err != nil {
+		return err
+	}
+	if err := req.Synchronizer.Validate(); err != nil {
+		return err
+	}
+	if len(req.Participants) == 0 {
+		return fmt.Errorf("at least one hosting participant is required")
+	}
+
+	var confirming uint32
+	seen := make(map[model.ParticipantUID]bool, len(req.Participants))
+	for _, p := range req.Participants {
+		if err := p.ParticipantUID.Validate(); err != nil {
+			return err
+		}
+		if seen[p.ParticipantUID] {
+			return fmt.Errorf("participant %s is listed more than once", p.ParticipantUID)
+		}
+		seen[p.ParticipantUID] = true
+		if p.Permission != model.ParticipantPermissionObservation {
+			confirming++
+		}
+	}
+	if req.Threshold < 1 || req.Threshold > confirming {
+		return fmt.Errorf("threshold %d must be between 1 and the %d confirming participants", req.Threshold, confirming)
+	}
+
+	return nil
+}
+
+// currentPartyHosting returns the party's mapping in the head state of store and its serial, or nil and 0.
+func currentPartyHosting(ctx context.Context, read TopologyManagerRead, store *model.StoreID, party model.PartyID) (*model.PartyToParticipantMapping, uint32, error) {
+	resp, err := read.ListPartyToParticipant(ctx, &model.ListPartyToParticipantRequest{
+		BaseQuery: &model.BaseQuery{
+			Store:     store,
+			TimeQuery: &model.TimeQuery{HeadState: true},
+		},
+		FilterParty: string(party),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list hosting of %s: %w", party, err)
+	}
+
+	var current *model.PartyToParticipantMapping
+	var serial uint32
+	for _, r := range resp.Results {
+		if r == nil || r.Item == nil || r.Context == nil || r.Item.Party != party {
+			continue
+		}
+		if current == nil || uint32(r.Context.Serial) > serial {
+			current = r.Item
+			serial = uint32(r.Context.Serial)
+		}
+	}
+
+	return current, serial, nil
+}
+
+func waitForPartyHosting(ctx context.Context, node HostingNode, store *model.StoreID, desired *model.PartyToParticipantMapping, serial uint32, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		current, currentSerial, err := currentPartyHosting(ctx, node.Read, store, desired.Party)
+		if err != nil {
+			return fmt.Errorf("failed to check hosting on %s: %w", node.ParticipantUID, err)
+		}
+		if current != nil && currentSerial >= serial && samePartyHosting(current, desired) {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("hosting of %s not visible on %s: %w", desired.Party, node.ParticipantUID, ctx.Err())
+		}
+	}
+}
+
+// samePartyHosting compares threshold and hosting participants, ignoring their order.
+func samePartyHosting(a, b *model.PartyToParticipantMapping) bool {
+	if a.Threshold != b.Threshold || len(a.Participants) != len(b.Participants) {
+		return false
+	}
+
+	hosts := make(map[model.ParticipantUID]model.HostingParticipant, len(a.Participants))
+	for _, p := range a.Participants {
+		hosts[p.ParticipantUID] = p
+	}
+	for _, p := range b.Participants {
+		if hosts[p.ParticipantUID] != p {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,127 @@
+package topology_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHostingSynchronizer applies a proposal once every node has authorized it.
+type fakeHostingSynchronizer struct {
+	nodes      int
+	mapping    *model.PartyToParticipantMapping
+	serial     int32
+	authorized map[string]bool
+	queries    []*model.ListPartyToParticipantRequest
+}
+
+type fakeHostingNode struct {
+	topology.TopologyManagerRead
+	topology.TopologyManagerWrite
+	uid  string
+	sync *fakeHostingSynchronizer
+}
+
+func (f *fakeHostingNode) ListPartyToParticipant(_ context.Context, req *model.ListPartyToParticipantRequest) (*model.ListPartyToParticipantResponse, error) {
+	f.sync.queries = append(f.sync.queries, req)
+	if f.sync.mapping == nil {
+		return &model.ListPartyToParticipantResponse{}, nil
+	}
+	return &model.ListPartyToParticipantResponse{Results: []*model.PartyToParticipantResult{
+		{Context: &model.BaseResult{Serial: f.sync.serial}, Item: f.sync.mapping},
+	}}, nil
+}
+
+func (f *fakeHostingNode) Authorize(_ context.Context, req *model.AuthorizeRequest) (*model.AuthorizeResponse, error) {
+	f.sync.authorized[f.uid] = true
+	if len(f.sync.authorized) == f.sync.nodes {
+		f.sync.mapping = req.Proposal.Mapping.(*model.PartyToParticipantMapping)
+		f.sync.serial = int32(req.Proposal.Serial)
+	}
+	return &model.AuthorizeResponse{}, nil
+}
+
+func newFakeHostingNodes(sync *fakeHostingSynchronizer, uids ...string) []topology.HostingNode {
+	sync.nodes = len(uids)
+	sync.authorized = make(map[string]bool)
+
+	nodes := make([]topology.HostingNode, len(uids))
+	for i, uid := range uids {
+		fake := &fakeHostingNode{uid: uid, sync: sync}
+		nodes[i] = topology.HostingNode{ParticipantUID: model.ParticipantUID(uid), Read: fake, Write: fake}
+	}
+	return nodes
+}
+
+func TestPartyHostingHelper_UpdateHosting(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	party := model.PartyID("alice::1220aa")
+	sync := &fakeHostingSynchronizer{
+		serial: 1,
+		mapping: &model.PartyToParticipantMapping{
+			Party:        party,
+			Threshold:    1,
+			Participants: []model.HostingParticipant{{ParticipantUID: "p1::1220aa", Permission: model.ParticipantPermissionSubmission}},
+		},
+	}
+	helper := topology.NewPartyHostingHelper(newFakeHostingNodes(sync, "p1::1220aa", "p2::1220bb")...)
+
+	req := topology.PartyHostingRequest{
+		Party:        party,
+		Synchronizer: "sync::1220dd",
+		Threshold:    2,
+		Participants: []model.HostingParticipant{
+			{ParticipantUID: "p1::1220aa", Permission: model.ParticipantPermissionSubmission},
+			{ParticipantUID: "p2::1220bb", Permission: model.ParticipantPermissionConfirmation},
+		},
+		PollInterval: 10 * time.Millisecond,
+	}
+	mapping, err := helper.UpdateHosting(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), mapping.Threshold)
+	require.Equal(t, int32(2), sync.serial)
+	require.Len(t, sync.authorized, 2)
+	require.True(t, sync.queries[0].BaseQuery.TimeQuery.HeadState)
+	require.Equal(t, "synchronizer:sync::1220dd", sync.queries[0].BaseQuery.Store.Value)
+
+	sync.authorized = make(map[string]bool)
+	_, err = helper.UpdateHosting(ctx, req)
+	require.NoError(t, err)
+	require.Empty(t, sync.authorized)
+	require.Equal(t, int32(2), sync.serial)
+}
+
+func TestPartyHostingHelper_Validation(t *testing.T) {
+	helper := topology.NewPartyHostingHelper(newFakeHostingNodes(&fakeHostingSynchronizer{}, "p1::1220aa")...)
+
+	tests := []struct {
+		name string
+		req  topology.PartyHostingRequest
+		err  string
+	}{
+		{"missing party", topology.PartyHostingRequest{Synchronizer: "sync::1220dd"}, "party"},
+		{"missing participants", topology.PartyHostingRequest{Party: "alice::1220aa", Synchronizer: "sync::1220dd"}, "hosting participant"},
+		{"threshold above confirming", topology.PartyHostingRequest{
+			Party:        "alice::1220aa",
+			Synchronizer: "sync::1220dd",
+			Threshold:    2,
+			Participants: []model.HostingParticipant{
+				{ParticipantUID: "p1::1220aa", Permission: model.ParticipantPermissionConfirmation},
+				{ParticipantUID: "p2::1220bb", Permission: model.ParticipantPermissionObservation},
+			},
+		}, "threshold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := helper.UpdateHosting(context.Background(), tt.req)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+const proposalBundleVersion = 1
+
+// ProposalBundle is the portable form of a topology proposal that several owners sign
+// independently, e.g. a change to a decentralized namespace.
+type ProposalBundle struct {
+	Transaction     []byte
+	TransactionHash []byte
+	RequiredSigners []string
+	Threshold       int
+	Signatures      []model.TopologyTransactionSignature
+}
+
+type proposalBundleFile struct {
+	Version         int                     `json:"version"`
+	Transaction     []byte                  `json:"transaction"`
+	TransactionHash []byte                  `json:"transaction_hash"`
+	RequiredSigners []string                `json:"required_signers"`
+	Threshold       int                     `json:"threshold"`
+	Signatures      []proposalSignatureFile `json:"signatures"`
+}
+
+type proposalSignatureFile struct {
+	SignedBy  string `json:"signed_by"`
+	Signature []byte `json:"signature"`
+	Format    int32  `json:"format"`
+	Algorithm int32  `json:"signing_algorithm_spec,omitempty"`
+}
+
+// ProposalStatus reports which required signers have signed a proposal.
+type ProposalStatus struct {
+	Signed    []string
+	Missing   []string
+	Threshold int
+	Ready     bool
+}
+
+// Encode writes the bundle as JSON so it can be handed to other signers.
+func (b *ProposalBundle) Encode(w io.Writer) error {
+	file := proposalBundleFile{
+		Version:         proposalBundleVersion,
+		Transaction:     b.Transaction,
+		TransactionHash: b.TransactionHash,
+		RequiredSigners: b.RequiredSigners,
+		Threshold:       b.Threshold,
+	}
+	for _, sig := range b.Signatures {
+		file.Signatures = append(file.Signatures, proposalSignatureFile{
+			SignedBy:  sig.SignedBy,
+			Signature: sig.Signature,
+			Format:    sig.SignatureFormat,
+			Algorithm: int32(sig.SigningAlgorithmSpec),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return fmt.Errorf("failed to encode proposal bundle: %w", err)
+	}
+	return nil
+}
+
+// DecodeProposalBundle reads a bundle written by Encode. The transaction hash is recomputed
+// from the transaction, and each required signer may appear and sign only once. Signatures
+// are not verified here; the participant verifies them when the proposal is submitted.
+func DecodeProposalBundle(r io.Reader) (*ProposalBundle, error) {
+	var file proposalBundleFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode proposal bundle: %w", err)
+	}
+	if file.Version != proposalBundleVersion {
+		return nil, fmt.Errorf("unsupported proposal bundle version %d", file.Version)
+	}
+	if len(file.Transaction) == 0 {
+		return nil, fmt.Errorf("proposal bundle has no transaction")
+	}
+	hash := TopologyTransactionHash(file.Transaction)
+	if !bytes.Equal(hash, file.TransactionHash) {
+		return nil, fmt.Errorf("proposal bundle transaction hash %x does not match its transaction (%x)", file.TransactionHash, hash)
+	}
+	if err := validateSigners(file.RequiredSigners, file.Threshold); err != nil {
+		return nil, err
+	}
+
+	b := &ProposalBundle{
+		Transaction:     file.Transaction,
+		TransactionHash: hash,
+		RequiredSigners: file.RequiredSigners,
+		Threshold:       file.Threshold,
+	}
+	for _, sig := range file.Signatures {
+		if slices.ContainsFunc(b.Signatures, func(s model.TopologyTransactionSignature) bool { return s.SignedBy == sig.SignedBy }) {
+			return nil, fmt.Errorf("proposal bundle has more than one signature by %s", sig.SignedBy)
+		}
+		b.Signatures = append(b.Signatures, model.TopologyTransactionSignature{
+			SignedBy:             sig.SignedBy,
+			Signature:            sig.Signature,
+			SignatureFormat:      sig.Format,
+			SigningAlgorithmSpec: model.SigningAlgorithmSpec(sig.Algorithm),
+		})
+	}
+	if err := b.checkSignatures(b.Signatures); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func validateSigners(signers []string, threshold int) error {
+	if len(signers) == 0 {
+		return fmt.Errorf("at least one required signer is needed")
+	}
+	for i, signer := range signers {
+		if signer == "" {
+			return fmt.Errorf("required signer %d is empty", i)
+		}
+		if slices.Contains(signers[:i], signer) {
+			return fmt.Errorf("required signer %s is listed more than once", signer)
+		}
+	}
+	if threshold < 1 || threshold > len(signers) {
+		return fmt.Errorf("threshold %d must be between 1 and %d", threshold, len(signers))
+	}
+	return nil
+}
+
+func (b *ProposalBundle) checkSignatures(sigs []model.TopologyTransactionSignature) error {
+	for _, sig := range sigs {
+		if !slices.Contains(b.RequiredSigners, sig.SignedBy) {
+			return fmt.Errorf("signer %s is not a required signer of this proposal", sig.SignedBy)
+		}
+		if len(sig.Signature) == 0 {
+			return fmt.Errorf("signature by %s is empty", sig.SignedBy)
+		}
+	}
+	return nil
+}
+
+// AddSignatures merges signatures produced elsewhere, e.g. by an offline key. Empty signatures
+// and signers outside RequiredSigners are rejected; a signer's later signature replaces an earlier one.
+func (b *ProposalBundle) AddSignatures(sigs ...model.TopologyTransactionSignature) error {
+	if err := b.checkSignatures(sigs); err != nil {
+		return err
+	}
+
+	for _, sig := range sigs {
+		idx := slices.IndexFunc(b.Signatures, func(s model.TopologyTransactionSignature) bool {
+			return s.SignedBy == sig.SignedBy
+		})
+		if idx >= 0 {
+			b.Signatures[idx] = sig
+		} else {
+			b.Signatures = append(b.Signatures, sig)
+		}
+	}
+
+	return nil
+}
+
+// Status counts each required signer with a signature once. It does not verify the signatures.
+func (b *ProposalBundle) Status() ProposalStatus {
+	status := ProposalStatus{Threshold: b.Threshold}
+	for _, signer := range b.RequiredSigners {
+		signed := slices.ContainsFunc(b.Signatures, func(s model.TopologyTransactionSignature) bool {
+			return s.SignedBy == signer
+		})
+		if signed {
+			status.Signed = append(status.Signed, signer)
+		} else {
+			status.Missing = append(status.Missing, signer)
+		}
+	}
+	status.Ready = len(status.Signed) >= b.Threshold
+
+	return status
+}
+
+func (b *ProposalBundle) signedTransaction() *model.SignedTopologyTransaction {
+	return &model.SignedTopologyTransaction{
+		Transaction: b.Transaction,
+		Signatures:  slices.Clone(b.Signatures),
+		Proposal:    !b.Status().Ready,
+	}
+}
+
+// ProposalWorkflow drives a multi-signature proposal through creation, signing on one
+// or more participants, and submission.
+type ProposalWorkflow struct {
+	write           TopologyManagerWrite
+	protocolVersion uint32
+}
+
+// NewProposalWorkflow creates a workflow. protocolVersion is used for the temporary
+// stores in which participants sign.
+func NewProposalWorkflow(write TopologyManagerWrite, protocolVersion uint32) *ProposalWorkflow {
+	return &ProposalWorkflow{
+		write:           write,
+		protocolVersion: protocolVersion,
+	}
+}
+
+// Create generates the transaction for proposal and returns an unsigned bundle that
+// needs threshold signatures out of requiredSigners (key fingerprints).
+func (w *ProposalWorkflow) Create(ctx context.Context, proposal *model.GenerateTransactionProposal, requiredSigners []string, threshold int) (*ProposalBundle, error) {
+	if err := validateSigners(requiredSigners, threshold); err != nil {
+		return nil, err
+	}
+
+	resp, err := w.write.GenerateTransactions(ctx, &model.GenerateTransactionsRequest{
+		Proposals: []*model.GenerateTransactionProposal{proposal},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate proposal: %w", err)
+	}
+	if len(resp.GeneratedTransactions) != 1 {
+		return nil, fmt.Errorf("expected 1 generated transaction, got %d", len(resp.GeneratedTransactions))
+	}
+
+	return &ProposalBundle{
+		Transaction:     resp.GeneratedTransactions[0].SerializedTransaction,
+		TransactionHash: resp.GeneratedTransactions[0].TransactionHash,
+		RequiredSigners: slices.Clone(requiredSigners),
+		Threshold:       threshold,
+	}, nil
+}
+
+// Sign has the connected participant sign the bundle with the keys in signedBy. Signing
+// happens against a temporary store so the participant's own stores are left untouched.
+// It fails, leaving the bundle unchanged, unless the participant signed with every key.
+func (w *ProposalWorkflow) Sign(ctx context.Context, bundle *ProposalBundle, signedBy []string) error {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to name temporary store: %w", err)
+	}
+	created, err := w.write.CreateTemporaryTopologyStore(ctx, &model.CreateTemporaryTopologyStoreRequest{
+		Name:            "proposal-" + hex.EncodeToString(bundle.TransactionHash[max(0, len(bundle.TransactionHash)-8):]) + "-" + hex.EncodeToString(suffix),
+		ProtocolVersion: w.protocolVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create temporary store: %w", err)
+	}
+	defer func() {
+		_, _ = w.write.DropTemporaryTopologyStore(context.WithoutCancel(ctx), &model.DropTemporaryTopologyStoreRequest{StoreID: created.StoreID})
+	}()
+
+	resp, err := w.write.SignTransactions(ctx, &model.SignTransactionsRequest{
+		Transactions: []*model.SignedTopologyTransaction{bundle.signedTransaction()},
+		SignedBy:     signedBy,
+		Store:        created.StoreID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign proposal: %w", err)
+	}
+	if len(resp.Transactions) != 1 {
+		return fmt.Errorf("expected 1 signed transaction, got %d", len(resp.Transactions))
+	}
+
+	var added []model.TopologyTransactionSignature
+	for _, sig := range resp.Transactions[0].Signatures {
+		if slices.Contains(signedBy, sig.SignedBy) {
+			added = append(added, sig)
+		}
+	}
+	var missing []string
+	for _, signer := range signedBy {
+		if !slices.ContainsFunc(added, func(s model.TopologyTransactionSignature) bool { return s.SignedBy == signer }) {
+			missing = append(missing, signer)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("participant did not sign the proposal with %v", missing)
+	}
+
+	return bundle.AddSignatures(added...)
+}
+
+// Submit adds the signed transaction to store, typically the synchronizer store. It fails
+// if the threshold has not been met.
+func (w *ProposalWorkflow) Submit(ctx context.Context, bundle *ProposalBundle, store *model.StoreID, waitToBecomeEffective *time.Duration) error {
+	status := bundle.Status()
+	if !status.Ready {
+		return fmt.Errorf("proposal has %d of %d required signatures, missing %v", len(status.Signed), status.Threshold, status.Missing)
+	}
+
+	_, err := w.write.AddTransactions(ctx, &model.AddTransactionsRequest{
+		Transactions:          []*model.SignedTopologyTransaction{bundle.signedTransaction()},
+		Store:                 store,
+		WaitToBecomeEffective: waitToBecomeEffective,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to submit proposal: %w", err)
+	}
+
+	return nil
+}
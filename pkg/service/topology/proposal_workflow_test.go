@@ -0,0 +1,149 @@
+package topology_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTopologyWrite struct {
+	topology.TopologyManagerWrite
+	storesCreated []string
+	storesDropped []string
+	added         []*model.SignedTopologyTransaction
+	// keys are the signers the participant holds keys for; nil means all of them.
+	keys []string
+}
+
+func (f *fakeTopologyWrite) GenerateTransactions(context.Context, *model.GenerateTransactionsRequest) (*model.GenerateTransactionsResponse, error) {
+	return &model.GenerateTransactionsResponse{GeneratedTransactions: []*model.GeneratedTransaction{
+		{SerializedTransaction: []byte("tx"), TransactionHash: topology.TopologyTransactionHash([]byte("tx"))},
+	}}, nil
+}
+
+func (f *fakeTopologyWrite) CreateTemporaryTopologyStore(_ context.Context, req *model.CreateTemporaryTopologyStoreRequest) (*model.CreateTemporaryTopologyStoreResponse, error) {
+	f.storesCreated = append(f.storesCreated, req.Name)
+	return &model.CreateTemporaryTopologyStoreResponse{StoreID: &model.StoreID{Value: "temporary:" + req.Name}}, nil
+}
+
+func (f *fakeTopologyWrite) DropTemporaryTopologyStore(_ context.Context, req *model.DropTemporaryTopologyStoreRequest) (*model.DropTemporaryTopologyStoreResponse, error) {
+	f.storesDropped = append(f.storesDropped, req.StoreID.Value)
+	return &model.DropTemporaryTopologyStoreResponse{}, nil
+}
+
+func (f *fakeTopologyWrite) SignTransactions(_ context.Context, req *model.SignTransactionsRequest) (*model.SignTransactionsResponse, error) {
+	tx := *req.Transactions[0]
+	for _, signer := range req.SignedBy {
+		if f.keys != nil && !slices.Contains(f.keys, signer) {
+			continue
+		}
+		tx.Signatures = append(tx.Signatures, model.TopologyTransactionSignature{SignedBy: signer, Signature: []byte("sig-" + signer)})
+	}
+	return &model.SignTransactionsResponse{Transactions: []*model.SignedTopologyTransaction{&tx}}, nil
+}
+
+func (f *fakeTopologyWrite) AddTransactions(_ context.Context, req *model.AddTransactionsRequest) (*model.AddTransactionsResponse, error) {
+	f.added = append(f.added, req.Transactions...)
+	return &model.AddTransactionsResponse{}, nil
+}
+
+func TestProposalWorkflow(t *testing.T) {
+	ctx := context.Background()
+	write := &fakeTopologyWrite{}
+	workflow := topology.NewProposalWorkflow(write, 33)
+
+	bundle, err := workflow.Create(ctx, &model.GenerateTransactionProposal{
+		Operation: model.OperationAddReplace,
+		Serial:    2,
+		Mapping:   &model.DecentralizedNamespaceDefinition{Namespace: "1220dn", Threshold: 2, Owners: []string{"1220a", "1220b", "1220c"}},
+	}, []string{"1220a", "1220b", "1220c"}, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1220a", "1220b", "1220c"}, bundle.Status().Missing)
+
+	require.NoError(t, workflow.Sign(ctx, bundle, []string{"1220a"}))
+	require.Len(t, write.storesCreated, 1)
+	require.Equal(t, []string{"temporary:" + write.storesCreated[0]}, write.storesDropped)
+
+	write.keys = []string{"1220a"}
+	require.ErrorContains(t, workflow.Sign(ctx, bundle, []string{"1220a", "1220b"}), "did not sign the proposal with [1220b]")
+	require.Equal(t, []string{"1220b", "1220c"}, bundle.Status().Missing)
+	require.NotEqual(t, write.storesCreated[0], write.storesCreated[1])
+	write.keys = nil
+
+	err = workflow.Submit(ctx, bundle, &model.StoreID{Value: "synchronizer:sync::1220dd"}, nil)
+	require.ErrorContains(t, err, "missing [1220b 1220c]")
+
+	var file bytes.Buffer
+	require.NoError(t, bundle.Encode(&file))
+	decoded, err := topology.DecodeProposalBundle(&file)
+	require.NoError(t, err)
+	require.Equal(t, bundle, decoded)
+
+	require.Error(t, decoded.AddSignatures(model.TopologyTransactionSignature{SignedBy: "1220z"}))
+	require.NoError(t, decoded.AddSignatures(model.TopologyTransactionSignature{SignedBy: "1220b", Signature: []byte("offline")}))
+
+	require.Error(t, decoded.AddSignatures(model.TopologyTransactionSignature{SignedBy: "1220c"}))
+
+	status := decoded.Status()
+	require.True(t, status.Ready)
+	require.Equal(t, []string{"1220c"}, status.Missing)
+
+	require.NoError(t, workflow.Submit(ctx, decoded, &model.StoreID{Value: "synchronizer:sync::1220dd"}, nil))
+	require.Len(t, write.added, 1)
+	require.False(t, write.added[0].Proposal)
+	require.Len(t, write.added[0].Signatures, 2)
+}
+
+func TestDecodeProposalBundle_Rejects(t *testing.T) {
+	tx := []byte("tx")
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"version":          1,
+			"transaction":      tx,
+			"transaction_hash": topology.TopologyTransactionHash(tx),
+			"required_signers": []string{"1220a", "1220b"},
+			"threshold":        2,
+			"signatures":       []map[string]interface{}{{"signed_by": "1220a", "signature": []byte("sig")}},
+		}
+	}
+	decode := func(file map[string]interface{}) error {
+		data, err := json.Marshal(file)
+		require.NoError(t, err)
+		_, err = topology.DecodeProposalBundle(bytes.NewReader(data))
+		return err
+	}
+	require.NoError(t, decode(valid()))
+
+	tests := []struct {
+		name   string
+		modify func(map[string]interface{})
+		err    string
+	}{
+		{"tampered transaction", func(f map[string]interface{}) { f["transaction"] = []byte("other") }, "does not match"},
+		{"missing hash", func(f map[string]interface{}) { delete(f, "transaction_hash") }, "does not match"},
+		{"duplicate signer", func(f map[string]interface{}) { f["required_signers"] = []string{"1220a", "1220a"} }, "more than once"},
+		{"threshold", func(f map[string]interface{}) { f["threshold"] = 3 }, "threshold"},
+		{"duplicate signature", func(f map[string]interface{}) {
+			f["signatures"] = []map[string]interface{}{{"signed_by": "1220a", "signature": []byte("a")}, {"signed_by": "1220a", "signature": []byte("b")}}
+		}, "more than one signature"},
+		{"unknown signer", func(f map[string]interface{}) {
+			f["signatures"] = []map[string]interface{}{{"signed_by": "1220z", "signature": []byte("sig")}}
+		}, "not a required signer"},
+		{"empty signature", func(f map[string]interface{}) {
+			f["signatures"] = []map[string]interface{}{{"signed_by": "1220b"}}
+		}, "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := valid()
+			tt.modify(file)
+			require.ErrorContains(t, decode(file), tt.err)
+		})
+	}
+}
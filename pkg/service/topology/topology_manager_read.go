@@ -2,9 +2,12 @@ package topology
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	cryptov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/crypto/v30"
@@ -17,6 +20,16 @@ type TopologyManagerRead interface {
 	ListNamespaceDelegation(ctx context.Context, req *model.ListNamespaceDelegationRequest) (*model.ListNamespaceDelegationResponse, error)
 	ListPartyToKeyMapping(ctx context.Context, req *model.ListPartyToKeyMappingRequest) (*model.ListPartyToKeyMappingResponse, error)
 	ListPartyToParticipant(ctx context.Context, req *model.ListPartyToParticipantRequest) (*model.ListPartyToParticipantResponse, error)
+	ListOwnerToKeyMapping(ctx context.Context, req *model.ListOwnerToKeyMappingRequest) (*model.ListOwnerToKeyMappingResponse, error)
+	ListDecentralizedNamespaceDefinition(ctx context.Context, req *model.ListDecentralizedNamespaceDefinitionRequest) (*model.ListDecentralizedNamespaceDefinitionResponse, error)
+	ListSynchronizerTrustCertificate(ctx context.Context, req *model.ListSynchronizerTrustCertificateRequest) (*model.ListSynchronizerTrustCertificateResponse, error)
+	ListParticipantSynchronizerPermission(ctx context.Context, req *model.ListParticipantSynchronizerPermissionRequest) (*model.ListParticipantSynchronizerPermissionResponse, error)
+	ListSynchronizerParametersState(ctx context.Context, req *model.ListSynchronizerParametersStateRequest) (*model.ListSynchronizerParametersStateResponse, error)
+	ListMediatorSynchronizerState(ctx context.Context, req *model.ListMediatorSynchronizerStateRequest) (*model.ListMediatorSynchronizerStateResponse, error)
+	ListSequencerSynchronizerState(ctx context.Context, req *model.ListSequencerSynchronizerStateRequest) (*model.ListSequencerSynchronizerStateResponse, error)
+	ListVettedPackages(ctx context.Context, req *model.ListVettedPackagesRequest) (*model.ListVettedPackagesResponse, error)
+	ListAll(ctx context.Context, req *model.ListAllRequest) (*model.ListAllResponse, error)
+	ExportTopologySnapshot(ctx context.Context, req *model.ExportTopologySnapshotRequest, w io.Writer) (int64, error)
 }
 
 type topologyManagerRead struct {
@@ -31,7 +44,10 @@ func NewTopologyManagerReadClient(conn *grpc.ClientConn) *topologyManagerRead {
 }
 
 func (c *topologyManagerRead) ListNamespaceDelegation(ctx context.Context, req *model.ListNamespaceDelegationRequest) (*model.ListNamespaceDelegationResponse, error) {
-	protoReq := listNamespaceDelegationRequestToProto(req)
+	protoReq, err := listNamespaceDelegationRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.ListNamespaceDelegation(ctx, protoReq)
 	if err != nil {
@@ -42,7 +58,10 @@ func (c *topologyManagerRead) ListNamespaceDelegation(ctx context.Context, req *
 }
 
 func (c *topologyManagerRead) ListPartyToKeyMapping(ctx context.Context, req *model.ListPartyToKeyMappingRequest) (*model.ListPartyToKeyMappingResponse, error) {
-	protoReq := listPartyToKeyMappingRequestToProto(req)
+	protoReq, err := listPartyToKeyMappingRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.ListPartyToKeyMapping(ctx, protoReq)
 	if err != nil {
@@ -53,7 +72,10 @@ func (c *topologyManagerRead) ListPartyToKeyMapping(ctx context.Context, req *mo
 }
 
 func (c *topologyManagerRead) ListPartyToParticipant(ctx context.Context, req *model.ListPartyToParticipantRequest) (*model.ListPartyToParticipantResponse, error) {
-	protoReq := listPartyToParticipantRequestToProto(req)
+	protoReq, err := listPartyToParticipantRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.ListPartyToParticipant(ctx, protoReq)
 	if err != nil {
@@ -63,16 +85,183 @@ func (c *topologyManagerRead) ListPartyToParticipant(ctx context.Context, req *m
 	return listPartyToParticipantResponseFromProto(resp), nil
 }
 
-func listNamespaceDelegationRequestToProto(req *model.ListNamespaceDelegationRequest) *topov30.ListNamespaceDelegationRequest {
+func (c *topologyManagerRead) ListOwnerToKeyMapping(ctx context.Context, req *model.ListOwnerToKeyMappingRequest) (*model.ListOwnerToKeyMappingResponse, error) {
+	protoReq, err := listOwnerToKeyMappingRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListOwnerToKeyMapping(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listOwnerToKeyMappingResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListDecentralizedNamespaceDefinition(ctx context.Context, req *model.ListDecentralizedNamespaceDefinitionRequest) (*model.ListDecentralizedNamespaceDefinitionResponse, error) {
+	protoReq, err := listDecentralizedNamespaceDefinitionRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListDecentralizedNamespaceDefinition(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listDecentralizedNamespaceDefinitionResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListSynchronizerTrustCertificate(ctx context.Context, req *model.ListSynchronizerTrustCertificateRequest) (*model.ListSynchronizerTrustCertificateResponse, error) {
+	protoReq, err := listSynchronizerTrustCertificateRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListSynchronizerTrustCertificate(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listSynchronizerTrustCertificateResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListParticipantSynchronizerPermission(ctx context.Context, req *model.ListParticipantSynchronizerPermissionRequest) (*model.ListParticipantSynchronizerPermissionResponse, error) {
+	protoReq, err := listParticipantSynchronizerPermissionRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListParticipantSynchronizerPermission(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listParticipantSynchronizerPermissionResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListSynchronizerParametersState(ctx context.Context, req *model.ListSynchronizerParametersStateRequest) (*model.ListSynchronizerParametersStateResponse, error) {
+	protoReq, err := listSynchronizerParametersStateRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListSynchronizerParametersState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listSynchronizerParametersStateResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListMediatorSynchronizerState(ctx context.Context, req *model.ListMediatorSynchronizerStateRequest) (*model.ListMediatorSynchronizerStateResponse, error) {
+	protoReq, err := listMediatorSynchronizerStateRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListMediatorSynchronizerState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listMediatorSynchronizerStateResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListSequencerSynchronizerState(ctx context.Context, req *model.ListSequencerSynchronizerStateRequest) (*model.ListSequencerSynchronizerStateResponse, error) {
+	protoReq, err := listSequencerSynchronizerStateRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListSequencerSynchronizerState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listSequencerSynchronizerStateResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListVettedPackages(ctx context.Context, req *model.ListVettedPackagesRequest) (*model.ListVettedPackagesResponse, error) {
+	protoReq, err := listVettedPackagesRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListVettedPackages(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listVettedPackagesResponseFromProto(resp), nil
+}
+
+func (c *topologyManagerRead) ListAll(ctx context.Context, req *model.ListAllRequest) (*model.ListAllResponse, error) {
+	protoReq, err := listAllRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.ListAll(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return listAllResponseFromProto(resp), nil
+}
+
+// ExportTopologySnapshot streams the selected topology transactions to w and returns the number of bytes written.
+func (c *topologyManagerRead) ExportTopologySnapshot(ctx context.Context, req *model.ExportTopologySnapshotRequest, w io.Writer) (int64, error) {
+	protoReq := &topov30.ExportTopologySnapshotRequest{}
+	if req != nil {
+		baseQuery, err := baseQueryToProto(req.BaseQuery)
+		if err != nil {
+			return 0, err
+		}
+		protoReq.BaseQuery = baseQuery
+		protoReq.ExcludeMappings = req.ExcludeMappings
+		protoReq.FilterNamespace = req.FilterNamespace
+	}
+
+	stream, err := c.client.ExportTopologySnapshot(ctx, protoReq)
+	if err != nil {
+		return 0, err
+	}
+
+	var written int64
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return written, nil
+		}
+		if err != nil {
+			return written, fmt.Errorf("failed to receive topology snapshot: %w", err)
+		}
+
+		n, err := w.Write(resp.Chunk)
+		written += int64(n)
+		if err != nil {
+			return written, fmt.Errorf("failed to write topology snapshot: %w", err)
+		}
+	}
+}
+
+func listNamespaceDelegationRequestToProto(req *model.ListNamespaceDelegationRequest) (*topov30.ListNamespaceDelegationRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
 	}
 
 	return &topov30.ListNamespaceDelegationRequest{
-		BaseQuery:                  baseQueryToProto(req.BaseQuery),
+		BaseQuery:                  baseQuery,
 		FilterNamespace:            req.FilterNamespace,
 		FilterTargetKeyFingerprint: req.FilterTargetKeyFingerprint,
-	}
+	}, nil
 }
 
 func listNamespaceDelegationResponseFromProto(pb *topov30.ListNamespaceDelegationResponse) *model.ListNamespaceDelegationResponse {
@@ -90,15 +279,20 @@ func listNamespaceDelegationResponseFromProto(pb *topov30.ListNamespaceDelegatio
 	}
 }
 
-func listPartyToKeyMappingRequestToProto(req *model.ListPartyToKeyMappingRequest) *topov30.ListPartyToKeyMappingRequest {
+func listPartyToKeyMappingRequestToProto(req *model.ListPartyToKeyMappingRequest) (*topov30.ListPartyToKeyMappingRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
 	}
 
 	return &topov30.ListPartyToKeyMappingRequest{
-		BaseQuery:   baseQueryToProto(req.BaseQuery),
+		BaseQuery:   baseQuery,
 		FilterParty: req.FilterParty,
-	}
+	}, nil
 }
 
 func listPartyToKeyMappingResponseFromProto(pb *topov30.ListPartyToKeyMappingResponse) *model.ListPartyToKeyMappingResponse {
@@ -116,16 +310,21 @@ func listPartyToKeyMappingResponseFromProto(pb *topov30.ListPartyToKeyMappingRes
 	}
 }
 
-func listPartyToParticipantRequestToProto(req *model.ListPartyToParticipantRequest) *topov30.ListPartyToParticipantRequest {
+func listPartyToParticipantRequestToProto(req *model.ListPartyToParticipantRequest) (*topov30.ListPartyToParticipantRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
 	}
 
 	return &topov30.ListPartyToParticipantRequest{
-		BaseQuery:         baseQueryToProto(req.BaseQuery),
+		BaseQuery:         baseQuery,
 		FilterParty:       req.FilterParty,
 		FilterParticipant: req.FilterParticipant,
-	}
+	}, nil
 }
 
 func listPartyToParticipantResponseFromProto(pb *topov30.ListPartyToParticipantResponse) *model.ListPartyToParticipantResponse {
@@ -143,13 +342,416 @@ func listPartyToParticipantResponseFromProto(pb *topov30.ListPartyToParticipantR
 	}
 }
 
-func baseQueryToProto(query *model.BaseQuery) *topov30.BaseQuery {
-	if query == nil {
+func listOwnerToKeyMappingRequestToProto(req *model.ListOwnerToKeyMappingRequest) (*topov30.ListOwnerToKeyMappingRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListOwnerToKeyMappingRequest{
+		BaseQuery:          baseQuery,
+		FilterKeyOwnerType: req.FilterKeyOwnerType,
+		FilterKeyOwnerUid:  req.FilterKeyOwnerUID,
+	}, nil
+}
+
+func listOwnerToKeyMappingResponseFromProto(pb *topov30.ListOwnerToKeyMappingResponse) *model.ListOwnerToKeyMappingResponse {
+	if pb == nil {
 		return nil
 	}
 
+	results := make([]*model.OwnerToKeyMappingResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = ownerToKeyMappingResultFromProto(r)
+	}
+
+	return &model.ListOwnerToKeyMappingResponse{
+		Results: results,
+	}
+}
+
+func ownerToKeyMappingResultFromProto(pb *topov30.ListOwnerToKeyMappingResponse_Result) *model.OwnerToKeyMappingResult {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.OwnerToKeyMappingResult{
+		Context: baseResultFromProto(pb.Context),
+		Item:    ownerToKeyMappingFromProto(pb.Item),
+	}
+}
+
+func listDecentralizedNamespaceDefinitionRequestToProto(req *model.ListDecentralizedNamespaceDefinitionRequest) (*topov30.ListDecentralizedNamespaceDefinitionRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListDecentralizedNamespaceDefinitionRequest{
+		BaseQuery:       baseQuery,
+		FilterNamespace: req.FilterNamespace,
+	}, nil
+}
+
+func listDecentralizedNamespaceDefinitionResponseFromProto(pb *topov30.ListDecentralizedNamespaceDefinitionResponse) *model.ListDecentralizedNamespaceDefinitionResponse {
+	if pb == nil {
+		return nil
+	}
+
+	results := make([]*model.DecentralizedNamespaceDefinitionResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = decentralizedNamespaceDefinitionResultFromProto(r)
+	}
+
+	return &model.ListDecentralizedNamespaceDefinitionResponse{
+		Results: results,
+	}
+}
+
+func decentralizedNamespaceDefinitionResultFromProto(pb *topov30.ListDecentralizedNamespaceDefinitionResponse_Result) *model.DecentralizedNamespaceDefinitionResult {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.DecentralizedNamespaceDefinitionResult{
+		Context: baseResultFromProto(pb.Context),
+		Item:    decentralizedNamespaceDefinitionFromProto(pb.Item),
+	}
+}
+
+func listSynchronizerTrustCertificateRequestToProto(req *model.ListSynchronizerTrustCertificateRequest) (*topov30.ListSynchronizerTrustCertificateRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListSynchronizerTrustCertificateRequest{
+		BaseQuery: baseQuery,
+		FilterUid: req.FilterUID,
+	}, nil
+}
+
+func listSynchronizerTrustCertificateResponseFromProto(pb *topov30.ListSynchronizerTrustCertificateResponse) *model.ListSynchronizerTrustCertificateResponse {
+	if pb == nil {
+		return nil
+	}
+
+	results := make([]*model.SynchronizerTrustCertificateResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = synchronizerTrustCertificateResultFromProto(r)
+	}
+
+	return &model.ListSynchronizerTrustCertificateResponse{
+		Results: results,
+	}
+}
+
+func synchronizerTrustCertificateResultFromProto(pb *topov30.ListSynchronizerTrustCertificateResponse_Result) *model.SynchronizerTrustCertificateResult {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.SynchronizerTrustCertificateResult{
+		Context: baseResultFromProto(pb.Context),
+		Item:    synchronizerTrustCertificateFromProto(pb.Item),
+	}
+}
+
+func listParticipantSynchronizerPermissionRequestToProto(req *model.ListParticipantSynchronizerPermissionRequest) (*topov30.ListParticipantSynchronizerPermissionRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListParticipantSynchronizerPermissionRequest{
+		BaseQuery: baseQuery,
+		FilterUid: req.FilterUID,
+	}, nil
+}
+
+func listParticipantSynchronizerPermissionResponseFromProto(pb *topov30.ListParticipantSynchronizerPermissionResponse) *model.ListParticipantSynchronizerPermissionResponse {
+	if pb == nil {
+		return nil
+	}
+
+	results := make([]*model.ParticipantSynchronizerPermissionResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = participantSynchronizerPermissionResultFromProto(r)
+	}
+
+	return &model.ListParticipantSynchronizerPermissionResponse{
+		Results: results,
+	}
+}
+
+func participantSynchronizerPermissionResultFromProto(pb *topov30.ListParticipantSynchronizerPermissionResponse_Result) *model.ParticipantSynchronizerPermissionResult {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.ParticipantSynchronizerPermissionResult{
+		Context: baseResultFromProto(pb.Context),
+		Item:    participantSynchronizerPermissionFromProto(pb.Item),
+	}
+}
+
+func listSynchronizerParametersStateRequestToProto(req *model.ListSynchronizerParametersStateRequest) (*topov30.ListSynchronizerParametersStateRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListSynchronizerParametersStateRequest{
+		BaseQuery:            baseQuery,
+		FilterSynchronizerId: req.FilterSynchronizerID,
+	}, nil
+}
+
+func listSynchronizerParametersStateResponseFromProto(pb *topov30.ListSynchronizerParametersStateResponse) *model.ListSynchronizerParametersStateResponse {
+	if pb == nil {
+		return nil
+	}
+
+	results := make([]*model.SynchronizerParametersStateResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = synchronizerParametersStateResultFromProto(r)
+	}
+
+	return &model.ListSynchronizerParametersStateResponse{
+		Results: results,
+	}
+}
+
+func synchronizerParametersStateResultFromProto(pb *topov30.ListSynchronizerParametersStateResponse_Result) *model.SynchronizerParametersStateResult {
+	if pb == nil {
+		return nil
+	}
+
+	result := &model.SynchronizerParametersStateResult{
+		Context: baseResultFromProto(pb.Context),
+	}
+	if pb.Item != nil {
+		// The result only holds the parameters; the synchronizer is the one whose store was read.
+		result.Item = &model.SynchronizerParametersState{Parameters: dynamicSynchronizerParametersFromProto(pb.Item)}
+		if result.Context != nil && result.Context.Store != nil {
+			result.Item.SynchronizerID = result.Context.Store.SynchronizerID()
+		}
+	}
+	return result
+}
+
+func listMediatorSynchronizerStateRequestToProto(req *model.ListMediatorSynchronizerStateRequest) (*topov30.ListMediatorSynchronizerStateRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListMediatorSynchronizerStateRequest{
+		BaseQuery:            baseQuery,
+		FilterSynchronizerId: req.FilterSynchronizerID,
+	}, nil
+}
+
+func listMediatorSynchronizerStateResponseFromProto(pb *topov30.ListMediatorSynchronizerStateResponse) *model.ListMediatorSynchronizerStateResponse {
+	if pb == nil {
+		return nil
+	}
+
+	results := make([]*model.MediatorSynchronizerStateResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = mediatorSynchronizerStateResultFromProto(r)
+	}
+
+	return &model.ListMediatorSynchronizerStateResponse{
+		Results: results,
+	}
+}
+
+func mediatorSynchronizerStateResultFromProto(pb *topov30.ListMediatorSynchronizerStateResponse_Result) *model.MediatorSynchronizerStateResult {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.MediatorSynchronizerStateResult{
+		Context: baseResultFromProto(pb.Context),
+		Item:    mediatorSynchronizerStateFromProto(pb.Item),
+	}
+}
+
+func listSequencerSynchronizerStateRequestToProto(req *model.ListSequencerSynchronizerStateRequest) (*topov30.ListSequencerSynchronizerStateRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListSequencerSynchronizerStateRequest{
+		BaseQuery:            baseQuery,
+		FilterSynchronizerId: req.FilterSynchronizerID,
+	}, nil
+}
+
+func listSequencerSynchronizerStateResponseFromProto(pb *topov30.ListSequencerSynchronizerStateResponse) *model.ListSequencerSynchronizerStateResponse {
+	if pb == nil {
+		return nil
+	}
+
+	results := make([]*model.SequencerSynchronizerStateResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = sequencerSynchronizerStateResultFromProto(r)
+	}
+
+	return &model.ListSequencerSynchronizerStateResponse{
+		Results: results,
+	}
+}
+
+func sequencerSynchronizerStateResultFromProto(pb *topov30.ListSequencerSynchronizerStateResponse_Result) *model.SequencerSynchronizerStateResult {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.SequencerSynchronizerStateResult{
+		Context: baseResultFromProto(pb.Context),
+		Item:    sequencerSynchronizerStateFromProto(pb.Item),
+	}
+}
+
+func listVettedPackagesRequestToProto(req *model.ListVettedPackagesRequest) (*topov30.ListVettedPackagesRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListVettedPackagesRequest{
+		BaseQuery:         baseQuery,
+		FilterParticipant: req.FilterParticipant,
+	}, nil
+}
+
+func listVettedPackagesResponseFromProto(pb *topov30.ListVettedPackagesResponse) *model.ListVettedPackagesResponse {
+	if pb == nil {
+		return nil
+	}
+
+	results := make([]*model.VettedPackagesResult, len(pb.Results))
+	for i, r := range pb.Results {
+		results[i] = vettedPackagesResultFromProto(r)
+	}
+
+	return &model.ListVettedPackagesResponse{
+		Results: results,
+	}
+}
+
+func vettedPackagesResultFromProto(pb *topov30.ListVettedPackagesResponse_Result) *model.VettedPackagesResult {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.VettedPackagesResult{
+		Context: baseResultFromProto(pb.Context),
+		Item:    vettedPackagesFromProto(pb.Item),
+	}
+}
+
+func listAllRequestToProto(req *model.ListAllRequest) (*topov30.ListAllRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	baseQuery, err := baseQueryToProto(req.BaseQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &topov30.ListAllRequest{
+		BaseQuery:       baseQuery,
+		ExcludeMappings: req.ExcludeMappings,
+		FilterNamespace: req.FilterNamespace,
+	}, nil
+}
+
+func listAllResponseFromProto(pb *topov30.ListAllResponse) *model.ListAllResponse {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.ListAllResponse{
+		Items: topologyTransactionItemsFromProto(pb.Result),
+	}
+}
+
+func topologyTransactionItemsFromProto(pb *topov30.TopologyTransactions) []*model.TopologyTransactionItem {
+	if pb == nil {
+		return nil
+	}
+
+	items := make([]*model.TopologyTransactionItem, len(pb.Items))
+	for i, item := range pb.Items {
+		items[i] = &model.TopologyTransactionItem{
+			Sequenced:       timestampToTime(item.Sequenced),
+			ValidFrom:       timestampToTime(item.ValidFrom),
+			ValidUntil:      timestampToTime(item.ValidUntil),
+			Transaction:     item.Transaction,
+			RejectionReason: item.RejectionReason,
+		}
+	}
+
+	return items
+}
+
+func timestampToTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func baseQueryToProto(query *model.BaseQuery) (*topov30.BaseQuery, error) {
+	if query == nil {
+		return nil, nil
+	}
+
+	store, err := storeIDToProto(query.Store)
+	if err != nil {
+		return nil, err
+	}
+
 	pbQuery := &topov30.BaseQuery{
-		Store:           storeIDToProto(query.Store),
+		Store:           store,
 		Proposals:       query.Proposals,
 		Operation:       operationToProto(query.Operation),
 		FilterSignedKey: query.FilterSignedKey,
@@ -160,7 +762,26 @@ func baseQueryToProto(query *model.BaseQuery) *topov30.BaseQuery {
 	}
 
 	if query.TimeQuery != nil {
-		if query.TimeQuery.Serial != nil {
+		tq := query.TimeQuery
+		set := 0
+		for _, ok := range []bool{tq.HeadState, tq.Snapshot != nil, tq.Serial != nil, tq.Range != nil} {
+			if ok {
+				set++
+			}
+		}
+		if set > 1 {
+			return nil, fmt.Errorf("time query must set only one of HeadState, Snapshot, Serial or Range")
+		}
+
+		if query.TimeQuery.HeadState {
+			pbQuery.TimeQuery = &topov30.BaseQuery_HeadState{
+				HeadState: &emptypb.Empty{},
+			}
+		} else if query.TimeQuery.Snapshot != nil {
+			pbQuery.TimeQuery = &topov30.BaseQuery_Snapshot{
+				Snapshot: timestamppb.New(*query.TimeQuery.Snapshot),
+			}
+		} else if query.TimeQuery.Serial != nil {
 			pbQuery.TimeQuery = &topov30.BaseQuery_Snapshot{
 				Snapshot: timestamppb.New(time.Unix(*query.TimeQuery.Serial, 0)),
 			}
@@ -178,7 +799,7 @@ func baseQueryToProto(query *model.BaseQuery) *topov30.BaseQuery {
 		}
 	}
 
-	return pbQuery
+	return pbQuery, nil
 }
 
 func operationToProto(op model.Operation) protov30.Enums_TopologyChangeOp {
@@ -294,11 +915,26 @@ func namespaceDelegationFromProto(pb *protov30.NamespaceDelegation) *model.Names
 		return nil
 	}
 
-	return &model.NamespaceDelegationMapping{
-		Namespace:        pb.Namespace,
+	mapping := &model.NamespaceDelegationMapping{
+		Namespace:        model.Namespace(pb.Namespace),
 		TargetKey:        signingPublicKeyFromProto(pb.TargetKey),
 		IsRootDelegation: pb.IsRootDelegation,
 	}
+
+	switch r := pb.Restriction.(type) {
+	case *protov30.NamespaceDelegation_CanSignAllMappings_:
+		mapping.Restriction = &model.DelegationRestriction{CanSignAllMappings: true}
+	case *protov30.NamespaceDelegation_CanSignAllButNamespaceDelegations_:
+		mapping.Restriction = &model.DelegationRestriction{CanSignAllButNamespaceDelegations: true}
+	case *protov30.NamespaceDelegation_CanSignSpecificMapings:
+		codes := make([]model.TopologyMappingCode, len(r.CanSignSpecificMapings.GetMappings()))
+		for i, c := range r.CanSignSpecificMapings.GetMappings() {
+			codes[i] = model.TopologyMappingCode(c)
+		}
+		mapping.Restriction = &model.DelegationRestriction{CanSignSpecificMappings: codes}
+	}
+
+	return mapping
 }
 
 func partyToKeyMappingFromProto(pb *protov30.PartyToKeyMapping) *model.PartyToKeyMapping {
@@ -312,7 +948,7 @@ func partyToKeyMappingFromProto(pb *protov30.PartyToKeyMapping) *model.PartyToKe
 	}
 
 	return &model.PartyToKeyMapping{
-		Party:       pb.Party,
+		Party:       model.PartyID(pb.Party),
 		Threshold:   pb.Threshold,
 		SigningKeys: keys,
 	}
@@ -326,16 +962,30 @@ func partyToParticipantMappingFromProto(pb *protov30.PartyToParticipant) *model.
 	participants := make([]model.HostingParticipant, len(pb.Participants))
 	for i, p := range pb.Participants {
 		participants[i] = model.HostingParticipant{
-			ParticipantUID: p.ParticipantUid,
+			ParticipantUID: model.ParticipantUID(p.ParticipantUid),
 			Permission:     participantPermissionFromProto(p.Permission),
+			Onboarding:     p.Onboarding != nil,
 		}
 	}
 
-	return &model.PartyToParticipantMapping{
-		Party:        pb.Party,
+	mapping := &model.PartyToParticipantMapping{
+		Party:        model.PartyID(pb.Party),
 		Threshold:    pb.Threshold,
 		Participants: participants,
 	}
+
+	if pb.PartySigningKeys != nil {
+		keys := make([]model.PublicKey, len(pb.PartySigningKeys.Keys))
+		for i, k := range pb.PartySigningKeys.Keys {
+			keys[i] = signingPublicKeyFromProto(k)
+		}
+		mapping.PartySigningKeys = &model.SigningKeysWithThreshold{
+			Keys:      keys,
+			Threshold: pb.PartySigningKeys.Threshold,
+		}
+	}
+
+	return mapping
 }
 
 func participantPermissionFromProto(pp protov30.Enums_ParticipantPermission) model.ParticipantPermission {
@@ -349,13 +999,204 @@ func participantPermissionFromProto(pp protov30.Enums_ParticipantPermission) mod
 	}
 }
 
+func ownerToKeyMappingFromProto(pb *protov30.OwnerToKeyMapping) *model.OwnerToKeyMapping {
+	if pb == nil {
+		return nil
+	}
+
+	keys := make([]model.PublicKey, len(pb.PublicKeys))
+	for i, k := range pb.PublicKeys {
+		keys[i] = publicKeyFromProto(k)
+	}
+
+	return &model.OwnerToKeyMapping{
+		Member:     pb.Member,
+		PublicKeys: keys,
+	}
+}
+
+func decentralizedNamespaceDefinitionFromProto(pb *protov30.DecentralizedNamespaceDefinition) *model.DecentralizedNamespaceDefinition {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.DecentralizedNamespaceDefinition{
+		Namespace: model.Namespace(pb.DecentralizedNamespace),
+		Threshold: pb.Threshold,
+		Owners:    pb.Owners,
+	}
+}
+
+func synchronizerTrustCertificateFromProto(pb *protov30.SynchronizerTrustCertificate) *model.SynchronizerTrustCertificate {
+	if pb == nil {
+		return nil
+	}
+
+	var flags []model.ParticipantFeatureFlag
+	for _, f := range pb.FeatureFlags {
+		flags = append(flags, model.ParticipantFeatureFlag(f))
+	}
+
+	return &model.SynchronizerTrustCertificate{
+		ParticipantUID: model.ParticipantUID(pb.ParticipantUid),
+		SynchronizerID: model.SynchronizerID(pb.SynchronizerId),
+		FeatureFlags:   flags,
+	}
+}
+
+func participantSynchronizerPermissionFromProto(pb *protov30.ParticipantSynchronizerPermission) *model.ParticipantSynchronizerPermission {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.ParticipantSynchronizerPermission{
+		SynchronizerID: model.SynchronizerID(pb.SynchronizerId),
+		ParticipantUID: model.ParticipantUID(pb.ParticipantUid),
+		Permission:     participantPermissionFromProto(pb.Permission),
+		Limits:         participantSynchronizerLimitsFromProto(pb.Limits),
+		LoginAfter:     pb.LoginAfter,
+	}
+}
+
+func participantSynchronizerLimitsFromProto(pb *protov30.ParticipantSynchronizerLimits) *model.ParticipantSynchronizerLimits {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.ParticipantSynchronizerLimits{
+		ConfirmationRequestsMaxRate: pb.ConfirmationRequestsMaxRate,
+	}
+}
+
+func dynamicSynchronizerParametersFromProto(pb *protov30.DynamicSynchronizerParameters) *model.DynamicSynchronizerParameters {
+	if pb == nil {
+		return nil
+	}
+
+	params := &model.DynamicSynchronizerParameters{
+		ConfirmationResponseTimeout:         pb.ConfirmationResponseTimeout.AsDuration(),
+		MediatorReactionTimeout:             pb.MediatorReactionTimeout.AsDuration(),
+		AssignmentExclusivityTimeout:        pb.AssignmentExclusivityTimeout.AsDuration(),
+		LedgerTimeRecordTimeTolerance:       pb.LedgerTimeRecordTimeTolerance.AsDuration(),
+		ReconciliationInterval:              pb.ReconciliationInterval.AsDuration(),
+		MediatorDeduplicationTimeout:        pb.MediatorDeduplicationTimeout.AsDuration(),
+		MaxRequestSize:                      pb.MaxRequestSize,
+		OnboardingRestriction:               model.OnboardingRestriction(pb.OnboardingRestriction),
+		ParticipantSynchronizerLimits:       participantSynchronizerLimitsFromProto(pb.ParticipantSynchronizerLimits),
+		SequencerAggregateSubmissionTimeout: pb.SequencerAggregateSubmissionTimeout.AsDuration(),
+		PreparationTimeRecordTimeTolerance:  pb.PreparationTimeRecordTimeTolerance.AsDuration(),
+	}
+
+	if tc := pb.TrafficControl; tc != nil {
+		params.TrafficControl = &model.TrafficControlParameters{
+			MaxBaseTrafficAmount:                  tc.MaxBaseTrafficAmount,
+			MaxBaseTrafficAccumulationDuration:    tc.MaxBaseTrafficAccumulationDuration.AsDuration(),
+			ReadVsWriteScalingFactor:              tc.ReadVsWriteScalingFactor,
+			SetBalanceRequestSubmissionWindowSize: tc.SetBalanceRequestSubmissionWindowSize.AsDuration(),
+			EnforceRateLimiting:                   tc.EnforceRateLimiting,
+			BaseEventCost:                         tc.BaseEventCost,
+		}
+	}
+
+	if cu := pb.AcsCommitmentsCatchup; cu != nil {
+		params.AcsCommitmentsCatchup = &model.AcsCommitmentsCatchUpConfig{
+			CatchupIntervalSkip:         cu.CatchupIntervalSkip,
+			NrIntervalsToTriggerCatchup: cu.NrIntervalsToTriggerCatchup,
+		}
+	}
+
+	return params
+}
+
+func mediatorSynchronizerStateFromProto(pb *protov30.MediatorSynchronizerState) *model.MediatorSynchronizerState {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.MediatorSynchronizerState{
+		SynchronizerID: model.SynchronizerID(pb.SynchronizerId),
+		Group:          pb.Group,
+		Threshold:      pb.Threshold,
+		Active:         pb.Active,
+		Observers:      pb.Observers,
+	}
+}
+
+func sequencerSynchronizerStateFromProto(pb *protov30.SequencerSynchronizerState) *model.SequencerSynchronizerState {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.SequencerSynchronizerState{
+		SynchronizerID: model.SynchronizerID(pb.SynchronizerId),
+		Threshold:      pb.Threshold,
+		Active:         pb.Active,
+		Observers:      pb.Observers,
+	}
+}
+
+func vettedPackagesFromProto(pb *protov30.VettedPackages) *model.VettedPackages {
+	if pb == nil {
+		return nil
+	}
+
+	packages := make([]model.VettedPackage, 0, len(pb.Packages)+len(pb.PackageIds))
+	for _, id := range pb.PackageIds {
+		packages = append(packages, model.VettedPackage{PackageID: id})
+	}
+	for _, p := range pb.Packages {
+		packages = append(packages, model.VettedPackage{
+			PackageID:  p.PackageId,
+			ValidFrom:  timestampToTime(p.ValidFromInclusive),
+			ValidUntil: timestampToTime(p.ValidUntilExclusive),
+		})
+	}
+
+	return &model.VettedPackages{
+		ParticipantUID: model.ParticipantUID(pb.ParticipantUid),
+		Packages:       packages,
+	}
+}
+
+func publicKeyFromProto(pb *cryptov30.PublicKey) model.PublicKey {
+	if pb == nil {
+		return model.PublicKey{}
+	}
+
+	switch key := pb.Key.(type) {
+	case *cryptov30.PublicKey_SigningPublicKey:
+		return signingPublicKeyFromProto(key.SigningPublicKey)
+	case *cryptov30.PublicKey_EncryptionPublicKey:
+		if key.EncryptionPublicKey == nil {
+			return model.PublicKey{Purpose: model.KeyPurposeEncryption}
+		}
+		return model.PublicKey{
+			Format:  int32(key.EncryptionPublicKey.Format),
+			Key:     key.EncryptionPublicKey.PublicKey,
+			Scheme:  int32(key.EncryptionPublicKey.Scheme),
+			KeySpec: int32(key.EncryptionPublicKey.KeySpec),
+			Purpose: model.KeyPurposeEncryption,
+		}
+	default:
+		return model.PublicKey{}
+	}
+}
+
 func signingPublicKeyFromProto(pb *cryptov30.SigningPublicKey) model.PublicKey {
 	if pb == nil {
 		return model.PublicKey{}
 	}
+
+	usage := make([]int32, len(pb.Usage))
+	for i, u := range pb.Usage {
+		usage[i] = int32(u)
+	}
+
 	return model.PublicKey{
-		Format: int32(pb.Format),
-		Key:    pb.PublicKey,
-		ID:     "",
+		Format:  int32(pb.Format),
+		Key:     pb.PublicKey,
+		Scheme:  int32(pb.Scheme),
+		KeySpec: int32(pb.KeySpec),
+		Usage:   usage,
 	}
 }
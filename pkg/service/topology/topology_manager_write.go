@@ -1,8 +1,11 @@
 package topology
 
 import (
+	"bytes"
 	"context"
-	"strings"
+	"errors"
+	"fmt"
+	"io"
 
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -20,8 +23,11 @@ type TopologyManagerWrite interface {
 	GenerateTransactions(ctx context.Context, req *model.GenerateTransactionsRequest) (*model.GenerateTransactionsResponse, error)
 	CreateTemporaryTopologyStore(ctx context.Context, req *model.CreateTemporaryTopologyStoreRequest) (*model.CreateTemporaryTopologyStoreResponse, error)
 	DropTemporaryTopologyStore(ctx context.Context, req *model.DropTemporaryTopologyStoreRequest) (*model.DropTemporaryTopologyStoreResponse, error)
+	ImportTopologySnapshot(ctx context.Context, req *model.ImportTopologySnapshotRequest, r io.Reader) (*model.ImportTopologySnapshotResponse, error)
 }
 
+const snapshotChunkSize = 1 << 20
+
 type topologyManagerWrite struct {
 	client topov30.TopologyManagerWriteServiceClient
 }
@@ -34,7 +40,10 @@ func NewTopologyManagerWriteClient(conn *grpc.ClientConn) *topologyManagerWrite
 }
 
 func (c *topologyManagerWrite) Authorize(ctx context.Context, req *model.AuthorizeRequest) (*model.AuthorizeResponse, error) {
-	protoReq := authorizeRequestToProto(req)
+	protoReq, err := authorizeRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.Authorize(ctx, protoReq)
 	if err != nil {
@@ -45,7 +54,10 @@ func (c *topologyManagerWrite) Authorize(ctx context.Context, req *model.Authori
 }
 
 func (c *topologyManagerWrite) AddTransactions(ctx context.Context, req *model.AddTransactionsRequest) (*model.AddTransactionsResponse, error) {
-	protoReq := addTransactionsRequestToProto(req)
+	protoReq, err := addTransactionsRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.AddTransactions(ctx, protoReq)
 	if err != nil {
@@ -55,16 +67,21 @@ func (c *topologyManagerWrite) AddTransactions(ctx context.Context, req *model.A
 	return addTransactionsResponseFromProto(resp), nil
 }
 
-func authorizeRequestToProto(req *model.AuthorizeRequest) *topov30.AuthorizeRequest {
+func authorizeRequestToProto(req *model.AuthorizeRequest) (*topov30.AuthorizeRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
+	}
+
+	store, err := storeIDToProto(req.Store)
+	if err != nil {
+		return nil, err
 	}
 
 	protoReq := &topov30.AuthorizeRequest{
 		MustFullyAuthorize: req.MustFullyAuthorize,
 		ForceChanges:       forceFlagsToProto(req.ForceChanges),
 		SignedBy:           req.SignedBy,
-		Store:              storeIDToProto(req.Store),
+		Store:              store,
 	}
 
 	if req.WaitToBecomeEffective != nil {
@@ -72,10 +89,14 @@ func authorizeRequestToProto(req *model.AuthorizeRequest) *topov30.AuthorizeRequ
 	}
 
 	if req.Proposal != nil {
+		mapping, err := topologyMappingToProto(req.Proposal.Mapping)
+		if err != nil {
+			return nil, err
+		}
 		protoReq.Type = &topov30.AuthorizeRequest_Proposal_{
 			Proposal: &topov30.AuthorizeRequest_Proposal{
 				Change:  operationToProto(req.Proposal.Operation),
-				Mapping: topologyMappingToProto(req.Proposal.Mapping),
+				Mapping: mapping,
 				Serial:  req.Proposal.Serial,
 			},
 		}
@@ -85,7 +106,7 @@ func authorizeRequestToProto(req *model.AuthorizeRequest) *topov30.AuthorizeRequ
 		}
 	}
 
-	return protoReq
+	return protoReq, nil
 }
 
 func authorizeResponseFromProto(pb *topov30.AuthorizeResponse) *model.AuthorizeResponse {
@@ -98,22 +119,27 @@ func authorizeResponseFromProto(pb *topov30.AuthorizeResponse) *model.AuthorizeR
 	}
 }
 
-func addTransactionsRequestToProto(req *model.AddTransactionsRequest) *topov30.AddTransactionsRequest {
+func addTransactionsRequestToProto(req *model.AddTransactionsRequest) (*topov30.AddTransactionsRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
+	}
+
+	store, err := storeIDToProto(req.Store)
+	if err != nil {
+		return nil, err
 	}
 
 	protoReq := &topov30.AddTransactionsRequest{
 		Transactions: signedTopologyTransactionsToProto(req.Transactions),
 		ForceChanges: forceFlagsToProto(req.ForceChanges),
-		Store:        storeIDToProto(req.Store),
+		Store:        store,
 	}
 
 	if req.WaitToBecomeEffective != nil {
 		protoReq.WaitToBecomeEffective = durationpb.New(*req.WaitToBecomeEffective)
 	}
 
-	return protoReq
+	return protoReq, nil
 }
 
 func addTransactionsResponseFromProto(pb *topov30.AddTransactionsResponse) *model.AddTransactionsResponse {
@@ -143,33 +169,36 @@ func forceFlagToProto(flag model.ForceFlag) topov30.ForceFlag {
 	}
 }
 
-func storeIDToProto(store *model.StoreID) *topov30.StoreId {
+func storeIDToProto(store *model.StoreID) (*topov30.StoreId, error) {
 	if store == nil {
-		return nil
+		return nil, nil
+	}
+	if err := store.Validate(); err != nil {
+		return nil, err
 	}
 
 	pbStore := &topov30.StoreId{}
-	if store.Value == "authorized" {
-		pbStore.Store = &topov30.StoreId_Authorized_{
-			Authorized: &topov30.StoreId_Authorized{},
-		}
-	} else if strings.HasPrefix(store.Value, "synchronizer:") {
+	if id := store.SynchronizerID(); id != "" {
 		pbStore.Store = &topov30.StoreId_Synchronizer{
 			Synchronizer: &topov30.Synchronizer{
 				Kind: &topov30.Synchronizer_Id{
-					Id: store.Value[13:],
+					Id: string(id),
 				},
 			},
 		}
-	} else if strings.HasPrefix(store.Value, "temporary:") {
+	} else if name := store.TemporaryName(); name != "" {
 		pbStore.Store = &topov30.StoreId_Temporary_{
 			Temporary: &topov30.StoreId_Temporary{
-				Name: store.Value[10:],
+				Name: name,
 			},
 		}
+	} else {
+		pbStore.Store = &topov30.StoreId_Authorized_{
+			Authorized: &topov30.StoreId_Authorized{},
+		}
 	}
 
-	return pbStore
+	return pbStore, nil
 }
 
 func signedTopologyTransactionToProto(tx *model.SignedTopologyTransaction) *protov30.SignedTopologyTransaction {
@@ -180,9 +209,10 @@ func signedTopologyTransactionToProto(tx *model.SignedTopologyTransaction) *prot
 	signatures := make([]*cryptov30.Signature, len(tx.Signatures))
 	for i, sig := range tx.Signatures {
 		signatures[i] = &cryptov30.Signature{
-			SignedBy:  sig.SignedBy,
-			Signature: sig.Signature,
-			Format:    cryptov30.SignatureFormat(sig.SignatureFormat),
+			SignedBy:             sig.SignedBy,
+			Signature:            sig.Signature,
+			Format:               cryptov30.SignatureFormat(sig.SignatureFormat),
+			SigningAlgorithmSpec: cryptov30.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 		}
 	}
 
@@ -191,9 +221,10 @@ func signedTopologyTransactionToProto(tx *model.SignedTopologyTransaction) *prot
 		sigs := make([]*cryptov30.Signature, len(mts.Signatures))
 		for j, sig := range mts.Signatures {
 			sigs[j] = &cryptov30.Signature{
-				SignedBy:  sig.SignedBy,
-				Signature: sig.Signature,
-				Format:    cryptov30.SignatureFormat(sig.SignatureFormat),
+				SignedBy:             sig.SignedBy,
+				Signature:            sig.Signature,
+				Format:               cryptov30.SignatureFormat(sig.SignatureFormat),
+				SigningAlgorithmSpec: cryptov30.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 			}
 		}
 		multiTxSigs[i] = &protov30.MultiTransactionSignatures{
@@ -226,9 +257,10 @@ func signedTopologyTransactionFromProto(pb *protov30.SignedTopologyTransaction)
 	signatures := make([]model.TopologyTransactionSignature, len(pb.Signatures))
 	for i, sig := range pb.Signatures {
 		signatures[i] = model.TopologyTransactionSignature{
-			SignedBy:        sig.SignedBy,
-			Signature:       sig.Signature,
-			SignatureFormat: int32(sig.Format),
+			SignedBy:             sig.SignedBy,
+			Signature:            sig.Signature,
+			SignatureFormat:      int32(sig.Format),
+			SigningAlgorithmSpec: model.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 		}
 	}
 
@@ -237,9 +269,10 @@ func signedTopologyTransactionFromProto(pb *protov30.SignedTopologyTransaction)
 		sigs := make([]model.TopologyTransactionSignature, len(mts.Signatures))
 		for j, sig := range mts.Signatures {
 			sigs[j] = model.TopologyTransactionSignature{
-				SignedBy:        sig.SignedBy,
-				Signature:       sig.Signature,
-				SignatureFormat: int32(sig.Format),
+				SignedBy:             sig.SignedBy,
+				Signature:            sig.Signature,
+				SignatureFormat:      int32(sig.Format),
+				SigningAlgorithmSpec: model.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 			}
 		}
 		multiTxSigs[i] = &model.MultiTransactionSignatures{
@@ -264,54 +297,6 @@ func signedTopologyTransactionsFromProto(pbs []*protov30.SignedTopologyTransacti
 	return result
 }
 
-func topologyMappingToProto(mapping model.TopologyMapping) *protov30.TopologyMapping {
-	if mapping == nil {
-		return nil
-	}
-
-	pbMapping := &protov30.TopologyMapping{}
-
-	switch m := mapping.(type) {
-	case *model.NamespaceDelegationMapping:
-		pbMapping.Mapping = &protov30.TopologyMapping_NamespaceDelegation{
-			NamespaceDelegation: &protov30.NamespaceDelegation{
-				Namespace:        m.Namespace,
-				TargetKey:        signingPublicKeyToProto(&m.TargetKey),
-				IsRootDelegation: m.IsRootDelegation,
-			},
-		}
-	case *model.PartyToKeyMapping:
-		keys := make([]*cryptov30.SigningPublicKey, len(m.SigningKeys))
-		for i, k := range m.SigningKeys {
-			keys[i] = signingPublicKeyToProto(&k)
-		}
-		pbMapping.Mapping = &protov30.TopologyMapping_PartyToKeyMapping{
-			PartyToKeyMapping: &protov30.PartyToKeyMapping{
-				Party:       m.Party,
-				Threshold:   m.Threshold,
-				SigningKeys: keys,
-			},
-		}
-	case *model.PartyToParticipantMapping:
-		participants := make([]*protov30.PartyToParticipant_HostingParticipant, len(m.Participants))
-		for i, p := range m.Participants {
-			participants[i] = &protov30.PartyToParticipant_HostingParticipant{
-				ParticipantUid: p.ParticipantUID,
-				Permission:     participantPermissionToProto(p.Permission),
-			}
-		}
-		pbMapping.Mapping = &protov30.TopologyMapping_PartyToParticipant{
-			PartyToParticipant: &protov30.PartyToParticipant{
-				Party:        m.Party,
-				Threshold:    m.Threshold,
-				Participants: participants,
-			},
-		}
-	}
-
-	return pbMapping
-}
-
 func signingPublicKeyToProto(key *model.PublicKey) *cryptov30.SigningPublicKey {
 	if key == nil {
 		return nil
@@ -341,7 +326,10 @@ func participantPermissionToProto(permission model.ParticipantPermission) protov
 }
 
 func (c *topologyManagerWrite) SignTransactions(ctx context.Context, req *model.SignTransactionsRequest) (*model.SignTransactionsResponse, error) {
-	protoReq := signTransactionsRequestToProto(req)
+	protoReq, err := signTransactionsRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.SignTransactions(ctx, protoReq)
 	if err != nil {
@@ -352,7 +340,10 @@ func (c *topologyManagerWrite) SignTransactions(ctx context.Context, req *model.
 }
 
 func (c *topologyManagerWrite) GenerateTransactions(ctx context.Context, req *model.GenerateTransactionsRequest) (*model.GenerateTransactionsResponse, error) {
-	protoReq := generateTransactionsRequestToProto(req)
+	protoReq, err := generateTransactionsRequestToProto(req)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.client.GenerateTransactions(ctx, protoReq)
 	if err != nil {
@@ -393,17 +384,93 @@ func (c *topologyManagerWrite) DropTemporaryTopologyStore(ctx context.Context, r
 	return &model.DropTemporaryTopologyStoreResponse{}, nil
 }
 
-func signTransactionsRequestToProto(req *model.SignTransactionsRequest) *topov30.SignTransactionsRequest {
+// ImportTopologySnapshot uploads a snapshot produced by ExportTopologySnapshot in chunks read from r.
+// When r is nil, req.TopologySnapshot is uploaded instead.
+func (c *topologyManagerWrite) ImportTopologySnapshot(ctx context.Context, req *model.ImportTopologySnapshotRequest, r io.Reader) (*model.ImportTopologySnapshotResponse, error) {
 	if req == nil {
-		return nil
+		req = &model.ImportTopologySnapshotRequest{}
+	}
+	if r == nil {
+		r = bytes.NewReader(req.TopologySnapshot)
+	}
+
+	store, err := storeIDToProto(req.Store)
+	if err != nil {
+		return nil, err
+	}
+
+	// read ahead so an empty snapshot is rejected before a stream is opened
+	chunk, last, err := readSnapshotChunk(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunk) == 0 {
+		return nil, errors.New("topology snapshot is empty")
+	}
+
+	stream, err := c.client.ImportTopologySnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var wait *durationpb.Duration
+	if req.WaitToBecomeEffective != nil {
+		wait = durationpb.New(*req.WaitToBecomeEffective)
+	}
+
+	for len(chunk) > 0 {
+		if err := stream.Send(&topov30.ImportTopologySnapshotRequest{
+			TopologySnapshot:      chunk,
+			Store:                 store,
+			WaitToBecomeEffective: wait,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to send topology snapshot chunk: %w", err)
+		}
+		if last {
+			break
+		}
+		if chunk, last, err = readSnapshotChunk(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return nil, err
+	}
+
+	return &model.ImportTopologySnapshotResponse{}, nil
+}
+
+// readSnapshotChunk reads the next chunk of a snapshot into a new slice, as the gRPC stream may
+// still hold the previous one. last reports that r is exhausted.
+func readSnapshotChunk(r io.Reader) (chunk []byte, last bool, err error) {
+	buf := make([]byte, snapshotChunkSize)
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return buf[:n], true, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read topology snapshot: %w", err)
+	}
+	return buf, false, nil
+}
+
+func signTransactionsRequestToProto(req *model.SignTransactionsRequest) (*topov30.SignTransactionsRequest, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	store, err := storeIDToProto(req.Store)
+	if err != nil {
+		return nil, err
 	}
 
 	return &topov30.SignTransactionsRequest{
 		Transactions: signedTopologyTransactionsToProto(req.Transactions),
 		SignedBy:     req.SignedBy,
-		Store:        storeIDToProto(req.Store),
+		Store:        store,
 		ForceFlags:   forceFlagsToProto(req.ForceFlags),
-	}
+	}, nil
 }
 
 func signTransactionsResponseFromProto(pb *topov30.SignTransactionsResponse) *model.SignTransactionsResponse {
@@ -416,24 +483,32 @@ func signTransactionsResponseFromProto(pb *topov30.SignTransactionsResponse) *mo
 	}
 }
 
-func generateTransactionsRequestToProto(req *model.GenerateTransactionsRequest) *topov30.GenerateTransactionsRequest {
+func generateTransactionsRequestToProto(req *model.GenerateTransactionsRequest) (*topov30.GenerateTransactionsRequest, error) {
 	if req == nil {
-		return nil
+		return nil, nil
 	}
 
 	proposals := make([]*topov30.GenerateTransactionsRequest_Proposal, len(req.Proposals))
 	for i, p := range req.Proposals {
+		mapping, err := topologyMappingToProto(p.Mapping)
+		if err != nil {
+			return nil, fmt.Errorf("proposal %d: %w", i, err)
+		}
+		store, err := storeIDToProto(p.Store)
+		if err != nil {
+			return nil, fmt.Errorf("proposal %d: %w", i, err)
+		}
 		proposals[i] = &topov30.GenerateTransactionsRequest_Proposal{
 			Operation: operationToProto(p.Operation),
 			Serial:    p.Serial,
-			Mapping:   topologyMappingToProto(p.Mapping),
-			Store:     storeIDToProto(p.Store),
+			Mapping:   mapping,
+			Store:     store,
 		}
 	}
 
 	return &topov30.GenerateTransactionsRequest{
 		Proposals: proposals,
-	}
+	}, nil
 }
 
 func generateTransactionsResponseFromProto(pb *topov30.GenerateTransactionsResponse) *model.GenerateTransactionsResponse {
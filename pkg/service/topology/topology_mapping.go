@@ -0,0 +1,426 @@
+package topology
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	cryptov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/crypto/v30"
+	protov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/protocol/v30"
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+func topologyMappingToProto(mapping model.TopologyMapping) (*protov30.TopologyMapping, error) {
+	if mapping == nil {
+		return nil, fmt.Errorf("topology mapping is required")
+	}
+	if err := validateTopologyMappingIDs(mapping); err != nil {
+		return nil, err
+	}
+
+	pbMapping := &protov30.TopologyMapping{}
+
+	switch m := mapping.(type) {
+	case *model.NamespaceDelegationMapping:
+		delegation, err := namespaceDelegationToProto(m)
+		if err != nil {
+			return nil, err
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_NamespaceDelegation{
+			NamespaceDelegation: delegation,
+		}
+	case *model.DecentralizedNamespaceDefinition:
+		pbMapping.Mapping = &protov30.TopologyMapping_DecentralizedNamespaceDefinition{
+			DecentralizedNamespaceDefinition: &protov30.DecentralizedNamespaceDefinition{
+				DecentralizedNamespace: string(m.Namespace),
+				Threshold:              m.Threshold,
+				Owners:                 m.Owners,
+			},
+		}
+	case *model.OwnerToKeyMapping:
+		keys := make([]*cryptov30.PublicKey, len(m.PublicKeys))
+		for i := range m.PublicKeys {
+			keys[i] = publicKeyToProto(&m.PublicKeys[i])
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_OwnerToKeyMapping{
+			OwnerToKeyMapping: &protov30.OwnerToKeyMapping{
+				Member:     m.Member,
+				PublicKeys: keys,
+			},
+		}
+	case *model.PartyToKeyMapping:
+		keys := make([]*cryptov30.SigningPublicKey, len(m.SigningKeys))
+		for i := range m.SigningKeys {
+			keys[i] = signingPublicKeyToProto(&m.SigningKeys[i])
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_PartyToKeyMapping{
+			PartyToKeyMapping: &protov30.PartyToKeyMapping{
+				Party:       string(m.Party),
+				Threshold:   m.Threshold,
+				SigningKeys: keys,
+			},
+		}
+	case *model.SynchronizerTrustCertificate:
+		flags := make([]protov30.Enums_ParticipantFeatureFlag, len(m.FeatureFlags))
+		for i, f := range m.FeatureFlags {
+			flags[i] = protov30.Enums_ParticipantFeatureFlag(f)
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_SynchronizerTrustCertificate{
+			SynchronizerTrustCertificate: &protov30.SynchronizerTrustCertificate{
+				ParticipantUid: string(m.ParticipantUID),
+				SynchronizerId: string(m.SynchronizerID),
+				FeatureFlags:   flags,
+			},
+		}
+	case *model.ParticipantSynchronizerPermission:
+		pbMapping.Mapping = &protov30.TopologyMapping_ParticipantPermission{
+			ParticipantPermission: &protov30.ParticipantSynchronizerPermission{
+				SynchronizerId: string(m.SynchronizerID),
+				ParticipantUid: string(m.ParticipantUID),
+				Permission:     participantPermissionToProto(m.Permission),
+				Limits:         participantSynchronizerLimitsToProto(m.Limits),
+				LoginAfter:     m.LoginAfter,
+			},
+		}
+	case *model.PartyHostingLimits:
+		pbMapping.Mapping = &protov30.TopologyMapping_PartyHostingLimits{
+			PartyHostingLimits: &protov30.PartyHostingLimits{
+				SynchronizerId: string(m.SynchronizerID),
+				Party:          string(m.Party),
+			},
+		}
+	case *model.VettedPackages:
+		packages := make([]*protov30.VettedPackages_VettedPackage, len(m.Packages))
+		for i, p := range m.Packages {
+			packages[i] = &protov30.VettedPackages_VettedPackage{
+				PackageId:           p.PackageID,
+				ValidFromInclusive:  timeToTimestamp(p.ValidFrom),
+				ValidUntilExclusive: timeToTimestamp(p.ValidUntil),
+			}
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_VettedPackages{
+			VettedPackages: &protov30.VettedPackages{
+				ParticipantUid: string(m.ParticipantUID),
+				Packages:       packages,
+			},
+		}
+	case *model.PartyToParticipantMapping:
+		pbMapping.Mapping = &protov30.TopologyMapping_PartyToParticipant{
+			PartyToParticipant: partyToParticipantToProto(m),
+		}
+	case *model.SynchronizerParametersState:
+		pbMapping.Mapping = &protov30.TopologyMapping_SynchronizerParametersState{
+			SynchronizerParametersState: &protov30.SynchronizerParametersState{
+				SynchronizerId:         string(m.SynchronizerID),
+				SynchronizerParameters: dynamicSynchronizerParametersToProto(m.Parameters),
+			},
+		}
+	case *model.MediatorSynchronizerState:
+		pbMapping.Mapping = &protov30.TopologyMapping_MediatorSynchronizerState{
+			MediatorSynchronizerState: &protov30.MediatorSynchronizerState{
+				SynchronizerId: string(m.SynchronizerID),
+				Group:          m.Group,
+				Threshold:      m.Threshold,
+				Active:         m.Active,
+				Observers:      m.Observers,
+			},
+		}
+	case *model.SequencerSynchronizerState:
+		pbMapping.Mapping = &protov30.TopologyMapping_SequencerSynchronizerState{
+			SequencerSynchronizerState: &protov30.SequencerSynchronizerState{
+				SynchronizerId: string(m.SynchronizerID),
+				Threshold:      m.Threshold,
+				Active:         m.Active,
+				Observers:      m.Observers,
+			},
+		}
+	case *model.DynamicSequencingParametersState:
+		pbMapping.Mapping = &protov30.TopologyMapping_SequencingDynamicParametersState{
+			SequencingDynamicParametersState: &protov30.DynamicSequencingParametersState{
+				SynchronizerId:       string(m.SynchronizerID),
+				SequencingParameters: &protov30.DynamicSequencingParameters{Payload: m.Payload},
+			},
+		}
+	case *model.SynchronizerUpgradeAnnouncement:
+		pbMapping.Mapping = &protov30.TopologyMapping_SynchronizerUpgradeAnnouncement{
+			SynchronizerUpgradeAnnouncement: &protov30.SynchronizerUpgradeAnnouncement{
+				SuccessorPhysicalSynchronizerId: m.SuccessorPhysicalSynchronizerID,
+				UpgradeTime:                     timeToTimestamp(m.UpgradeTime),
+			},
+		}
+	case *model.SequencerConnectionSuccessor:
+		pbMapping.Mapping = &protov30.TopologyMapping_SequencerConnectionSuccessor{
+			SequencerConnectionSuccessor: &protov30.SequencerConnectionSuccessor{
+				SequencerId:    m.SequencerID,
+				SynchronizerId: string(m.SynchronizerID),
+				Connection: &protov30.SequencerConnectionSuccessor_SequencerConnection{
+					ConnectionType: &protov30.SequencerConnectionSuccessor_SequencerConnection_Grpc_{
+						Grpc: &protov30.SequencerConnectionSuccessor_SequencerConnection_Grpc{
+							Endpoints:               m.Endpoints,
+							CustomTrustCertificates: m.CustomTrustCertificates,
+						},
+					},
+				},
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported topology mapping %T", mapping)
+	}
+
+	return pbMapping, nil
+}
+
+func topologyMappingFromProto(pb *protov30.TopologyMapping) (model.TopologyMapping, error) {
+	if pb == nil {
+		return nil, fmt.Errorf("topology mapping is required")
+	}
+
+	switch m := pb.Mapping.(type) {
+	case *protov30.TopologyMapping_NamespaceDelegation:
+		return namespaceDelegationFromProto(m.NamespaceDelegation), nil
+	case *protov30.TopologyMapping_DecentralizedNamespaceDefinition:
+		return decentralizedNamespaceDefinitionFromProto(m.DecentralizedNamespaceDefinition), nil
+	case *protov30.TopologyMapping_OwnerToKeyMapping:
+		return ownerToKeyMappingFromProto(m.OwnerToKeyMapping), nil
+	case *protov30.TopologyMapping_PartyToKeyMapping:
+		return partyToKeyMappingFromProto(m.PartyToKeyMapping), nil
+	case *protov30.TopologyMapping_SynchronizerTrustCertificate:
+		return synchronizerTrustCertificateFromProto(m.SynchronizerTrustCertificate), nil
+	case *protov30.TopologyMapping_ParticipantPermission:
+		return participantSynchronizerPermissionFromProto(m.ParticipantPermission), nil
+	case *protov30.TopologyMapping_PartyHostingLimits:
+		return &model.PartyHostingLimits{
+			SynchronizerID: model.SynchronizerID(m.PartyHostingLimits.GetSynchronizerId()),
+			Party:          model.PartyID(m.PartyHostingLimits.GetParty()),
+		}, nil
+	case *protov30.TopologyMapping_VettedPackages:
+		return vettedPackagesFromProto(m.VettedPackages), nil
+	case *protov30.TopologyMapping_PartyToParticipant:
+		return partyToParticipantMappingFromProto(m.PartyToParticipant), nil
+	case *protov30.TopologyMapping_SynchronizerParametersState:
+		return &model.SynchronizerParametersState{
+			SynchronizerID: model.SynchronizerID(m.SynchronizerParametersState.GetSynchronizerId()),
+			Parameters:     dynamicSynchronizerParametersFromProto(m.SynchronizerParametersState.GetSynchronizerParameters()),
+		}, nil
+	case *protov30.TopologyMapping_MediatorSynchronizerState:
+		return mediatorSynchronizerStateFromProto(m.MediatorSynchronizerState), nil
+	case *protov30.TopologyMapping_SequencerSynchronizerState:
+		return sequencerSynchronizerStateFromProto(m.SequencerSynchronizerState), nil
+	case *protov30.TopologyMapping_SequencingDynamicParametersState:
+		return &model.DynamicSequencingParametersState{
+			SynchronizerID: model.SynchronizerID(m.SequencingDynamicParametersState.GetSynchronizerId()),
+			Payload:        m.SequencingDynamicParametersState.GetSequencingParameters().GetPayload(),
+		}, nil
+	case *protov30.TopologyMapping_SynchronizerUpgradeAnnouncement:
+		return &model.SynchronizerUpgradeAnnouncement{
+			SuccessorPhysicalSynchronizerID: m.SynchronizerUpgradeAnnouncement.GetSuccessorPhysicalSynchronizerId(),
+			UpgradeTime:                     timestampToTime(m.SynchronizerUpgradeAnnouncement.GetUpgradeTime()),
+		}, nil
+	case *protov30.TopologyMapping_SequencerConnectionSuccessor:
+		grpcConn := m.SequencerConnectionSuccessor.GetConnection().GetGrpc()
+		return &model.SequencerConnectionSuccessor{
+			SequencerID:             m.SequencerConnectionSuccessor.GetSequencerId(),
+			SynchronizerID:          model.SynchronizerID(m.SequencerConnectionSuccessor.GetSynchronizerId()),
+			Endpoints:               grpcConn.GetEndpoints(),
+			CustomTrustCertificates: grpcConn.GetCustomTrustCertificates(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported topology mapping %T", pb.Mapping)
+	}
+}
+
+func namespaceDelegationToProto(m *model.NamespaceDelegationMapping) (*protov30.NamespaceDelegation, error) {
+	pb := &protov30.NamespaceDelegation{
+		Namespace:        string(m.Namespace),
+		TargetKey:        signingPublicKeyToProto(&m.TargetKey),
+		IsRootDelegation: m.IsRootDelegation,
+	}
+
+	if r := m.Restriction; r != nil {
+		set := 0
+		for _, ok := range []bool{r.CanSignAllMappings, r.CanSignAllButNamespaceDelegations, len(r.CanSignSpecificMappings) > 0} {
+			if ok {
+				set++
+			}
+		}
+		// An empty restriction would encode as "can sign no mappings"; leave Restriction nil for the default.
+		if set != 1 {
+			return nil, fmt.Errorf("namespace delegation restriction must set exactly one of CanSignAllMappings, CanSignAllButNamespaceDelegations or CanSignSpecificMappings")
+		}
+
+		switch {
+		case r.CanSignAllMappings:
+			pb.Restriction = &protov30.NamespaceDelegation_CanSignAllMappings_{
+				CanSignAllMappings: &protov30.NamespaceDelegation_CanSignAllMappings{},
+			}
+		case r.CanSignAllButNamespaceDelegations:
+			pb.Restriction = &protov30.NamespaceDelegation_CanSignAllButNamespaceDelegations_{
+				CanSignAllButNamespaceDelegations: &protov30.NamespaceDelegation_CanSignAllButNamespaceDelegations{},
+			}
+		default:
+			codes := make([]protov30.Enums_TopologyMappingCode, len(r.CanSignSpecificMappings))
+			for i, c := range r.CanSignSpecificMappings {
+				codes[i] = protov30.Enums_TopologyMappingCode(c)
+			}
+			pb.Restriction = &protov30.NamespaceDelegation_CanSignSpecificMapings{
+				CanSignSpecificMapings: &protov30.NamespaceDelegation_CanSignSpecificMappings{Mappings: codes},
+			}
+		}
+	}
+
+	return pb, nil
+}
+
+func partyToParticipantToProto(m *model.PartyToParticipantMapping) *protov30.PartyToParticipant {
+	participants := make([]*protov30.PartyToParticipant_HostingParticipant, len(m.Participants))
+	for i, p := range m.Participants {
+		participants[i] = &protov30.PartyToParticipant_HostingParticipant{
+			ParticipantUid: string(p.ParticipantUID),
+			Permission:     participantPermissionToProto(p.Permission),
+		}
+		if p.Onboarding {
+			participants[i].Onboarding = &protov30.PartyToParticipant_HostingParticipant_Onboarding{}
+		}
+	}
+
+	pb := &protov30.PartyToParticipant{
+		Party:        string(m.Party),
+		Threshold:    m.Threshold,
+		Participants: participants,
+	}
+
+	if m.PartySigningKeys != nil {
+		keys := make([]*cryptov30.SigningPublicKey, len(m.PartySigningKeys.Keys))
+		for i := range m.PartySigningKeys.Keys {
+			keys[i] = signingPublicKeyToProto(&m.PartySigningKeys.Keys[i])
+		}
+		pb.PartySigningKeys = &cryptov30.SigningKeysWithThreshold{
+			Keys:      keys,
+			Threshold: m.PartySigningKeys.Threshold,
+		}
+	}
+
+	return pb
+}
+
+func participantSynchronizerLimitsToProto(limits *model.ParticipantSynchronizerLimits) *protov30.ParticipantSynchronizerLimits {
+	if limits == nil {
+		return nil
+	}
+
+	return &protov30.ParticipantSynchronizerLimits{
+		ConfirmationRequestsMaxRate: limits.ConfirmationRequestsMaxRate,
+	}
+}
+
+func dynamicSynchronizerParametersToProto(params *model.DynamicSynchronizerParameters) *protov30.DynamicSynchronizerParameters {
+	if params == nil {
+		return nil
+	}
+
+	pb := &protov30.DynamicSynchronizerParameters{
+		ConfirmationResponseTimeout:         durationpb.New(params.ConfirmationResponseTimeout),
+		MediatorReactionTimeout:             durationpb.New(params.MediatorReactionTimeout),
+		AssignmentExclusivityTimeout:        durationpb.New(params.AssignmentExclusivityTimeout),
+		LedgerTimeRecordTimeTolerance:       durationpb.New(params.LedgerTimeRecordTimeTolerance),
+		ReconciliationInterval:              durationpb.New(params.ReconciliationInterval),
+		MediatorDeduplicationTimeout:        durationpb.New(params.MediatorDeduplicationTimeout),
+		MaxRequestSize:                      params.MaxRequestSize,
+		OnboardingRestriction:               protov30.OnboardingRestriction(params.OnboardingRestriction),
+		ParticipantSynchronizerLimits:       participantSynchronizerLimitsToProto(params.ParticipantSynchronizerLimits),
+		SequencerAggregateSubmissionTimeout: durationpb.New(params.SequencerAggregateSubmissionTimeout),
+		PreparationTimeRecordTimeTolerance:  durationpb.New(params.PreparationTimeRecordTimeTolerance),
+	}
+
+	if tc := params.TrafficControl; tc != nil {
+		pb.TrafficControl = &protov30.TrafficControlParameters{
+			MaxBaseTrafficAmount:                  tc.MaxBaseTrafficAmount,
+			MaxBaseTrafficAccumulationDuration:    durationpb.New(tc.MaxBaseTrafficAccumulationDuration),
+			ReadVsWriteScalingFactor:              tc.ReadVsWriteScalingFactor,
+			SetBalanceRequestSubmissionWindowSize: durationpb.New(tc.SetBalanceRequestSubmissionWindowSize),
+			EnforceRateLimiting:                   tc.EnforceRateLimiting,
+			BaseEventCost:                         tc.BaseEventCost,
+		}
+	}
+
+	if cu := params.AcsCommitmentsCatchup; cu != nil {
+		pb.AcsCommitmentsCatchup = &protov30.AcsCommitmentsCatchUpConfig{
+			CatchupIntervalSkip:         cu.CatchupIntervalSkip,
+			NrIntervalsToTriggerCatchup: cu.NrIntervalsToTriggerCatchup,
+		}
+	}
+
+	return pb
+}
+
+func publicKeyToProto(key *model.PublicKey) *cryptov30.PublicKey {
+	if key == nil {
+		return nil
+	}
+
+	if key.Purpose == model.KeyPurposeEncryption {
+		return &cryptov30.PublicKey{
+			Key: &cryptov30.PublicKey_EncryptionPublicKey{
+				EncryptionPublicKey: &cryptov30.EncryptionPublicKey{
+					Format:    cryptov30.CryptoKeyFormat(key.Format),
+					PublicKey: key.Key,
+					Scheme:    cryptov30.EncryptionKeyScheme(key.Scheme),
+					KeySpec:   cryptov30.EncryptionKeySpec(key.KeySpec),
+				},
+			},
+		}
+	}
+
+	return &cryptov30.PublicKey{
+		Key: &cryptov30.PublicKey_SigningPublicKey{
+			SigningPublicKey: signingPublicKeyToProto(key),
+		},
+	}
+}
+
+func timeToTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// validateTopologyMappingIDs rejects malformed identifiers before a mapping is sent or hashed.
+func validateTopologyMappingIDs(mapping model.TopologyMapping) error {
+	var errs []error
+	switch m := mapping.(type) {
+	case *model.NamespaceDelegationMapping:
+		errs = append(errs, m.Namespace.Validate())
+	case *model.DecentralizedNamespaceDefinition:
+		errs = append(errs, m.Namespace.Validate())
+	case *model.PartyToKeyMapping:
+		errs = append(errs, m.Party.Validate())
+	case *model.PartyToParticipantMapping:
+		errs = append(errs, m.Party.Validate())
+		for _, p := range m.Participants {
+			errs = append(errs, p.ParticipantUID.Validate())
+		}
+	case *model.SynchronizerTrustCertificate:
+		errs = append(errs, m.ParticipantUID.Validate(), m.SynchronizerID.Validate())
+	case *model.ParticipantSynchronizerPermission:
+		errs = append(errs, m.ParticipantUID.Validate(), m.SynchronizerID.Validate())
+	case *model.PartyHostingLimits:
+		errs = append(errs, m.Party.Validate(), m.SynchronizerID.Validate())
+	case *model.VettedPackages:
+		errs = append(errs, m.ParticipantUID.Validate())
+	case *model.SynchronizerParametersState:
+		errs = append(errs, m.SynchronizerID.Validate())
+	case *model.MediatorSynchronizerState:
+		errs = append(errs, m.SynchronizerID.Validate())
+	case *model.SequencerSynchronizerState:
+		errs = append(errs, m.SynchronizerID.Validate())
+	case *model.DynamicSequencingParametersState:
+		errs = append(errs, m.SynchronizerID.Validate())
+	case *model.SequencerConnectionSuccessor:
+		errs = append(errs, m.SynchronizerID.Validate())
+	}
+	return errors.Join(errs...)
+}
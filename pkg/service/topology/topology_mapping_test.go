@@ -0,0 +1,207 @@
+package topology
+
+import (
+	"testing"
+	"time"
+
+	protov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/protocol/v30"
+	topov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/topology/admin/v30"
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopologyMappingRoundTrip(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	loginAfter := int64(42)
+	key := model.PublicKey{
+		Format:  3,
+		Key:     []byte{1, 2, 3},
+		Scheme:  int32(model.SigningKeySchemeED25519),
+		KeySpec: int32(model.SigningKeySpecCurve25519),
+		Usage:   []int32{int32(model.SigningKeyUsageNamespace)},
+	}
+
+	tests := []struct {
+		name    string
+		mapping model.TopologyMapping
+	}{
+		{
+			name: "namespace delegation",
+			mapping: &model.NamespaceDelegationMapping{
+				Namespace: "1220aa",
+				TargetKey: key,
+				Restriction: &model.DelegationRestriction{
+					CanSignSpecificMappings: []model.TopologyMappingCode{model.TopologyMappingCodePartyToParticipant},
+				},
+			},
+		},
+		{
+			name:    "decentralized namespace",
+			mapping: &model.DecentralizedNamespaceDefinition{Namespace: "1220bb", Threshold: 2, Owners: []string{"1220aa", "1220cc"}},
+		},
+		{
+			name: "owner to key",
+			mapping: &model.OwnerToKeyMapping{
+				Member: "PAR::p1::1220aa",
+				PublicKeys: []model.PublicKey{
+					key,
+					{Format: 4, Key: []byte{4, 5}, Scheme: 1, KeySpec: 1, Purpose: model.KeyPurposeEncryption},
+				},
+			},
+		},
+		{
+			name:    "party to key",
+			mapping: &model.PartyToKeyMapping{Party: "alice::1220aa", Threshold: 1, SigningKeys: []model.PublicKey{key}},
+		},
+		{
+			name: "synchronizer trust certificate",
+			mapping: &model.SynchronizerTrustCertificate{
+				ParticipantUID: "p1::1220aa",
+				SynchronizerID: "sync::1220dd",
+				FeatureFlags:   []model.ParticipantFeatureFlag{model.ParticipantFeatureFlagPV33ExternalSigningLocalContractInSubview},
+			},
+		},
+		{
+			name: "participant permission",
+			mapping: &model.ParticipantSynchronizerPermission{
+				SynchronizerID: "sync::1220dd",
+				ParticipantUID: "p1::1220aa",
+				Permission:     model.ParticipantPermissionObservation,
+				Limits:         &model.ParticipantSynchronizerLimits{ConfirmationRequestsMaxRate: 10},
+				LoginAfter:     &loginAfter,
+			},
+		},
+		{
+			name:    "party hosting limits",
+			mapping: &model.PartyHostingLimits{SynchronizerID: "sync::1220dd", Party: "alice::1220aa"},
+		},
+		{
+			name: "vetted packages",
+			mapping: &model.VettedPackages{
+				ParticipantUID: "p1::1220aa",
+				Packages:       []model.VettedPackage{{PackageID: "pkg1", ValidFrom: &now}},
+			},
+		},
+		{
+			name: "party to participant",
+			mapping: &model.PartyToParticipantMapping{
+				Party:     "alice::1220aa",
+				Threshold: 1,
+				Participants: []model.HostingParticipant{
+					{ParticipantUID: "p1::1220aa", Permission: model.ParticipantPermissionConfirmation, Onboarding: true},
+				},
+				PartySigningKeys: &model.SigningKeysWithThreshold{Keys: []model.PublicKey{key}, Threshold: 1},
+			},
+		},
+		{
+			name: "synchronizer parameters",
+			mapping: &model.SynchronizerParametersState{
+				SynchronizerID: "sync::1220dd",
+				Parameters: &model.DynamicSynchronizerParameters{
+					ConfirmationResponseTimeout: 30 * time.Second,
+					MaxRequestSize:              1 << 20,
+					OnboardingRestriction:       model.OnboardingRestrictionRestrictedOpen,
+					AcsCommitmentsCatchup:       &model.AcsCommitmentsCatchUpConfig{CatchupIntervalSkip: 5, NrIntervalsToTriggerCatchup: 2},
+				},
+			},
+		},
+		{
+			name:    "mediator state",
+			mapping: &model.MediatorSynchronizerState{SynchronizerID: "sync::1220dd", Group: 1, Threshold: 1, Active: []string{"MED::m1::1220aa"}},
+		},
+		{
+			name:    "sequencer state",
+			mapping: &model.SequencerSynchronizerState{SynchronizerID: "sync::1220dd", Threshold: 1, Active: []string{"SEQ::s1::1220aa"}},
+		},
+		{
+			name:    "sequencing parameters",
+			mapping: &model.DynamicSequencingParametersState{SynchronizerID: "sync::1220dd", Payload: []byte("payload")},
+		},
+		{
+			name:    "upgrade announcement",
+			mapping: &model.SynchronizerUpgradeAnnouncement{SuccessorPhysicalSynchronizerID: "sync::1220dd::35-1", UpgradeTime: &now},
+		},
+		{
+			name: "sequencer connection successor",
+			mapping: &model.SequencerConnectionSuccessor{
+				SequencerID:    "SEQ::s1::1220aa",
+				SynchronizerID: "sync::1220dd",
+				Endpoints:      []string{"https://seq.example.com:443"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pb, err := topologyMappingToProto(tt.mapping)
+			require.NoError(t, err)
+
+			decoded, err := topologyMappingFromProto(pb)
+			require.NoError(t, err)
+			require.Equal(t, tt.mapping, decoded)
+		})
+	}
+
+	_, err := topologyMappingToProto(nil)
+	require.Error(t, err)
+
+	_, err = topologyMappingFromProto(&protov30.TopologyMapping{})
+	require.ErrorContains(t, err, "unsupported topology mapping")
+
+	for _, r := range []*model.DelegationRestriction{
+		{},
+		{CanSignAllMappings: true, CanSignAllButNamespaceDelegations: true},
+		{CanSignAllMappings: true, CanSignSpecificMappings: []model.TopologyMappingCode{model.TopologyMappingCodePartyToParticipant}},
+	} {
+		_, err = topologyMappingToProto(&model.NamespaceDelegationMapping{Namespace: "1220aa", TargetKey: key, Restriction: r})
+		require.ErrorContains(t, err, "exactly one")
+	}
+}
+
+func TestMalformedIDsRejected(t *testing.T) {
+	_, err := topologyMappingToProto(&model.PartyToParticipantMapping{Party: "alice", Threshold: 1})
+	require.ErrorContains(t, err, "party ID")
+
+	_, err = topologyMappingToProto(&model.SynchronizerTrustCertificate{ParticipantUID: "PAR::p1::1220aa", SynchronizerID: "sync::1220dd"})
+	require.ErrorContains(t, err, "participant")
+
+	_, err = authorizeRequestToProto(&model.AuthorizeRequest{Store: &model.StoreID{Value: "sync::1220dd"}})
+	require.ErrorContains(t, err, "invalid store")
+
+	_, err = listPartyToParticipantRequestToProto(&model.ListPartyToParticipantRequest{
+		BaseQuery: &model.BaseQuery{Store: model.SynchronizerStore("sync")},
+	})
+	require.ErrorContains(t, err, "invalid store")
+
+	store, err := storeIDToProto(model.SynchronizerStore("sync::1220dd"))
+	require.NoError(t, err)
+	require.Equal(t, "sync::1220dd", store.GetSynchronizer().GetId())
+}
+
+func TestBaseQueryTimeQuery(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	query, err := baseQueryToProto(&model.BaseQuery{TimeQuery: &model.TimeQuery{Snapshot: &at}})
+	require.NoError(t, err)
+	require.Equal(t, at, query.GetSnapshot().AsTime())
+
+	query, err = baseQueryToProto(&model.BaseQuery{TimeQuery: &model.TimeQuery{HeadState: true}})
+	require.NoError(t, err)
+	require.NotNil(t, query.GetHeadState())
+
+	_, err = baseQueryToProto(&model.BaseQuery{TimeQuery: &model.TimeQuery{HeadState: true, Snapshot: &at}})
+	require.ErrorContains(t, err, "only one of")
+	_, err = baseQueryToProto(&model.BaseQuery{TimeQuery: &model.TimeQuery{Snapshot: &at, Range: &model.TimeRange{}}})
+	require.ErrorContains(t, err, "only one of")
+}
+
+func TestSynchronizerParametersStateResultFromProto(t *testing.T) {
+	store, err := storeIDToProto(model.SynchronizerStore("sync::1220dd"))
+	require.NoError(t, err)
+	result := synchronizerParametersStateResultFromProto(&topov30.ListSynchronizerParametersStateResponse_Result{
+		Context: &topov30.BaseResult{Store: store},
+		Item:    &protov30.DynamicSynchronizerParameters{MaxRequestSize: 1 << 20},
+	})
+	require.Equal(t, model.SynchronizerID("sync::1220dd"), result.Item.SynchronizerID)
+	require.Equal(t, uint32(1<<20), result.Item.Parameters.MaxRequestSize)
+}
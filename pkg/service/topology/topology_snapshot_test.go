@@ -0,0 +1,111 @@
+package topology_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	topov30 "github.com/digital-asset/dazl-client/v8/go/api/com/digitalasset/canton/topology/admin/v30"
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeSnapshotServer struct {
+	topov30.UnimplementedTopologyManagerReadServiceServer
+	topov30.UnimplementedTopologyManagerWriteServiceServer
+	snapshot      []byte
+	exportRequest *topov30.ExportTopologySnapshotRequest
+	imported      bytes.Buffer
+	importChunks  int
+	importStore   *topov30.StoreId
+}
+
+func (s *fakeSnapshotServer) ExportTopologySnapshot(req *topov30.ExportTopologySnapshotRequest, stream grpc.ServerStreamingServer[topov30.ExportTopologySnapshotResponse]) error {
+	s.exportRequest = req
+	for i := 0; i < len(s.snapshot); i += 3 {
+		end := min(i+3, len(s.snapshot))
+		if err := stream.Send(&topov30.ExportTopologySnapshotResponse{Chunk: s.snapshot[i:end]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeSnapshotServer) ImportTopologySnapshot(stream grpc.ClientStreamingServer[topov30.ImportTopologySnapshotRequest, topov30.ImportTopologySnapshotResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&topov30.ImportTopologySnapshotResponse{})
+		}
+		if err != nil {
+			return err
+		}
+		s.importChunks++
+		s.importStore = req.Store
+		s.imported.Write(req.TopologySnapshot)
+	}
+}
+
+func TestTopologySnapshotExportImport(t *testing.T) {
+	server := &fakeSnapshotServer{snapshot: []byte("serialized-topology-snapshot")}
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	topov30.RegisterTopologyManagerReadServiceServer(grpcServer, server)
+	topov30.RegisterTopologyManagerWriteServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	n, err := topology.NewTopologyManagerReadClient(conn).ExportTopologySnapshot(context.Background(), &model.ExportTopologySnapshotRequest{
+		BaseQuery: &model.BaseQuery{
+			Store:     &model.StoreID{Value: "synchronizer:sync::1220dd"},
+			TimeQuery: &model.TimeQuery{Snapshot: &at},
+		},
+		ExcludeMappings: []string{"VettedPackages"},
+	}, &out)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(server.snapshot)), n)
+	require.Equal(t, server.snapshot, out.Bytes())
+	require.Equal(t, "sync::1220dd", server.exportRequest.BaseQuery.Store.GetSynchronizer().GetId())
+	require.Equal(t, at, server.exportRequest.BaseQuery.GetSnapshot().AsTime())
+
+	_, err = topology.NewTopologyManagerWriteClient(conn).ImportTopologySnapshot(context.Background(), &model.ImportTopologySnapshotRequest{
+		Store: &model.StoreID{Value: "temporary:restore"},
+	}, &out)
+	require.NoError(t, err)
+	require.Equal(t, server.snapshot, server.imported.Bytes())
+	require.Equal(t, 1, server.importChunks)
+	require.Equal(t, "restore", server.importStore.GetTemporary().GetName())
+
+	large := bytes.Repeat([]byte("0123456789abcdef"), 1<<17)
+	server.imported.Reset()
+	server.importChunks = 0
+	_, err = topology.NewTopologyManagerWriteClient(conn).ImportTopologySnapshot(context.Background(), &model.ImportTopologySnapshotRequest{
+		TopologySnapshot: large,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, large, server.imported.Bytes())
+	require.Equal(t, 2, server.importChunks)
+
+	server.importChunks = 0
+	_, err = topology.NewTopologyManagerWriteClient(conn).ImportTopologySnapshot(context.Background(), &model.ImportTopologySnapshotRequest{}, bytes.NewReader(nil))
+	require.ErrorContains(t, err, "topology snapshot is empty")
+	require.Equal(t, 0, server.importChunks)
+}
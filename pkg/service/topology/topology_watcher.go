@@ -0,0 +1,369 @@
+package topology
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+type TopologyChangeType int
+
+const (
+	TopologyChangeAdded TopologyChangeType = iota
+	TopologyChangeRemoved
+	// TopologyChangeExpired reports a transaction seen earlier that is no longer valid. Its
+	// Context is the one the transaction was first reported with, unless the range query
+	// returned it again with its ValidUntil.
+	TopologyChangeExpired
+)
+
+func (t TopologyChangeType) String() string {
+	switch t {
+	case TopologyChangeAdded:
+		return "added"
+	case TopologyChangeRemoved:
+		return "removed"
+	case TopologyChangeExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// TopologyChange is a topology transaction that appeared in the watched store. Context
+// carries its ValidFrom and ValidUntil.
+type TopologyChange struct {
+	Type    TopologyChangeType
+	Code    model.TopologyMappingCode
+	Mapping model.TopologyMapping
+	Context *model.BaseResult
+}
+
+type TopologyWatchConfig struct {
+	Store *model.StoreID
+	// Mappings selects what to watch. Supported are PartyToParticipant (the default),
+	// PartyToKeyMapping and NamespaceDelegation.
+	Mappings []model.TopologyMappingCode
+	// Parties limits the watch to these parties; namespace delegations are matched on the party's namespace.
+	Parties []model.PartyID
+	// Since is the start of the first queried time range. Nil reports the full history on the first poll.
+	Since    *time.Time
+	Interval time.Duration
+}
+
+// TopologyWatcher polls TopologyManagerRead with time range queries and reports new
+// topology transactions for the configured mappings and parties. Range queries only return
+// transactions that became valid since the watermark, so the watcher also compares the
+// transactions it reported as valid with the head state to find the ones that expired.
+type TopologyWatcher struct {
+	read      TopologyManagerRead
+	config    TopologyWatchConfig
+	watermark *time.Time
+	seen      map[string]seenTransaction
+	// valid holds the reported transactions without a ValidUntil, by transaction hash.
+	valid map[string]*TopologyChange
+}
+
+// seenTransaction records a reported transaction so it is reported again only when it expires.
+type seenTransaction struct {
+	validFrom time.Time
+	expired   bool
+}
+
+func NewTopologyWatcher(read TopologyManagerRead, config TopologyWatchConfig) *TopologyWatcher {
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+	if len(config.Mappings) == 0 {
+		config.Mappings = []model.TopologyMappingCode{model.TopologyMappingCodePartyToParticipant}
+	}
+
+	return &TopologyWatcher{
+		read:      read,
+		config:    config,
+		watermark: config.Since,
+		seen:      make(map[string]seenTransaction),
+		valid:     make(map[string]*TopologyChange),
+	}
+}
+
+// Watch polls until ctx is done or a poll fails.
+func (w *TopologyWatcher) Watch(ctx context.Context) (<-chan *TopologyChange, <-chan error) {
+	responseCh := make(chan *TopologyChange)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(responseCh)
+		defer close(errCh)
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			changes, err := w.Poll(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			for _, change := range changes {
+				select {
+				case responseCh <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return responseCh, errCh
+}
+
+// Poll queries the time range since the previous poll and returns unseen changes, and
+// reported transactions that have since expired, ordered by ValidFrom.
+func (w *TopologyWatcher) Poll(ctx context.Context) ([]*TopologyChange, error) {
+	for _, party := range w.config.Parties {
+		if err := party.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	query := &model.BaseQuery{
+		Store:     w.config.Store,
+		TimeQuery: &model.TimeQuery{Range: &model.TimeRange{From: w.watermark}},
+	}
+
+	filters := w.config.Parties
+	if len(filters) == 0 {
+		filters = []model.PartyID{""}
+	}
+
+	var changes []*TopologyChange
+	for _, code := range w.config.Mappings {
+		for _, party := range filters {
+			found, err := w.list(ctx, code, query, party)
+			if err != nil {
+				return nil, err
+			}
+			for _, change := range found {
+				if change.Context == nil {
+					continue
+				}
+				key := hex.EncodeToString(change.Context.TransactionHash)
+				expired := change.Context.ValidUntil != nil
+				prev, ok := w.seen[key]
+				if ok && (prev.expired || !expired) {
+					continue
+				}
+				if ok {
+					change.Type = TopologyChangeExpired
+				}
+				w.seen[key] = seenTransaction{validFrom: validFromOf(change), expired: expired}
+				if expired {
+					delete(w.valid, key)
+				} else {
+					w.valid[key] = change
+				}
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	expired, err := w.expired(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, expired...)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return validFromOf(changes[i]).Before(validFromOf(changes[j]))
+	})
+
+	if len(changes) > 0 {
+		latest := validFromOf(changes[len(changes)-1])
+		if w.watermark == nil || latest.After(*w.watermark) {
+			w.watermark = &latest
+		}
+	}
+	for key, tx := range w.seen {
+		if tx.validFrom.Before(*w.watermarkOrZero()) {
+			delete(w.seen, key)
+		}
+	}
+
+	return changes, nil
+}
+
+// expired lists the head state and reports the valid transactions that are no longer in it.
+func (w *TopologyWatcher) expired(ctx context.Context, filters []model.PartyID) ([]*TopologyChange, error) {
+	if len(w.valid) == 0 {
+		return nil, nil
+	}
+
+	query := &model.BaseQuery{
+		Store:     w.config.Store,
+		TimeQuery: &model.TimeQuery{HeadState: true},
+	}
+
+	current := make(map[string]bool)
+	for _, code := range w.config.Mappings {
+		for _, party := range filters {
+			found, err := w.list(ctx, code, query, party)
+			if err != nil {
+				return nil, err
+			}
+			for _, change := range found {
+				if change.Context != nil {
+					current[hex.EncodeToString(change.Context.TransactionHash)] = true
+				}
+			}
+		}
+	}
+
+	var changes []*TopologyChange
+	for _, key := range slices.Sorted(maps.Keys(w.valid)) {
+		if current[key] {
+			continue
+		}
+		change := *w.valid[key]
+		change.Type = TopologyChangeExpired
+		delete(w.valid, key)
+		if tx, ok := w.seen[key]; ok {
+			tx.expired = true
+			w.seen[key] = tx
+		}
+		changes = append(changes, &change)
+	}
+	return changes, nil
+}
+
+// WaitFor polls until a change matching match is seen, then waits until it is effective.
+func (w *TopologyWatcher) WaitFor(ctx context.Context, match func(*TopologyChange) bool) (*TopologyChange, error) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		changes, err := w.Poll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			if match(change) {
+				return change, WaitUntilEffective(ctx, change)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// WaitUntilEffective blocks until the change's validity period has started.
+func WaitUntilEffective(ctx context.Context, change *TopologyChange) error {
+	if change == nil || change.Context == nil || change.Context.ValidFrom == nil {
+		return nil
+	}
+
+	delay := time.Until(*change.Context.ValidFrom)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *TopologyWatcher) list(ctx context.Context, code model.TopologyMappingCode, query *model.BaseQuery, party model.PartyID) ([]*TopologyChange, error) {
+	var changes []*TopologyChange
+
+	switch code {
+	case model.TopologyMappingCodePartyToParticipant:
+		resp, err := w.read.ListPartyToParticipant(ctx, &model.ListPartyToParticipantRequest{BaseQuery: query, FilterParty: string(party)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list party to participant mappings: %w", err)
+		}
+		for _, r := range resp.Results {
+			if r != nil && r.Item != nil && matchesParty(r.Item.Party, party) {
+				changes = append(changes, newTopologyChange(code, r.Item, r.Context))
+			}
+		}
+	case model.TopologyMappingCodePartyToKeyMapping:
+		resp, err := w.read.ListPartyToKeyMapping(ctx, &model.ListPartyToKeyMappingRequest{BaseQuery: query, FilterParty: string(party)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list party to key mappings: %w", err)
+		}
+		for _, r := range resp.Results {
+			if r != nil && r.Item != nil && matchesParty(r.Item.Party, party) {
+				changes = append(changes, newTopologyChange(code, r.Item, r.Context))
+			}
+		}
+	case model.TopologyMappingCodeNamespaceDelegation:
+		resp, err := w.read.ListNamespaceDelegation(ctx, &model.ListNamespaceDelegationRequest{BaseQuery: query, FilterNamespace: string(party.Namespace())})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespace delegations: %w", err)
+		}
+		for _, r := range resp.Results {
+			if r != nil && r.Item != nil {
+				changes = append(changes, newTopologyChange(code, r.Item, r.Context))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("watching topology mapping code %d is not supported", code)
+	}
+
+	return changes, nil
+}
+
+// matchesParty guards against the server treating the party filter as a prefix.
+func matchesParty(party, filter model.PartyID) bool {
+	return filter == "" || party == filter
+}
+
+func newTopologyChange(code model.TopologyMappingCode, mapping model.TopologyMapping, context *model.BaseResult) *TopologyChange {
+	change := &TopologyChange{
+		Type:    TopologyChangeAdded,
+		Code:    code,
+		Mapping: mapping,
+		Context: context,
+	}
+	if context != nil && context.Operation == model.OperationRemove {
+		change.Type = TopologyChangeRemoved
+	}
+	return change
+}
+
+func validFromOf(change *TopologyChange) time.Time {
+	if change.Context == nil || change.Context.ValidFrom == nil {
+		return time.Time{}
+	}
+	return *change.Context.ValidFrom
+}
+
+func (w *TopologyWatcher) watermarkOrZero() *time.Time {
+	if w.watermark == nil {
+		return &time.Time{}
+	}
+	return w.watermark
+}
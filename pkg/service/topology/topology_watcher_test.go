@@ -0,0 +1,124 @@
+package topology_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTopologyRead struct {
+	topology.TopologyManagerRead
+	results []*model.PartyToParticipantResult
+	// queries holds the time range queries; head state queries are only counted.
+	queries     []*model.ListPartyToParticipantRequest
+	headQueries int
+}
+
+func (f *fakeTopologyRead) ListPartyToParticipant(_ context.Context, req *model.ListPartyToParticipantRequest) (*model.ListPartyToParticipantResponse, error) {
+	resp := &model.ListPartyToParticipantResponse{}
+	if req.BaseQuery.TimeQuery.HeadState {
+		f.headQueries++
+		for _, r := range f.results {
+			if r.Context.ValidUntil == nil {
+				resp.Results = append(resp.Results, r)
+			}
+		}
+		return resp, nil
+	}
+
+	f.queries = append(f.queries, req)
+	for _, r := range f.results {
+		from := req.BaseQuery.TimeQuery.Range.From
+		if from == nil || !r.Context.ValidFrom.Before(*from) {
+			resp.Results = append(resp.Results, r)
+		}
+	}
+	return resp, nil
+}
+
+func partyHosting(party model.PartyID, op model.Operation, hash byte, validFrom time.Time) *model.PartyToParticipantResult {
+	return &model.PartyToParticipantResult{
+		Context: &model.BaseResult{Operation: op, TransactionHash: []byte{hash}, ValidFrom: &validFrom},
+		Item:    &model.PartyToParticipantMapping{Party: party},
+	}
+}
+
+func TestTopologyWatcher_Poll(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	read := &fakeTopologyRead{results: []*model.PartyToParticipantResult{
+		partyHosting("alice::1220aa", model.OperationAddReplace, 1, t0),
+		partyHosting("alice::1220aabb", model.OperationAddReplace, 2, t0),
+	}}
+
+	watcher := topology.NewTopologyWatcher(read, topology.TopologyWatchConfig{Parties: []model.PartyID{"alice::1220aa"}})
+
+	changes, err := watcher.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, topology.TopologyChangeAdded, changes[0].Type)
+	require.Equal(t, model.PartyID("alice::1220aa"), changes[0].Mapping.(*model.PartyToParticipantMapping).Party)
+	require.Nil(t, read.queries[0].BaseQuery.TimeQuery.Range.From)
+
+	changes, err = watcher.Poll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Equal(t, t0, *read.queries[1].BaseQuery.TimeQuery.Range.From)
+
+	removedAt := t0.Add(time.Minute)
+	read.results = append(read.results, partyHosting("alice::1220aa", model.OperationRemove, 3, removedAt))
+	changes, err = watcher.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, topology.TopologyChangeRemoved, changes[0].Type)
+
+	// The first transaction is older than the watermark, so only the head state shows it expired.
+	read.results[0].Context.ValidUntil = &removedAt
+	changes, err = watcher.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, topology.TopologyChangeExpired, changes[0].Type)
+	require.Equal(t, []byte{1}, changes[0].Context.TransactionHash)
+	require.Equal(t, removedAt, *read.queries[3].BaseQuery.TimeQuery.Range.From)
+
+	validUntil := t0.Add(2 * time.Minute)
+	read.results[2] = partyHosting("alice::1220aa", model.OperationRemove, 3, removedAt)
+	read.results[2].Context.ValidUntil = &validUntil
+	changes, err = watcher.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, topology.TopologyChangeExpired, changes[0].Type)
+	require.Equal(t, validUntil, *changes[0].Context.ValidUntil)
+
+	headQueries := read.headQueries
+	changes, err = watcher.Poll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Equal(t, headQueries, read.headQueries)
+
+	invalid := topology.NewTopologyWatcher(read, topology.TopologyWatchConfig{Parties: []model.PartyID{"alice"}})
+	_, err = invalid.Poll(ctx)
+	require.ErrorContains(t, err, "party ID")
+}
+
+func TestTopologyWatcher_WaitFor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	effective := time.Now().Add(50 * time.Millisecond)
+	read := &fakeTopologyRead{results: []*model.PartyToParticipantResult{
+		partyHosting("bob::1220bb", model.OperationAddReplace, 1, effective),
+	}}
+
+	watcher := topology.NewTopologyWatcher(read, topology.TopologyWatchConfig{Interval: 10 * time.Millisecond})
+	change, err := watcher.WaitFor(ctx, func(c *topology.TopologyChange) bool {
+		return c.Type == topology.TopologyChangeAdded
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.PartyID("bob::1220bb"), change.Mapping.(*model.PartyToParticipantMapping).Party)
+	require.False(t, time.Now().Before(effective))
+}
@@ -2,11 +2,13 @@ package testutil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"strings"
 	"sync"
+	"testing"
 	"time"
 
 	"github.com/noders-team/go-daml/pkg/client"
@@ -18,21 +20,28 @@ import (
 
 const (
 	damlSandboxVersion  = "3.5.0-snapshot.20251106.0"
+	jsonAPIPort         = "7575"
 	containerName       = "go-daml-test-canton"
 	containerLabelKey   = "go-daml-test"
 	containerLabelValue = "canton-sandbox"
 )
 
 var (
-	once       sync.Once
-	setupErr   error
-	cl         *client.DamlBindingClient
-	dockerPool *dockertest.Pool
-	resDaml    *dockertest.Resource
-	grpcAddr   string
-	adminAddr  string
+	once        sync.Once
+	setupErr    error
+	cl          *client.DamlBindingClient
+	dockerPool  *dockertest.Pool
+	resDaml     *dockertest.Resource
+	grpcAddr    string
+	adminAddr   string
+	jsonAPIAddr string
 )
 
+// ErrDockerUnavailable is returned by Setup when the Canton sandbox cannot be started because
+// Docker is not reachable. Packages that also hold pure unit tests can keep running them and
+// skip the sandbox tests with RequireClient.
+var ErrDockerUnavailable = errors.New("docker is unavailable")
+
 func Setup(ctx context.Context) error {
 	once.Do(func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 7*time.Minute)
@@ -40,14 +49,19 @@ func Setup(ctx context.Context) error {
 
 		dockerPool, err := dockertest.NewPool("")
 		if err != nil {
-			log.Fatal().Err(err).Msg("Could not connect to docker")
+			setupErr = fmt.Errorf("%w: could not connect to docker: %v", ErrDockerUnavailable, err)
+			return
 		}
 
 		if err := dockerPool.Client.Ping(); err != nil {
-			log.Fatal().Err(err).Msg("Could not ping docker")
+			setupErr = fmt.Errorf("%w: could not ping docker: %v", ErrDockerUnavailable, err)
+			return
 		}
 
 		resDaml, grpcAddr, adminAddr = initDamlSandbox(ctx, dockerPool)
+		if port := resDaml.GetPort(jsonAPIPort + "/tcp"); port != "" {
+			jsonAPIAddr = fmt.Sprintf("127.0.0.1:%s", port)
+		}
 
 		builder := client.NewDamlClient("", grpcAddr).WithAdminAddress(adminAddr)
 		if strings.HasSuffix(grpcAddr, ":443") {
@@ -246,6 +260,10 @@ func initDamlSandbox(ctx context.Context, dockerPool *dockertest.Pool) (*dockert
         port = 6865
         user-management-service.enabled = true
       }
+      http-ledger-api {
+        address = "0.0.0.0"
+        port = 7575
+      }
     }
   }
 }
@@ -272,7 +290,7 @@ func initDamlSandbox(ctx context.Context, dockerPool *dockertest.Pool) (*dockert
 			"-c", "/canton/canton.conf",
 			"--debug",
 		},
-		ExposedPorts: []string{ledgerAPIPort + "/tcp", adminAPIPort + "/tcp"},
+		ExposedPorts: []string{ledgerAPIPort + "/tcp", adminAPIPort + "/tcp", jsonAPIPort + "/tcp"},
 		Mounts:       []string{fmt.Sprintf("%s:/canton/canton.conf:ro", configPath)},
 		Labels: map[string]string{
 			containerLabelKey: containerLabelValue,
@@ -400,6 +418,15 @@ func GetClient() *client.DamlBindingClient {
 	return cl
 }
 
+// RequireClient returns the sandbox client, skipping the test if the sandbox could not be started.
+func RequireClient(t testing.TB) *client.DamlBindingClient {
+	t.Helper()
+	if cl == nil {
+		t.Skipf("Canton sandbox is unavailable: %v", setupErr)
+	}
+	return cl
+}
+
 func GetAdminAddr() string {
 	return adminAddr
 }
@@ -408,6 +435,12 @@ func GetGrpcAddr() string {
 	return grpcAddr
 }
 
+// GetJSONAPIAddr returns the host:port of the participant's JSON Ledger API, or "" when the
+// sandbox container does not expose it.
+func GetJSONAPIAddr() string {
+	return jsonAPIAddr
+}
+
 func waitForSynchronizerConnection(ctx context.Context, cl *client.DamlBindingClient, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
 
@@ -422,7 +455,7 @@ func waitForSynchronizerConnection(ctx context.Context, cl *client.DamlBindingCl
 		if err == nil && resp != nil && len(resp.ConnectedSynchronizers) > 0 {
 			log.Info().
 				Int("count", len(resp.ConnectedSynchronizers)).
-				Str("first_id", resp.ConnectedSynchronizers[0].SynchronizerID).
+				Str("first_id", string(resp.ConnectedSynchronizers[0].SynchronizerID)).
 				Msg("synchronizer connection established")
 			return nil
 		}
@@ -1,19 +1,17 @@
 package types
 
 import (
-	"math/big"
 	"time"
-
-	"github.com/shopspring/decimal"
 )
 
 type (
-	PARTY       string
-	TEXT        string
-	INT64       int64
-	BOOL        bool
-	DECIMAL     *big.Int
-	NUMERIC     string
+	PARTY   string
+	TEXT    string
+	INT64   int64
+	BOOL    bool
+	NUMERIC = Numeric
+	// DECIMAL is the legacy DAML Decimal, a Numeric with scale DecimalScale.
+	DECIMAL     = Numeric
 	DATE        time.Time
 	TIMESTAMP   time.Time
 	UNIT        struct{}
@@ -31,12 +29,6 @@ type (
 	}
 )
 
-func NewNumericFromDecimal(d decimal.Decimal) NUMERIC {
-	// Convert decimal to string with 10 decimal places precision
-	scaled := d.Shift(10)
-	return NUMERIC(scaled.String())
-}
-
 // VARIANT represents a DAML variant/union type
 type VARIANT interface {
 	GetVariantTag() string
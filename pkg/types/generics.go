@@ -0,0 +1,484 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// AnyOptional is implemented by every Optional[T] so codecs can handle optionals without knowing T.
+type AnyOptional interface {
+	AnyValue() (interface{}, bool)
+	ValueType() reflect.Type
+	// WithAnyValue returns Some(v) as the same Optional type.
+	WithAnyValue(v interface{}) (interface{}, error)
+}
+
+// AnyEntry is a GenMap entry with its key and value type erased.
+type AnyEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// MarshalJSON encodes the entry as a [key, value] pair.
+func (e AnyEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]interface{}{e.Key, e.Value})
+}
+
+// AnyGenMap is implemented by every GenMap[K, V] so codecs can handle maps without knowing K and V.
+type AnyGenMap interface {
+	AnyEntries() []AnyEntry
+	KeyType() reflect.Type
+	ValueType() reflect.Type
+	// WithAnyEntries returns a map of the same type holding entries.
+	WithAnyEntries(entries []AnyEntry) (interface{}, error)
+}
+
+// AnyTextMap is implemented by every TextMap[V].
+type AnyTextMap interface {
+	AnyTextEntries() map[string]interface{}
+	ValueType() reflect.Type
+}
+
+// AnySet is implemented by every Set[T] so codecs can handle sets without knowing T.
+type AnySet interface {
+	AnyItems() []interface{}
+	ValueType() reflect.Type
+	// WithAnyItems returns a set of the same type holding items.
+	WithAnyItems(items []interface{}) (interface{}, error)
+}
+
+var anyOptionalType = reflect.TypeFor[AnyOptional]()
+
+// Optional is a DAML Optional. The zero value is None.
+type Optional[T any] struct {
+	value T
+	some  bool
+}
+
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, some: true}
+}
+
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.some
+}
+
+func (o Optional[T]) IsSome() bool {
+	return o.some
+}
+
+// OrElse returns the value if present and def otherwise.
+func (o Optional[T]) OrElse(def T) T {
+	if o.some {
+		return o.value
+	}
+	return def
+}
+
+func (o Optional[T]) AnyValue() (interface{}, bool) {
+	if !o.some {
+		return nil, false
+	}
+	return o.value, true
+}
+
+func (o Optional[T]) ValueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (o Optional[T]) WithAnyValue(v interface{}) (interface{}, error) {
+	value, err := anyAs[T](v)
+	if err != nil {
+		return nil, err
+	}
+	return Some(value), nil
+}
+
+// MarshalJSON encodes None as null and Some(x) as x, except that an optional holding an
+// optional uses [] for Some(None) and [x] for Some(Some(x)) as in the Daml-LF JSON encoding.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.some {
+		return []byte("null"), nil
+	}
+	data, err := json.Marshal(o.value)
+	if err != nil {
+		return nil, err
+	}
+	if inner, ok := any(o.value).(AnyOptional); ok {
+		if _, some := inner.AnyValue(); !some {
+			return []byte("[]"), nil
+		}
+		return append(append([]byte("["), data...), ']'), nil
+	}
+	return data, nil
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = None[T]()
+		return nil
+	}
+
+	var value T
+	if reflect.TypeFor[T]().Implements(anyOptionalType) {
+		var nested []json.RawMessage
+		if err := json.Unmarshal(data, &nested); err != nil || len(nested) > 1 {
+			return fmt.Errorf("expected [] or [value] for nested optional, got %s", data)
+		}
+		if len(nested) == 1 {
+			if string(nested[0]) == "null" {
+				return fmt.Errorf("nested optional [null] is not a valid encoding")
+			}
+			if err := json.Unmarshal(nested[0], &value); err != nil {
+				return err
+			}
+		}
+	} else if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*o = Some(value)
+	return nil
+}
+
+// MapEntry is a single GenMap or EntryList key/value pair.
+type MapEntry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// GenMap is a DAML GenMap: a map with keys of any type that keeps entries in insertion order.
+// The zero value is an empty map. Like a slice, a GenMap should not be modified through copies.
+type GenMap[K comparable, V any] struct {
+	keys   []K
+	values map[K]V
+}
+
+func NewGenMap[K comparable, V any](entries ...MapEntry[K, V]) GenMap[K, V] {
+	var m GenMap[K, V]
+	for _, e := range entries {
+		m.Set(e.Key, e.Value)
+	}
+	return m
+}
+
+// Set adds or replaces the value for key; replacing keeps the key's position.
+func (m *GenMap[K, V]) Set(key K, value V) {
+	if m.values == nil {
+		m.values = make(map[K]V)
+	}
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m GenMap[K, V]) Get(key K) (V, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func (m *GenMap[K, V]) Delete(key K) {
+	if _, exists := m.values[key]; !exists {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m GenMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+func (m GenMap[K, V]) Keys() []K {
+	return append([]K(nil), m.keys...)
+}
+
+func (m GenMap[K, V]) Entries() []MapEntry[K, V] {
+	entries := make([]MapEntry[K, V], len(m.keys))
+	for i, k := range m.keys {
+		entries[i] = MapEntry[K, V]{Key: k, Value: m.values[k]}
+	}
+	return entries
+}
+
+// All iterates over the entries in insertion order.
+func (m GenMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range m.keys {
+			if !yield(k, m.values[k]) {
+				return
+			}
+		}
+	}
+}
+
+func (m GenMap[K, V]) AnyEntries() []AnyEntry {
+	entries := make([]AnyEntry, len(m.keys))
+	for i, k := range m.keys {
+		entries[i] = AnyEntry{Key: k, Value: m.values[k]}
+	}
+	return entries
+}
+
+func (m GenMap[K, V]) KeyType() reflect.Type {
+	return reflect.TypeFor[K]()
+}
+
+func (m GenMap[K, V]) ValueType() reflect.Type {
+	return reflect.TypeFor[V]()
+}
+
+func (m GenMap[K, V]) WithAnyEntries(entries []AnyEntry) (interface{}, error) {
+	var result GenMap[K, V]
+	for i, e := range entries {
+		key, err := anyAs[K](e.Key)
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d key: %w", i, err)
+		}
+		value, err := anyAs[V](e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d value: %w", i, err)
+		}
+		result.Set(key, value)
+	}
+	return result, nil
+}
+
+// MarshalJSON encodes the map as a list of [key, value] pairs, since keys need not be text.
+func (m GenMap[K, V]) MarshalJSON() ([]byte, error) {
+	pairs := make([][2]interface{}, len(m.keys))
+	for i, k := range m.keys {
+		pairs[i] = [2]interface{}{k, m.values[k]}
+	}
+	return json.Marshal(pairs)
+}
+
+func (m *GenMap[K, V]) UnmarshalJSON(data []byte) error {
+	var pairs [][2]json.RawMessage
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return fmt.Errorf("expected a list of [key, value] pairs for GenMap: %w", err)
+	}
+
+	var result GenMap[K, V]
+	for _, p := range pairs {
+		var key K
+		var value V
+		if err := json.Unmarshal(p[0], &key); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(p[1], &value); err != nil {
+			return err
+		}
+		result.Set(key, value)
+	}
+	*m = result
+	return nil
+}
+
+// EntryList is a DAML GenMap kept as a list of entries in ledger order. Unlike GenMap its keys
+// need not be comparable, so it holds maps keyed by records, variants or lists.
+type EntryList[K, V any] []MapEntry[K, V]
+
+// Get returns the value of the first entry whose key is deeply equal to key.
+func (l EntryList[K, V]) Get(key K) (V, bool) {
+	for _, e := range l {
+		if reflect.DeepEqual(e.Key, key) {
+			return e.Value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+func (l EntryList[K, V]) AnyEntries() []AnyEntry {
+	entries := make([]AnyEntry, len(l))
+	for i, e := range l {
+		entries[i] = AnyEntry{Key: e.Key, Value: e.Value}
+	}
+	return entries
+}
+
+func (l EntryList[K, V]) KeyType() reflect.Type {
+	return reflect.TypeFor[K]()
+}
+
+func (l EntryList[K, V]) ValueType() reflect.Type {
+	return reflect.TypeFor[V]()
+}
+
+func (l EntryList[K, V]) WithAnyEntries(entries []AnyEntry) (interface{}, error) {
+	result := make(EntryList[K, V], len(entries))
+	for i, e := range entries {
+		key, err := anyAs[K](e.Key)
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d key: %w", i, err)
+		}
+		value, err := anyAs[V](e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("GenMap entry %d value: %w", i, err)
+		}
+		result[i] = MapEntry[K, V]{Key: key, Value: value}
+	}
+	return result, nil
+}
+
+// MarshalJSON encodes the list as [key, value] pairs, like GenMap.
+func (l EntryList[K, V]) MarshalJSON() ([]byte, error) {
+	pairs := make([][2]interface{}, len(l))
+	for i, e := range l {
+		pairs[i] = [2]interface{}{e.Key, e.Value}
+	}
+	return json.Marshal(pairs)
+}
+
+func (l *EntryList[K, V]) UnmarshalJSON(data []byte) error {
+	var pairs [][2]json.RawMessage
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return fmt.Errorf("expected a list of [key, value] pairs for GenMap: %w", err)
+	}
+
+	result := make(EntryList[K, V], len(pairs))
+	for i, p := range pairs {
+		if err := json.Unmarshal(p[0], &result[i].Key); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(p[1], &result[i].Value); err != nil {
+			return err
+		}
+	}
+	*l = result
+	return nil
+}
+
+// TextMap is a DAML TextMap, a map keyed by text.
+type TextMap[V any] map[string]V
+
+func (m TextMap[V]) AnyTextEntries() map[string]interface{} {
+	entries := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		entries[k] = v
+	}
+	return entries
+}
+
+func (m TextMap[V]) ValueType() reflect.Type {
+	return reflect.TypeFor[V]()
+}
+
+// Set is a DAML DA.Set.Set. It keeps items in insertion order; the zero value is an empty set.
+type Set[T comparable] struct {
+	items GenMap[T, struct{}]
+}
+
+func NewSet[T comparable](items ...T) Set[T] {
+	var s Set[T]
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+func (s *Set[T]) Add(item T) {
+	s.items.Set(item, struct{}{})
+}
+
+func (s *Set[T]) Remove(item T) {
+	s.items.Delete(item)
+}
+
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s.items.Get(item)
+	return ok
+}
+
+func (s Set[T]) Len() int {
+	return s.items.Len()
+}
+
+func (s Set[T]) Items() []T {
+	return s.items.Keys()
+}
+
+func (s Set[T]) AnyItems() []interface{} {
+	items := make([]interface{}, 0, s.Len())
+	for _, item := range s.items.keys {
+		items = append(items, item)
+	}
+	return items
+}
+
+func (s Set[T]) ValueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (s Set[T]) WithAnyItems(items []interface{}) (interface{}, error) {
+	var result Set[T]
+	for i, v := range items {
+		item, err := anyAs[T](v)
+		if err != nil {
+			return nil, fmt.Errorf("Set item %d: %w", i, err)
+		}
+		result.Add(item)
+	}
+	return result, nil
+}
+
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Items())
+}
+
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*s = NewSet(items...)
+	return nil
+}
+
+// AnyTuple is implemented by the DAML tuple types, whose fields are labelled _1, _2, _3 on the
+// ledger. Records are recognised as tuples by this marker, never by their field names.
+type AnyTuple interface {
+	isTuple()
+}
+
+// Tuple2 is a DAML (a, b) tuple, DA.Types:Tuple2.
+type Tuple2[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Tuple3 is a DAML (a, b, c) tuple, DA.Types:Tuple3.
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+func (Tuple2[A, B]) isTuple()    {}
+func (Tuple3[A, B, C]) isTuple() {}
+func (TUPLE2) isTuple()          {}
+
+func anyAs[T any](v interface{}) (T, error) {
+	var zero T
+	if v == nil {
+		return zero, nil
+	}
+	value, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("expected %v, got %T", reflect.TypeFor[T](), v)
+	}
+	return value, nil
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptional(t *testing.T) {
+	some := Some[TEXT]("x")
+	value, ok := some.Get()
+	require.True(t, ok)
+	require.Equal(t, TEXT("x"), value)
+	require.Equal(t, TEXT("x"), some.OrElse("y"))
+
+	var none Optional[TEXT]
+	require.Equal(t, None[TEXT](), none)
+	require.False(t, none.IsSome())
+	require.Equal(t, TEXT("y"), none.OrElse("y"))
+
+	wrapped, err := none.WithAnyValue(TEXT("z"))
+	require.NoError(t, err)
+	require.Equal(t, Some[TEXT]("z"), wrapped)
+	_, err = none.WithAnyValue(INT64(1))
+	require.Error(t, err)
+}
+
+func TestOptional_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value Optional[Optional[INT64]]
+		json  string
+	}{
+		{"none", None[Optional[INT64]](), `null`},
+		{"some none", Some(None[INT64]()), `[]`},
+		{"some some", Some(Some[INT64](1)), `[1]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.json, string(data))
+
+			var decoded Optional[Optional[INT64]]
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			require.Equal(t, tt.value, decoded)
+		})
+	}
+
+	var nested Optional[Optional[INT64]]
+	require.Error(t, json.Unmarshal([]byte(`[null]`), &nested))
+	require.Error(t, json.Unmarshal([]byte(`[1, 2]`), &nested))
+}
+
+func TestGenMap(t *testing.T) {
+	m := NewGenMap(MapEntry[PARTY, INT64]{Key: "Bob", Value: 1}, MapEntry[PARTY, INT64]{Key: "Alice", Value: 2})
+	m.Set("Carol", 3)
+	m.Set("Bob", 4)
+	require.Equal(t, []PARTY{"Bob", "Alice", "Carol"}, m.Keys())
+
+	value, ok := m.Get("Bob")
+	require.True(t, ok)
+	require.Equal(t, INT64(4), value)
+
+	m.Delete("Alice")
+	m.Delete("Dave")
+	require.Equal(t, 2, m.Len())
+	_, ok = m.Get("Alice")
+	require.False(t, ok)
+	require.Equal(t, []MapEntry[PARTY, INT64]{{Key: "Bob", Value: 4}, {Key: "Carol", Value: 3}}, m.Entries())
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	var decoded GenMap[PARTY, INT64]
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, m.Entries(), decoded.Entries())
+
+	var empty GenMap[PARTY, INT64]
+	require.Zero(t, empty.Len())
+	_, ok = empty.Get("Bob")
+	require.False(t, ok)
+}
+
+func TestEntryList(t *testing.T) {
+	l := EntryList[[]TEXT, INT64]{{Key: []TEXT{"a", "b"}, Value: 1}, {Key: []TEXT{}, Value: 2}}
+	value, ok := l.Get([]TEXT{"a", "b"})
+	require.True(t, ok)
+	require.Equal(t, INT64(1), value)
+	_, ok = l.Get([]TEXT{"b", "a"})
+	require.False(t, ok)
+
+	data, err := json.Marshal(l)
+	require.NoError(t, err)
+	var decoded EntryList[[]TEXT, INT64]
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, l, decoded)
+}
+
+func TestSet(t *testing.T) {
+	s := NewSet[PARTY]("Bob", "Alice", "Bob")
+	require.Equal(t, []PARTY{"Bob", "Alice"}, s.Items())
+	require.True(t, s.Contains("Alice"))
+
+	s.Remove("Alice")
+	require.False(t, s.Contains("Alice"))
+	require.Equal(t, 1, s.Len())
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.JSONEq(t, `["Bob"]`, string(data))
+	var decoded Set[PARTY]
+	require.NoError(t, json.Unmarshal([]byte(`["Carol", "Carol", "Bob"]`), &decoded))
+	require.Equal(t, []PARTY{"Carol", "Bob"}, decoded.Items())
+}
+
+func TestTuple(t *testing.T) {
+	require.Implements(t, (*AnyTuple)(nil), Tuple2[INT64, TEXT]{})
+	require.Implements(t, (*AnyTuple)(nil), Tuple3[INT64, TEXT, BOOL]{})
+
+	var record interface{} = struct {
+		First  INT64
+		Second INT64
+	}{}
+	_, ok := record.(AnyTuple)
+	require.False(t, ok)
+}
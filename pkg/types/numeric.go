@@ -0,0 +1,282 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// NumericMaxScale is the largest scale of a DAML-LF Numeric.
+	NumericMaxScale = 37
+	// NumericMaxPrecision is the number of significant digits a DAML-LF Numeric can hold.
+	NumericMaxPrecision = 38
+	// DecimalScale is the scale of the legacy DAML Decimal type, Numeric 10.
+	DecimalScale = 10
+)
+
+var numericUnscaledLimit = new(big.Int).Exp(big.NewInt(10), big.NewInt(NumericMaxPrecision), nil)
+
+// Numeric is a DAML Numeric: an unscaled integer and a scale between 0 and 37, so
+// 1.50 has unscaled value 150 and scale 2. The zero value is an unset Numeric.
+type Numeric struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewNumeric returns unscaled * 10^-scale.
+func NewNumeric(unscaled *big.Int, scale int32) (Numeric, error) {
+	if unscaled == nil {
+		return Numeric{}, fmt.Errorf("numeric unscaled value is nil")
+	}
+	n := newNumeric(new(big.Int).Set(unscaled), scale)
+	if err := n.Validate(); err != nil {
+		return Numeric{}, err
+	}
+	return n, nil
+}
+
+// ParseNumeric parses a decimal literal such as "-12.3400"; the scale is the number of
+// fractional digits, so trailing zeros are significant.
+func ParseNumeric(s string) (Numeric, error) {
+	literal := strings.TrimSpace(s)
+	digits, negative := strings.CutPrefix(literal, "-")
+	if !negative {
+		digits = strings.TrimPrefix(digits, "+")
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(digits, ".")
+	if intPart == "" || (hasDot && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return Numeric{}, fmt.Errorf("invalid numeric literal %q", s)
+	}
+
+	unscaled, _ := new(big.Int).SetString(intPart+fracPart, 10)
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+	if len(fracPart) > NumericMaxScale {
+		return Numeric{}, fmt.Errorf("numeric literal %q has scale %d, maximum is %d", s, len(fracPart), NumericMaxScale)
+	}
+
+	n := newNumeric(unscaled, int32(len(fracPart)))
+	if err := n.Validate(); err != nil {
+		return Numeric{}, err
+	}
+	return n, nil
+}
+
+// MustParseNumeric is like ParseNumeric but panics on invalid input.
+func MustParseNumeric(s string) Numeric {
+	n, err := ParseNumeric(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// NewNumericFromDecimal converts d keeping its own scale; 1.5 becomes a Numeric with scale 1.
+func NewNumericFromDecimal(d decimal.Decimal) (Numeric, error) {
+	if d.Exponent() >= 0 {
+		return NewNumeric(d.BigInt(), 0)
+	}
+	return NewNumeric(d.Coefficient(), -d.Exponent())
+}
+
+// NewNumericFromRat converts r to the given scale, failing if r is not exactly representable.
+func NewNumericFromRat(r *big.Rat, scale int32) (Numeric, error) {
+	if r == nil {
+		return Numeric{}, fmt.Errorf("numeric value is nil")
+	}
+	if err := validateNumericScale(scale); err != nil {
+		return Numeric{}, err
+	}
+
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	if !scaled.IsInt() {
+		return Numeric{}, fmt.Errorf("%s is not representable with scale %d", r.RatString(), scale)
+	}
+	return NewNumeric(scaled.Num(), scale)
+}
+
+func newNumeric(unscaled *big.Int, scale int32) Numeric {
+	if unscaled.Sign() == 0 {
+		// keep zero values comparable with reflect.DeepEqual
+		unscaled = new(big.Int)
+	}
+	return Numeric{unscaled: unscaled, scale: scale}
+}
+
+// IsZero reports whether n is the unset zero value. Use Sign to test for the number zero.
+func (n Numeric) IsZero() bool {
+	return n.unscaled == nil
+}
+
+func (n Numeric) Scale() int32 {
+	return n.scale
+}
+
+// Unscaled returns a copy of the unscaled integer value.
+func (n Numeric) Unscaled() *big.Int {
+	if n.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(n.unscaled)
+}
+
+func (n Numeric) Sign() int {
+	if n.unscaled == nil {
+		return 0
+	}
+	return n.unscaled.Sign()
+}
+
+// Validate checks the DAML-LF bounds: a scale of 0 to 37 and at most 38 significant digits.
+func (n Numeric) Validate() error {
+	if err := validateNumericScale(n.scale); err != nil {
+		return err
+	}
+	if n.unscaled != nil && new(big.Int).Abs(n.unscaled).Cmp(numericUnscaledLimit) >= 0 {
+		return fmt.Errorf("numeric %s exceeds %d significant digits", n, NumericMaxPrecision)
+	}
+	return nil
+}
+
+// String formats n with exactly Scale fractional digits.
+func (n Numeric) String() string {
+	digits := n.Unscaled().String()
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if n.scale <= 0 {
+		return sign + digits
+	}
+
+	if pad := int(n.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	split := len(digits) - int(n.scale)
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// Rescale changes the scale, failing if digits would be lost.
+func (n Numeric) Rescale(scale int32) (Numeric, error) {
+	return NewNumericFromRat(n.Rat(), scale)
+}
+
+// Round changes the scale, rounding half to even like DAML-LF.
+func (n Numeric) Round(scale int32) (Numeric, error) {
+	return roundRat(n.Rat(), scale)
+}
+
+// Add returns n + m exactly, with the larger of the two scales.
+func (n Numeric) Add(m Numeric) (Numeric, error) {
+	return NewNumericFromRat(new(big.Rat).Add(n.Rat(), m.Rat()), max(n.scale, m.scale))
+}
+
+// Sub returns n - m exactly, with the larger of the two scales.
+func (n Numeric) Sub(m Numeric) (Numeric, error) {
+	return NewNumericFromRat(new(big.Rat).Sub(n.Rat(), m.Rat()), max(n.scale, m.scale))
+}
+
+// Mul returns n * m at the given scale, rounding half to even like DAML-LF MUL_NUMERIC.
+func (n Numeric) Mul(m Numeric, scale int32) (Numeric, error) {
+	return roundRat(new(big.Rat).Mul(n.Rat(), m.Rat()), scale)
+}
+
+// Div returns n / m at the given scale, rounding half to even like DAML-LF DIV_NUMERIC.
+func (n Numeric) Div(m Numeric, scale int32) (Numeric, error) {
+	if m.Sign() == 0 {
+		return Numeric{}, fmt.Errorf("numeric division by zero")
+	}
+	return roundRat(new(big.Rat).Quo(n.Rat(), m.Rat()), scale)
+}
+
+// Cmp compares the values of n and m regardless of their scales.
+func (n Numeric) Cmp(m Numeric) int {
+	return n.Rat().Cmp(m.Rat())
+}
+
+// Equal reports whether n and m have the same value and scale.
+func (n Numeric) Equal(m Numeric) bool {
+	return n.scale == m.scale && n.Cmp(m) == 0
+}
+
+func (n Numeric) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(n.Unscaled(), pow10(n.scale))
+}
+
+func (n Numeric) Decimal() decimal.Decimal {
+	return decimal.NewFromBigInt(n.Unscaled(), -n.scale)
+}
+
+func (n Numeric) MarshalJSON() ([]byte, error) {
+	if n.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.String())
+}
+
+// UnmarshalJSON accepts a JSON string or number.
+func (n *Numeric) UnmarshalJSON(data []byte) error {
+	literal := string(data)
+	if literal == "null" {
+		*n = Numeric{}
+		return nil
+	}
+	if strings.HasPrefix(literal, `"`) {
+		if err := json.Unmarshal(data, &literal); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseNumeric(literal)
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
+func roundRat(r *big.Rat, scale int32) (Numeric, error) {
+	if err := validateNumericScale(scale); err != nil {
+		return Numeric{}, err
+	}
+
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	quo, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	// compare 2*|rem| with the denominator to round half to even
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	if c := half.Cmp(scaled.Denom()); c > 0 || (c == 0 && quo.Bit(0) == 1) {
+		if scaled.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return NewNumeric(quo, scale)
+}
+
+func validateNumericScale(scale int32) error {
+	if scale < 0 || scale > NumericMaxScale {
+		return fmt.Errorf("numeric scale %d is outside 0..%d", scale, NumericMaxScale)
+	}
+	return nil
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, ch := range s {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
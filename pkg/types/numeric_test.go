@@ -0,0 +1,166 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumeric(t *testing.T) {
+	n := MustParseNumeric("-12.3400")
+	require.Equal(t, int32(4), n.Scale())
+	require.Equal(t, big.NewInt(-123400), n.Unscaled())
+	require.Equal(t, "-12.3400", n.String())
+	require.Equal(t, -1, n.Sign())
+
+	require.Equal(t, "0", MustParseNumeric("-0").String())
+
+	for _, invalid := range []string{"", "-", ".05", "1.", "1.2.3", "1e5", "+-1", "1,5", "0x10"} {
+		_, err := ParseNumeric(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestNumeric_Bounds(t *testing.T) {
+	t.Run("scale", func(t *testing.T) {
+		n, err := ParseNumeric("0." + strings.Repeat("0", 36) + "1")
+		require.NoError(t, err)
+		require.Equal(t, int32(37), n.Scale())
+
+		_, err = ParseNumeric("1." + strings.Repeat("0", 38))
+		require.ErrorContains(t, err, "scale")
+		_, err = NewNumeric(big.NewInt(1), 38)
+		require.ErrorContains(t, err, "scale")
+		_, err = NewNumeric(big.NewInt(1), -1)
+		require.ErrorContains(t, err, "scale")
+		_, err = MustParseNumeric("1").Rescale(38)
+		require.ErrorContains(t, err, "scale")
+	})
+
+	t.Run("precision", func(t *testing.T) {
+		_, err := ParseNumeric(strings.Repeat("9", 38))
+		require.NoError(t, err)
+		_, err = ParseNumeric("-0." + strings.Repeat("9", 37))
+		require.NoError(t, err)
+
+		_, err = ParseNumeric(strings.Repeat("9", 39))
+		require.ErrorContains(t, err, "significant digits")
+		_, err = ParseNumeric("-1" + strings.Repeat("0", 38))
+		require.ErrorContains(t, err, "significant digits")
+		_, err = ParseNumeric("9." + strings.Repeat("9", 37))
+		require.NoError(t, err)
+		_, err = ParseNumeric("99." + strings.Repeat("9", 37))
+		require.ErrorContains(t, err, "significant digits")
+
+		largest := MustParseNumeric(strings.Repeat("9", 38))
+		_, err = largest.Add(MustParseNumeric("1"))
+		require.ErrorContains(t, err, "significant digits")
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		var n Numeric
+		require.True(t, n.IsZero())
+		require.Equal(t, 0, n.Sign())
+		require.Equal(t, "0", n.String())
+		require.False(t, MustParseNumeric("0").IsZero())
+	})
+}
+
+func TestNumeric_Arithmetic(t *testing.T) {
+	sum, err := MustParseNumeric("1.25").Add(MustParseNumeric("0.125"))
+	require.NoError(t, err)
+	require.Equal(t, "1.375", sum.String())
+
+	diff, err := MustParseNumeric("1").Sub(MustParseNumeric("1.50"))
+	require.NoError(t, err)
+	require.Equal(t, "-0.50", diff.String())
+
+	product, err := MustParseNumeric("0.25").Mul(MustParseNumeric("0.5"), 2)
+	require.NoError(t, err)
+	require.Equal(t, "0.12", product.String())
+
+	quotient, err := MustParseNumeric("-1").Div(MustParseNumeric("3"), 4)
+	require.NoError(t, err)
+	require.Equal(t, "-0.3333", quotient.String())
+
+	_, err = MustParseNumeric("1").Div(MustParseNumeric("0.0"), 2)
+	require.Error(t, err)
+
+	require.Equal(t, 0, MustParseNumeric("1.50").Cmp(MustParseNumeric("1.5")))
+	require.Equal(t, -1, MustParseNumeric("-2").Cmp(MustParseNumeric("1.5")))
+	require.False(t, MustParseNumeric("1.50").Equal(MustParseNumeric("1.5")))
+}
+
+func TestNumeric_Rounding(t *testing.T) {
+	tests := []struct {
+		in       string
+		scale    int32
+		expected string
+	}{
+		{"2.25", 1, "2.2"},
+		{"2.35", 1, "2.4"},
+		{"2.251", 1, "2.3"},
+		{"-2.25", 1, "-2.2"},
+		{"-2.35", 1, "-2.4"},
+		{"-2.251", 1, "-2.3"},
+		{"-2.249", 1, "-2.2"},
+		{"-0.5", 0, "0"},
+		{"-1.5", 0, "-2"},
+		{"-0.05", 1, "0.0"},
+	}
+	for _, tt := range tests {
+		rounded, err := MustParseNumeric(tt.in).Round(tt.scale)
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.expected, rounded.String(), tt.in)
+	}
+
+	quotient, err := MustParseNumeric("-5").Div(MustParseNumeric("2"), 0)
+	require.NoError(t, err)
+	require.Equal(t, "-2", quotient.String())
+
+	product, err := MustParseNumeric("-0.15").Mul(MustParseNumeric("1"), 1)
+	require.NoError(t, err)
+	require.Equal(t, "-0.2", product.String())
+
+	n, err := MustParseNumeric("2.5").Rescale(3)
+	require.NoError(t, err)
+	require.Equal(t, "2.500", n.String())
+	_, err = MustParseNumeric("-2.55").Rescale(1)
+	require.Error(t, err)
+}
+
+func TestNumeric_Conversions(t *testing.T) {
+	n, err := NewNumericFromDecimal(decimal.RequireFromString("12.340"))
+	require.NoError(t, err)
+	require.Equal(t, "12.340", n.String())
+	require.True(t, decimal.RequireFromString("12.34").Equal(n.Decimal()))
+
+	n, err = NewNumericFromDecimal(decimal.RequireFromString("-1.5e3"))
+	require.NoError(t, err)
+	require.Equal(t, "-1500", n.String())
+
+	n, err = NewNumericFromRat(big.NewRat(1, 8), 3)
+	require.NoError(t, err)
+	require.Equal(t, "0.125", n.String())
+	require.Equal(t, big.NewRat(1, 8), n.Rat())
+
+	_, err = NewNumericFromRat(big.NewRat(1, 3), 37)
+	require.Error(t, err)
+}
+
+func TestNumeric_JSON(t *testing.T) {
+	var n Numeric
+	require.NoError(t, n.UnmarshalJSON([]byte(`"-0.0000000200"`)))
+	require.Equal(t, int32(10), n.Scale())
+	require.NoError(t, n.UnmarshalJSON([]byte(`1.50`)))
+	require.Equal(t, "1.50", n.String())
+
+	data, err := n.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `"1.50"`, string(data))
+
+	require.Error(t, n.UnmarshalJSON([]byte(`true`)))
+}
@@ -0,0 +1,140 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+var (
+	// MinDate and MaxDate bound DAML Dates; Timestamps cover the same days.
+	MinDate = Date{Year: 1, Month: time.January, Day: 1}
+	MaxDate = Date{Year: 9999, Month: time.December, Day: 31}
+
+	MinTimestamp = time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	MaxTimestamp = time.Date(9999, time.December, 31, 23, 59, 59, 999999000, time.UTC)
+)
+
+// Date is a DAML Date, a calendar date without a time zone, like civil.Date.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar date of t in its own location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// DateFromDays converts the ledger encoding, days since 1970-01-01.
+func DateFromDays(days int32) (Date, error) {
+	d := DateOf(time.Unix(int64(days)*secondsPerDay, 0).UTC())
+	if err := d.Validate(); err != nil {
+		return Date{}, err
+	}
+	return d, nil
+}
+
+// ParseDate parses a date in YYYY-MM-DD form.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+	d := DateOf(t)
+	if err := d.Validate(); err != nil {
+		return Date{}, err
+	}
+	return d, nil
+}
+
+// IsValid reports whether d is a real calendar date, so 2023-02-29 is not.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// Validate checks that d is a real calendar date between MinDate and MaxDate.
+func (d Date) Validate() error {
+	if !d.IsValid() {
+		return fmt.Errorf("invalid date %04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+	}
+	if d.Before(MinDate) || MaxDate.Before(d) {
+		return fmt.Errorf("date %s is outside the DAML range %s to %s", d, MinDate, MaxDate)
+	}
+	return nil
+}
+
+// Days returns the ledger encoding of d, days since 1970-01-01, failing outside the DAML range.
+func (d Date) Days() (int32, error) {
+	if err := d.Validate(); err != nil {
+		return 0, err
+	}
+	return int32(d.In(time.UTC).Unix() / secondsPerDay), nil
+}
+
+// In returns midnight at the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+func (d Date) Before(other Date) bool {
+	if d.Year != other.Year {
+		return d.Year < other.Year
+	}
+	if d.Month != other.Month {
+		return d.Month < other.Month
+	}
+	return d.Day < other.Day
+}
+
+// String formats d as YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// ValidateTimestamp checks that t lies between MinTimestamp and MaxTimestamp and has no
+// sub-microsecond part, which the ledger would drop.
+func ValidateTimestamp(t time.Time) error {
+	if t.Before(MinTimestamp) || t.After(MaxTimestamp) {
+		return fmt.Errorf("timestamp %s is outside the DAML range %s to %s",
+			t.Format(time.RFC3339Nano), MinTimestamp.Format(time.RFC3339), MaxTimestamp.Format(time.RFC3339Nano))
+	}
+	if t.Nanosecond()%int(time.Microsecond) != 0 {
+		return fmt.Errorf("timestamp %s has sub-microsecond precision, truncate it with Truncate(time.Microsecond)",
+			t.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// TimestampMicros returns the ledger encoding of t, microseconds since the epoch.
+func TimestampMicros(t time.Time) (int64, error) {
+	if err := ValidateTimestamp(t); err != nil {
+		return 0, err
+	}
+	return t.UnixMicro(), nil
+}
+
+// TimestampFromMicros converts the ledger encoding to a UTC time.
+func TimestampFromMicros(micros int64) (time.Time, error) {
+	t := time.UnixMicro(micros).UTC()
+	if err := ValidateTimestamp(t); err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate_Days(t *testing.T) {
+	tests := []struct {
+		date Date
+		days int32
+	}{
+		{MinDate, -719162},
+		{Date{Year: 1969, Month: time.December, Day: 31}, -1},
+		{Date{Year: 1970, Month: time.January, Day: 1}, 0},
+		{Date{Year: 2024, Month: time.February, Day: 29}, 19782},
+		{MaxDate, 2932896},
+	}
+	for _, tt := range tests {
+		days, err := tt.date.Days()
+		require.NoError(t, err, tt.date)
+		require.Equal(t, tt.days, days, tt.date)
+
+		date, err := DateFromDays(tt.days)
+		require.NoError(t, err, tt.days)
+		require.Equal(t, tt.date, date)
+	}
+
+	for _, days := range []int32{-719163, 2932897} {
+		_, err := DateFromDays(days)
+		require.ErrorContains(t, err, "outside the DAML range", days)
+	}
+
+	_, err := Date{Year: 0, Month: time.December, Day: 31}.Days()
+	require.ErrorContains(t, err, "outside the DAML range")
+	_, err = Date{Year: 10000, Month: time.January, Day: 1}.Days()
+	require.ErrorContains(t, err, "outside the DAML range")
+	_, err = Date{Year: 2023, Month: time.February, Day: 29}.Days()
+	require.ErrorContains(t, err, "invalid date")
+}
+
+func TestParseDate(t *testing.T) {
+	d, err := ParseDate("0001-01-01")
+	require.NoError(t, err)
+	require.Equal(t, MinDate, d)
+	require.Equal(t, "0001-01-01", d.String())
+
+	var parsed Date
+	require.NoError(t, parsed.UnmarshalText([]byte("9999-12-31")))
+	require.Equal(t, MaxDate, parsed)
+	text, err := parsed.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "9999-12-31", string(text))
+
+	for _, invalid := range []string{"2023-02-29", "2024-13-01", "24-01-01", "0000-12-31", "2024-01-01T00:00:00Z"} {
+		_, err := ParseDate(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestDateOf(t *testing.T) {
+	late := time.Date(2024, 5, 1, 23, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	require.Equal(t, Date{Year: 2024, Month: time.May, Day: 1}, DateOf(late))
+	require.Equal(t, Date{Year: 2024, Month: time.May, Day: 2}, DateOf(late.UTC()))
+
+	require.True(t, MinDate.Before(MaxDate))
+	require.False(t, MaxDate.Before(MinDate))
+	require.False(t, Date{Year: 2024, Month: time.February, Day: 30}.IsValid())
+}
+
+func TestTimestampMicros(t *testing.T) {
+	tests := []struct {
+		at     time.Time
+		micros int64
+	}{
+		{MinTimestamp, -62135596800000000},
+		{time.Date(1969, 12, 31, 23, 59, 59, 999999000, time.UTC), -1},
+		{time.Unix(0, 0), 0},
+		{MaxTimestamp, 253402300799999999},
+	}
+	for _, tt := range tests {
+		micros, err := TimestampMicros(tt.at)
+		require.NoError(t, err, tt.at)
+		require.Equal(t, tt.micros, micros, tt.at)
+
+		at, err := TimestampFromMicros(tt.micros)
+		require.NoError(t, err, tt.micros)
+		require.True(t, tt.at.Equal(at), tt.micros)
+		require.Equal(t, time.UTC, at.Location())
+	}
+
+	_, err := TimestampMicros(MinTimestamp.Add(-time.Microsecond))
+	require.ErrorContains(t, err, "outside the DAML range")
+	_, err = TimestampMicros(MaxTimestamp.Add(time.Microsecond))
+	require.ErrorContains(t, err, "outside the DAML range")
+	_, err = TimestampMicros(time.Date(2024, 1, 1, 0, 0, 0, 1500, time.UTC))
+	require.ErrorContains(t, err, "sub-microsecond precision")
+
+	_, err = TimestampFromMicros(MaxTimestamp.UnixMicro() + 1)
+	require.ErrorContains(t, err, "outside the DAML range")
+}
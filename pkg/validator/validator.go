@@ -0,0 +1,413 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v2 "github.com/digital-asset/dazl-client/v8/go/api/com/daml/ledger/api/v2"
+	"github.com/noders-team/go-daml/pkg/lf"
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/ledger"
+	"github.com/noders-team/go-daml/pkg/types"
+)
+
+// FieldError is a single problem with a value, at a path such as someSimplePair.left.
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError lists the problems found in the arguments of a command.
+type ValidationError struct {
+	TemplateID string
+	Choice     string
+	Errors     []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		msgs[i] = fieldErr.Error()
+	}
+	if e.Choice != "" {
+		return fmt.Sprintf("invalid argument of choice %s on %s: %s", e.Choice, e.TemplateID, strings.Join(msgs, "; "))
+	}
+	return fmt.Sprintf("invalid arguments of %s: %s", e.TemplateID, strings.Join(msgs, "; "))
+}
+
+// Validator checks command arguments against the template and choice signatures of
+// DAML-LF packages, so that wrong fields and types are reported before submission.
+// Values whose types come from packages missing from the registry are not checked.
+type Validator struct {
+	registry *lf.Registry
+}
+
+func NewValidator(registry *lf.Registry) *Validator {
+	return &Validator{registry: registry}
+}
+
+// ValidateCreate checks create arguments, a map[string]interface{}, a generated template
+// struct or a *v2.Record, against the template's fields.
+func (v *Validator) ValidateCreate(templateID string, args interface{}) error {
+	template, err := v.registry.Template(templateID)
+	if err != nil {
+		return err
+	}
+	dataType, ok := v.registry.DataType(template.ID)
+	if !ok {
+		return fmt.Errorf("template %s has no data type", templateID)
+	}
+
+	value, err := recordValue(args)
+	if err != nil {
+		return fmt.Errorf("invalid arguments of %s: %w", templateID, err)
+	}
+
+	c := &checker{registry: v.registry}
+	c.checkData(value, dataType, nil, "")
+	return c.result(templateID, "")
+}
+
+// ValidateExercise checks a choice argument against the choice's parameter type. The
+// choice may be defined by the template, by an interface it implements, or templateID
+// may name the interface itself.
+func (v *Validator) ValidateExercise(templateID, choice string, arg interface{}) error {
+	def, err := v.registry.Choice(templateID, choice)
+	if err != nil {
+		return err
+	}
+
+	value, err := choiceValue(arg)
+	if err != nil {
+		return fmt.Errorf("invalid argument of choice %s on %s: %w", choice, templateID, err)
+	}
+
+	c := &checker{registry: v.registry}
+	c.check(value, lf.TypeRef{Type: def.ArgType}, "")
+	return c.result(templateID, choice)
+}
+
+// ValidateCommands validates every create, exercise and exercise-by-key command.
+func (v *Validator) ValidateCommands(cmds []*model.Command) error {
+	var errs []error
+	for i, cmd := range cmds {
+		var err error
+		switch c := cmd.Command.(type) {
+		case *model.CreateCommand:
+			err = v.ValidateCreate(c.TemplateID, c.Arguments)
+		case *model.ExerciseCommand:
+			err = v.ValidateExercise(c.TemplateID, c.Choice, c.Arguments)
+		case *model.ExerciseByKeyCommand:
+			err = v.validateExerciseByKey(c)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("command %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (v *Validator) validateExerciseByKey(cmd *model.ExerciseByKeyCommand) error {
+	template, err := v.registry.Template(cmd.TemplateID)
+	if err != nil {
+		return err
+	}
+	if template.Key == nil {
+		return fmt.Errorf("template %s has no key", cmd.TemplateID)
+	}
+	def, err := v.registry.Choice(cmd.TemplateID, cmd.Choice)
+	if err != nil {
+		return err
+	}
+
+	key, err := ledger.MapToValue(cmd.Key)
+	if err != nil {
+		return fmt.Errorf("invalid key of %s: %w", cmd.TemplateID, err)
+	}
+	value, err := choiceValue(cmd.Arguments)
+	if err != nil {
+		return fmt.Errorf("invalid argument of choice %s on %s: %w", cmd.Choice, cmd.TemplateID, err)
+	}
+
+	c := &checker{registry: v.registry}
+	c.check(key, lf.TypeRef{Type: template.Key}, "key")
+	c.check(value, lf.TypeRef{Type: def.ArgType}, "")
+	return c.result(cmd.TemplateID, cmd.Choice)
+}
+
+// recordValue converts create arguments the way the command service does.
+func recordValue(args interface{}) (*v2.Value, error) {
+	switch a := args.(type) {
+	case *v2.Value:
+		return a, nil
+	case *v2.Record:
+		return &v2.Value{Sum: &v2.Value_Record{Record: a}}, nil
+	}
+	record, err := ledger.ConvertToRecord(args)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	return &v2.Value{Sum: &v2.Value_Record{Record: record}}, nil
+}
+
+// choiceValue converts a choice argument the way the command service does.
+func choiceValue(arg interface{}) (*v2.Value, error) {
+	switch a := arg.(type) {
+	case *v2.Value:
+		return a, nil
+	case *v2.Record:
+		return &v2.Value{Sum: &v2.Value_Record{Record: a}}, nil
+	}
+	return ledger.MapToValue(arg)
+}
+
+type checker struct {
+	registry *lf.Registry
+	errs     []*FieldError
+}
+
+func (c *checker) fail(path, format string, args ...interface{}) {
+	c.errs = append(c.errs, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) mismatch(path, expected string, value *v2.Value) {
+	c.fail(path, "expected %s, got %s", expected, valueKind(value))
+}
+
+func (c *checker) result(templateID, choice string) error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(c.errs, func(i, j int) bool { return c.errs[i].Path < c.errs[j].Path })
+	return &ValidationError{TemplateID: templateID, Choice: choice, Errors: c.errs}
+}
+
+func (c *checker) check(value *v2.Value, t lf.TypeRef, path string) {
+	typ, args, ok := c.registry.Resolve(t)
+	if !ok || typ.Kind == lf.TypeNat {
+		return
+	}
+	if typ.Kind == lf.TypeCon {
+		if dataType, ok := c.registry.DataType(typ.Con); ok {
+			c.checkData(value, dataType, args, path)
+		}
+		return
+	}
+
+	expected, checked := builtinKinds[typ.Builtin]
+	if !checked {
+		return
+	}
+	if kind := valueKind(value); kind != expected {
+		c.mismatch(path, c.builtinName(typ, args), value)
+		return
+	}
+
+	switch typ.Builtin {
+	case lf.BuiltinNumeric:
+		c.checkNumeric(value.GetNumeric(), args, path)
+	case lf.BuiltinOptional:
+		if inner := value.GetOptional().GetValue(); inner != nil && len(args) > 0 {
+			c.check(inner, args[0], path)
+		}
+	case lf.BuiltinList:
+		for i, elem := range value.GetList().GetElements() {
+			if len(args) > 0 {
+				c.check(elem, args[0], fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case lf.BuiltinTextMap:
+		seen := make(map[string]bool)
+		for _, entry := range value.GetTextMap().GetEntries() {
+			entryPath := fmt.Sprintf("%s[%q]", path, entry.Key)
+			if seen[entry.Key] {
+				c.fail(entryPath, "duplicate key")
+			}
+			seen[entry.Key] = true
+			if len(args) > 0 {
+				c.check(entry.Value, args[0], entryPath)
+			}
+		}
+	case lf.BuiltinGenMap:
+		for i, entry := range value.GetGenMap().GetEntries() {
+			if len(args) > 1 {
+				c.check(entry.Key, args[0], fmt.Sprintf("%s[%d].key", path, i))
+				c.check(entry.Value, args[1], fmt.Sprintf("%s[%d].value", path, i))
+			}
+		}
+	}
+}
+
+func (c *checker) checkNumeric(literal string, args []lf.TypeRef, path string) {
+	n, err := types.ParseNumeric(literal)
+	if err != nil {
+		c.fail(path, "invalid numeric %q", literal)
+		return
+	}
+	if scale, ok := c.registry.NumericScale(args); ok {
+		if _, err := n.Rescale(int32(scale)); err != nil {
+			c.fail(path, "%s is out of range for Numeric %d", literal, scale)
+		}
+	}
+}
+
+// checkData checks value against a data type applied to args.
+func (c *checker) checkData(value *v2.Value, dataType *lf.DataType, args []lf.TypeRef, path string) {
+	vars := lf.BindParams(dataType.Params, args)
+	name := dataType.ID.QualifiedName()
+
+	switch dataType.Kind {
+	case lf.DataRecord:
+		record := value.GetRecord()
+		if record == nil {
+			c.mismatch(path, "record "+name, value)
+			return
+		}
+		c.checkFields(record, dataType.Fields, vars, path)
+	case lf.DataVariant:
+		variant := value.GetVariant()
+		if variant == nil {
+			c.mismatch(path, "variant "+name, value)
+			return
+		}
+		if field := dataType.Field(variant.Constructor); field != nil {
+			c.check(variant.Value, lf.TypeRef{Type: field.Type, Vars: vars}, joinPath(path, variant.Constructor))
+			return
+		}
+		c.fail(path, "unknown constructor %s of variant %s", variant.Constructor, name)
+	case lf.DataEnum:
+		enum := value.GetEnum()
+		if enum == nil {
+			c.mismatch(path, "enum "+name, value)
+			return
+		}
+		for _, constructor := range dataType.Constructors {
+			if constructor == enum.Constructor {
+				return
+			}
+		}
+		c.fail(path, "unknown constructor %s of enum %s, expected one of %s", enum.Constructor, name, strings.Join(dataType.Constructors, ", "))
+	}
+}
+
+// checkFields matches record fields by label, or by position when unlabelled.
+func (c *checker) checkFields(record *v2.Record, fields []*lf.Field, vars map[string]lf.TypeRef, path string) {
+	index := make(map[string]int, len(fields))
+	for i, field := range fields {
+		index[field.Name] = i
+	}
+
+	values := make([]*v2.Value, len(fields))
+	present := make([]bool, len(fields))
+	for i, field := range record.Fields {
+		pos := i
+		if field.Label != "" {
+			var ok bool
+			if pos, ok = index[field.Label]; !ok {
+				c.fail(joinPath(path, field.Label), "unknown field")
+				continue
+			}
+		} else if pos >= len(fields) {
+			c.fail(path, "unexpected field at position %d, the record has %d fields", i, len(fields))
+			continue
+		}
+		if present[pos] {
+			c.fail(joinPath(path, fields[pos].Name), "duplicate field")
+			continue
+		}
+		values[pos], present[pos] = field.Value, true
+	}
+
+	for i, field := range fields {
+		fieldType := lf.TypeRef{Type: field.Type, Vars: vars}
+		fieldPath := joinPath(path, field.Name)
+		if !present[i] {
+			// missing optional fields default to None
+			if typ, _, ok := c.registry.Resolve(fieldType); !ok || !typ.IsBuiltin(lf.BuiltinOptional) {
+				c.fail(fieldPath, "missing field")
+			}
+			continue
+		}
+		c.check(values[i], fieldType, fieldPath)
+	}
+}
+
+// builtinKinds maps the serializable builtin types to the kind of value they hold.
+var builtinKinds = map[lf.BuiltinType]string{
+	lf.BuiltinUnit:       "Unit",
+	lf.BuiltinBool:       "Bool",
+	lf.BuiltinInt64:      "Int64",
+	lf.BuiltinText:       "Text",
+	lf.BuiltinNumeric:    "Numeric",
+	lf.BuiltinParty:      "Party",
+	lf.BuiltinDate:       "Date",
+	lf.BuiltinTimestamp:  "Timestamp",
+	lf.BuiltinContractID: "ContractId",
+	lf.BuiltinOptional:   "Optional",
+	lf.BuiltinList:       "List",
+	lf.BuiltinTextMap:    "TextMap",
+	lf.BuiltinGenMap:     "GenMap",
+}
+
+func (c *checker) builtinName(typ *lf.Type, args []lf.TypeRef) string {
+	if scale, ok := c.registry.NumericScale(args); ok && typ.IsBuiltin(lf.BuiltinNumeric) {
+		return "Numeric " + strconv.FormatInt(scale, 10)
+	}
+	return builtinKinds[typ.Builtin]
+}
+
+func valueKind(value *v2.Value) string {
+	switch value.GetSum().(type) {
+	case *v2.Value_Unit:
+		return "Unit"
+	case *v2.Value_Bool:
+		return "Bool"
+	case *v2.Value_Int64:
+		return "Int64"
+	case *v2.Value_Text:
+		return "Text"
+	case *v2.Value_Numeric:
+		return "Numeric"
+	case *v2.Value_Party:
+		return "Party"
+	case *v2.Value_Date:
+		return "Date"
+	case *v2.Value_Timestamp:
+		return "Timestamp"
+	case *v2.Value_ContractId:
+		return "ContractId"
+	case *v2.Value_Optional:
+		return "Optional"
+	case *v2.Value_List:
+		return "List"
+	case *v2.Value_TextMap:
+		return "TextMap"
+	case *v2.Value_GenMap:
+		return "GenMap"
+	case *v2.Value_Record:
+		return "Record"
+	case *v2.Value_Variant:
+		return "Variant"
+	case *v2.Value_Enum:
+		return "Enum"
+	default:
+		return "unsupported value"
+	}
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/lf"
+	"github.com/noders-team/go-daml/pkg/model"
+	. "github.com/noders-team/go-daml/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	allKindsDar   = "../../test-data/all-kinds-of-1.0.0.dar"
+	interfacesDar = "../../test-data/amulets-interface-test-1.0.0.dar"
+	oneOfAll      = "#all-kinds-of:AllKindsOf:OneOfEverything"
+)
+
+type testColor string
+
+func (c testColor) GetEnumConstructor() string { return string(c) }
+func (c testColor) GetEnumTypeID() string      { return "AllKindsOf:Color" }
+
+type testVariant struct {
+	tag   string
+	value interface{}
+}
+
+func (v testVariant) GetVariantTag() string        { return v.tag }
+func (v testVariant) GetVariantValue() interface{} { return v.value }
+
+func loadDar(t *testing.T, path string) *Validator {
+	dar, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	registry := lf.NewRegistry()
+	_, err = registry.AddDar(dar)
+	require.NoError(t, err)
+	return NewValidator(registry)
+}
+
+func pair(left, right interface{}) map[string]interface{} {
+	return map[string]interface{}{"left": left, "right": right}
+}
+
+func validArguments() map[string]interface{} {
+	return map[string]interface{}{
+		"operator":        PARTY("Alice::1220aa"),
+		"someBoolean":     BOOL(true),
+		"someInteger":     INT64(190),
+		"someDecimal":     MustParseNumeric("1.5"),
+		"someMaybe":       Some(INT64(42)),
+		"someMaybeNot":    None[INT64](),
+		"someText":        TEXT("text"),
+		"someDate":        DATE(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+		"someDatetime":    TIMESTAMP(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+		"someSimpleList":  []INT64{1, 2, 3},
+		"someSimplePair":  pair(INT64(1), INT64(2)),
+		"someNestedPair":  pair(pair(INT64(1), INT64(2)), pair(INT64(3), INT64(4))),
+		"someUglyNesting": testVariant{tag: "Both", value: testVariant{tag: "Left", value: pair(pair(INT64(1), INT64(2)), pair(INT64(3), INT64(4)))}},
+		"someMeasurement": MustParseNumeric("0.0000000001"),
+		"someEnum":        testColor("Green"),
+		"theUnit":         UNIT{},
+	}
+}
+
+func TestValidateCreate(t *testing.T) {
+	v := loadDar(t, allKindsDar)
+
+	require.NoError(t, v.ValidateCreate(oneOfAll, validArguments()))
+
+	args := validArguments()
+	delete(args, "someText")
+	delete(args, "someMaybeNot")
+	args["someTxt"] = TEXT("typo")
+	args["someInteger"] = "190"
+	args["someDecimal"] = MustParseNumeric("1.12345678901")
+	args["someEnum"] = testColor("Purple")
+	args["someSimpleList"] = []interface{}{INT64(1), 2.5}
+	args["someNestedPair"] = pair(pair(INT64(1), TEXT("two")), pair(INT64(3), INT64(4)))
+	args["someUglyNesting"] = testVariant{tag: "Neither", value: UNIT{}}
+
+	err := v.ValidateCreate(oneOfAll, args)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	messages := make([]string, len(validationErr.Errors))
+	for i, fieldErr := range validationErr.Errors {
+		messages[i] = fieldErr.Error()
+	}
+	require.Equal(t, []string{
+		"someDecimal: 1.12345678901 is out of range for Numeric 10",
+		"someEnum: unknown constructor Purple of enum AllKindsOf:Color, expected one of Red, Green, Blue",
+		"someInteger: expected Int64, got Text",
+		"someNestedPair.left.right: expected Int64, got Text",
+		"someSimpleList[1]: expected Int64, got Record",
+		"someText: missing field",
+		"someTxt: unknown field",
+		"someUglyNesting: unknown constructor Neither of variant AllKindsOf:VPair",
+	}, messages)
+	require.ErrorContains(t, err, "invalid arguments of "+oneOfAll)
+}
+
+func TestValidateExercise(t *testing.T) {
+	v := loadDar(t, allKindsDar)
+
+	require.NoError(t, v.ValidateExercise(oneOfAll, "Accept", map[string]interface{}{}))
+	require.ErrorContains(t, v.ValidateExercise(oneOfAll, "Accept", map[string]interface{}{"extra": INT64(1)}),
+		"invalid argument of choice Accept on "+oneOfAll+": extra: unknown field")
+	require.ErrorContains(t, v.ValidateExercise(oneOfAll, "Reject", nil), "choice Reject not found")
+	require.ErrorContains(t, v.ValidateCreate("#all-kinds-of:AllKindsOf:Nothing", nil), "not found in registered packages")
+
+	v = loadDar(t, interfacesDar)
+	asset := "#amulets-interface-test:Interfaces:Asset"
+	require.NoError(t, v.ValidateExercise(asset, "Transfer", map[string]interface{}{"newOwner": PARTY("Bob")}))
+	require.NoError(t, v.ValidateExercise("#amulets-interface-test:Interfaces:Transferable", "Transfer", map[string]interface{}{"newOwner": PARTY("Bob")}))
+
+	err := v.ValidateCommands([]*model.Command{
+		{Command: &model.CreateCommand{TemplateID: asset, Arguments: map[string]interface{}{"owner": PARTY("Alice"), "name": TEXT("gold"), "value": INT64(1)}}},
+		{Command: &model.ExerciseCommand{TemplateID: asset, ContractID: "00aa", Choice: "AssetTransfer", Arguments: map[string]interface{}{"newOwner": "Bob"}}},
+	})
+	require.EqualError(t, err, "command 1: invalid argument of choice AssetTransfer on "+asset+": newOwner: expected Party, got Text")
+}